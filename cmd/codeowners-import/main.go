@@ -0,0 +1,66 @@
+// codeowners-import загружает файл CODEOWNERS формата GitHub в правила владельцев
+// кода команды, как POST /team/importCodeOwners, но напрямую в базу.
+//
+//	CONFIG_PATH=./config/local.yaml codeowners-import -team backend -file .github/CODEOWNERS
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"reviewer-service/internal/config"
+	"reviewer-service/internal/domain/codeowners"
+	logUtil "reviewer-service/internal/lib/logger/slog"
+	"reviewer-service/internal/storage/postgresql"
+)
+
+func main() {
+	teamName := flag.String("team", "", "team whose code owners rules are replaced")
+	file := flag.String("file", "CODEOWNERS", "path to the CODEOWNERS file, - for stdin")
+	flag.Parse()
+
+	if *teamName == "" {
+		fmt.Fprintln(os.Stderr, "usage: codeowners-import -team <team_name> [-file <path>]")
+		os.Exit(2)
+	}
+
+	appConfig := config.MustLoadConfig()
+	log := config.MustConfigureLogger(appConfig.Env)
+
+	var content io.Reader = os.Stdin
+	if *file != "-" {
+		f, err := os.Open(*file)
+		if err != nil {
+			log.Error("Failed to open CODEOWNERS file", logUtil.Err(err))
+			os.Exit(1)
+		}
+		defer f.Close()
+		content = f
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), appConfig.Datasource.Timeout)
+	defer cancel()
+
+	storage, err := postgresql.NewStorage(&appConfig.Datasource, ctx)
+	if err != nil {
+		log.Error("Failed to connect to database", logUtil.Err(err))
+		os.Exit(1)
+	}
+	defer storage.Close()
+
+	result, err := codeowners.Import(ctx, log, storage, storage, *teamName, content)
+	if err != nil {
+		log.Error("Failed to import CODEOWNERS", logUtil.Err(err))
+		os.Exit(1)
+	}
+
+	fmt.Printf("imported %d rules for team %s\n", len(result.Rules), *teamName)
+	for _, handle := range result.UnknownHandles {
+		fmt.Printf("line %d: unknown owner %s\n", handle.Line, handle.Handle)
+	}
+	for _, line := range result.InvalidLines {
+		fmt.Printf("line %d: unsupported pattern, skipped\n", line)
+	}
+}
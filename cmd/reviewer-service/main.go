@@ -2,18 +2,33 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
+	"net"
 	"net/http"
+	"os"
+	"reviewer-service/internal/availability"
 	"reviewer-service/internal/config"
-	"reviewer-service/internal/http-server/handlers/pullrequest"
-	"reviewer-service/internal/http-server/handlers/team"
-	"reviewer-service/internal/http-server/handlers/user"
-	"reviewer-service/internal/http-server/middleware/logger"
+	"reviewer-service/internal/domain/auth"
+	domainPR "reviewer-service/internal/domain/pullrequest"
+	"reviewer-service/internal/githost"
+	"reviewer-service/internal/githost/github"
+	grpcserver "reviewer-service/internal/grpc-server"
+	authMiddleware "reviewer-service/internal/http-server/middleware/auth"
+	rateLimitMiddleware "reviewer-service/internal/http-server/middleware/ratelimit"
+	"reviewer-service/internal/http-server/router"
+	"reviewer-service/internal/lib/jwt"
 	logUtil "reviewer-service/internal/lib/logger/slog"
+	"reviewer-service/internal/lib/netguard"
+	"reviewer-service/internal/lib/random"
+	"reviewer-service/internal/lib/ratelimit"
+	"reviewer-service/internal/notifier"
+	"reviewer-service/internal/outbox"
+	"reviewer-service/internal/sla"
 	"reviewer-service/internal/storage/postgresql"
-
-	"github.com/go-chi/chi/v5"
-	"github.com/go-chi/chi/v5/middleware"
+	"reviewer-service/internal/stream"
+	reviewerv1 "reviewer-service/pkg/api/reviewer/v1"
+	"slices"
 )
 
 func main() {
@@ -29,45 +44,222 @@ func main() {
 	}
 	defer storage.Close()
 
-	router := chi.NewRouter()
-	router.Use(middleware.RequestID)
-	router.Use(logger.New(log))
-	router.Use(middleware.Recoverer)
-	router.Use(middleware.URLFormat)
+	var tokens *jwt.Verifier
+	if appConfig.Auth.Enabled && appConfig.Auth.JWT.Enabled {
+		if appConfig.Auth.JWT.Issuer == "" || appConfig.Auth.JWT.Audience == "" {
+			log.Error("JWT auth is enabled without issuer or audience")
+			os.Exit(1)
+		}
+		if slices.Contains(appConfig.Auth.JWT.Scopes, auth.ScopeAdmin) {
+			log.Error("JWT user scopes must not include admin, grant the admin role instead")
+			os.Exit(1)
+		}
+
+		tokens, err = jwt.NewVerifier(context.Background(), log, jwt.Config{
+			JWKS:     appConfig.Auth.JWT.JWKS,
+			Issuer:   appConfig.Auth.JWT.Issuer,
+			Audience: appConfig.Auth.JWT.Audience,
+		})
+		if err != nil {
+			log.Error("Failed to load JWKS", logUtil.Err(err))
+			os.Exit(1)
+		}
+	}
+
+	if appConfig.Webhooks.GitHub.Enabled && appConfig.Webhooks.GitHub.Secret == "" {
+		log.Error("GitHub webhooks are enabled without a secret")
+		os.Exit(1)
+	}
+
+	if appConfig.Webhooks.GitLab.Enabled && appConfig.Webhooks.GitLab.Token == "" {
+		log.Error("GitLab webhooks are enabled without a token")
+		os.Exit(1)
+	}
+
+	if appConfig.Subscriptions.Enabled && !appConfig.Outbox.Enabled {
+		log.Error("Subscriptions require the outbox to be enabled")
+		os.Exit(1)
+	}
+
+	if appConfig.Notifications.Enabled && !appConfig.Outbox.Enabled {
+		log.Error("Notifications require the outbox to be enabled")
+		os.Exit(1)
+	}
+
+	if appConfig.Stream.Enabled && !appConfig.Outbox.Enabled {
+		log.Error("Event stream requires the outbox to be enabled")
+		os.Exit(1)
+	}
+
+	// Хаб остается nil, если поток событий выключен
+	var eventHub *stream.Hub
 
-	router.Post(
-		"/team/add", team.Save(log, storage, storage),
-	)
+	subscriptionTargets, err := netguard.New(appConfig.Subscriptions.AllowedTargets)
+	if err != nil {
+		log.Error("Invalid subscriptions.allowed_targets", logUtil.Err(err))
+		os.Exit(1)
+	}
 
-	router.Get(
-		"/team/get", team.Get(log, storage),
-	)
+	if appConfig.Outbox.Enabled {
+		sink, err := newOutboxSink(appConfig.Outbox)
+		if err != nil {
+			log.Error("Failed to configure outbox", logUtil.Err(err))
+			os.Exit(1)
+		}
+		sinks := outbox.MultiSink{sink}
 
-	router.Post(
-		"/users/setIsActive", user.SetIsActive(log, storage, storage),
-	)
+		if appConfig.Subscriptions.Enabled {
+			sinks = append(sinks, outbox.NewSubscriptionSink(storage))
 
-	router.Get(
-		"/users/getReview", user.GetReview(log, storage),
-	)
+			outbox.NewDeliverer(log, storage, storage, outbox.DeliveryOptions{
+				PollInterval:   appConfig.Subscriptions.PollInterval,
+				BatchSize:      appConfig.Subscriptions.BatchSize,
+				MaxAttempts:    appConfig.Subscriptions.MaxAttempts,
+				InitialBackoff: appConfig.Subscriptions.InitialBackoff,
+				MaxBackoff:     appConfig.Subscriptions.MaxBackoff,
+				Timeout:        appConfig.Subscriptions.Timeout,
+				Targets:        subscriptionTargets,
+			}).Start(context.Background())
+		}
 
-	router.Post(
-		"/pullRequest/create", pullrequest.Create(log, storage, storage),
-	)
+		if appConfig.Notifications.Enabled {
+			chatNotifier, err := notifier.New(log, storage, notifier.Options{
+				AssignedTemplate:   appConfig.Notifications.Templates.Assigned,
+				ReassignedTemplate: appConfig.Notifications.Templates.Reassigned,
+				MentionFormat:      appConfig.Notifications.MentionFormat,
+				Timeout:            appConfig.Notifications.Timeout,
+			})
+			if err != nil {
+				log.Error("Failed to configure notifications", logUtil.Err(err))
+				os.Exit(1)
+			}
 
-	router.Post(
-		"/pullRequest/merge", pullrequest.Merge(log, storage, storage),
-	)
+			sinks = append(sinks, chatNotifier)
+		}
 
-	router.Post(
-		"/pullRequest/reassign", pullrequest.Reassign(log, storage, storage),
-	)
+		if appConfig.Stream.Enabled {
+			eventHub = stream.NewHub(appConfig.Stream.BufferSize)
+			sinks = append(sinks, eventHub)
+		}
+
+		outbox.NewDispatcher(log, storage, storage, sinks, outbox.Options{
+			PollInterval:   appConfig.Outbox.PollInterval,
+			BatchSize:      appConfig.Outbox.BatchSize,
+			MaxAttempts:    appConfig.Outbox.MaxAttempts,
+			InitialBackoff: appConfig.Outbox.InitialBackoff,
+			MaxBackoff:     appConfig.Outbox.MaxBackoff,
+			Lease:          appConfig.Outbox.Lease,
+		}).Start(context.Background())
+	}
+
+	selectionRandom := random.New(appConfig.Selection.Seed)
+
+	// Интерфейс остается nil, если синхронизация выключена
+	var reviewerSyncer domainPR.ReviewerSyncer
+	if appConfig.GitHost.GitHub.Enabled {
+		syncer := githost.NewSyncer(log,
+			github.NewClient(appConfig.GitHost.GitHub.APIURL, appConfig.GitHost.GitHub.Token, appConfig.GitHost.GitHub.Timeout),
+			storage,
+			githost.Options{MaxAttempts: appConfig.GitHost.GitHub.MaxAttempts},
+		)
+		syncer.Start(context.Background())
+		reviewerSyncer = syncer
+	}
+
+	if appConfig.SLA.Enabled {
+		sla.NewScheduler(log, storage, storage, storage, reviewerSyncer, sla.Options{
+			PollInterval: appConfig.SLA.PollInterval,
+			BatchSize:    appConfig.SLA.BatchSize,
+			Random:       selectionRandom,
+		}).Start(context.Background())
+	}
+
+	if appConfig.Availability.Enabled {
+		availability.NewScheduler(log, storage, storage, storage, reviewerSyncer, availability.Options{
+			PollInterval: appConfig.Availability.PollInterval,
+			BatchSize:    appConfig.Availability.BatchSize,
+			Random:       selectionRandom,
+		}).Start(context.Background())
+	}
+
+	authOptions := authMiddleware.Options{
+		APIKeys:      storage,
+		BootstrapKey: appConfig.Auth.BootstrapKey,
+		Tokens:       tokens,
+		Users:        storage,
+		Roles:        storage,
+		UserClaim:    appConfig.Auth.JWT.UserClaim,
+		UserScopes:   appConfig.Auth.JWT.Scopes,
+	}
+
+	if appConfig.GrpcServer.Enabled {
+		grpcOptions := grpcserver.Options{}
+		if appConfig.Auth.Enabled {
+			grpcOptions.Auth = &authOptions
+		}
+
+		grpcServer := grpcserver.New(log, grpcOptions)
+		reviewerv1.RegisterTeamServiceServer(grpcServer, grpcserver.NewTeamService(log, storage, storage))
+		reviewerv1.RegisterUserServiceServer(grpcServer, grpcserver.NewUserService(log, storage, storage, storage))
+		reviewerv1.RegisterPullRequestServiceServer(grpcServer, grpcserver.NewPullRequestService(log, storage, storage, reviewerSyncer, selectionRandom))
+
+		listener, err := net.Listen("tcp", appConfig.GrpcServer.Host+":"+appConfig.GrpcServer.Port)
+		if err != nil {
+			log.Error("Failed to listen for gRPC", logUtil.Err(err))
+			os.Exit(1)
+		}
+
+		log.Info("starting grpc server", slog.String("address", listener.Addr().String()))
+
+		go func() {
+			if err := grpcServer.Serve(listener); err != nil {
+				log.Error("grpc server error", logUtil.Err(err))
+			}
+		}()
+	}
+
+	routerOptions := router.Options{
+		SeedHeader:       appConfig.IsTest(),
+		ValidateRequests: appConfig.HttpServer.OpenAPI.ValidateRequests,
+		LegacyRoutes:     appConfig.HttpServer.LegacyRoutes,
+		EventHub:         eventHub,
+		StreamHeartbeat:  appConfig.Stream.Heartbeat,
+		Syncer:           reviewerSyncer,
+		Random:           selectionRandom,
+
+		SubscriptionTargets: subscriptionTargets,
+	}
+	if appConfig.Auth.Enabled {
+		routerOptions.Auth = &authOptions
+	}
+	if appConfig.HttpServer.RateLimit.Enabled {
+		rateLimit := rateLimitOptions(appConfig.HttpServer.RateLimit)
+		routerOptions.RateLimit = &rateLimit
+
+		if byAddress := appConfig.HttpServer.RateLimit.ByAddress; byAddress.RPS > 0 {
+			routerOptions.AddressRateLimit = &rateLimitMiddleware.Options{
+				Default: ratelimit.Limit{Rate: byAddress.RPS, Burst: byAddress.Burst},
+			}
+		}
+	}
+	if appConfig.Webhooks.GitHub.Enabled {
+		routerOptions.GitHubSecret = appConfig.Webhooks.GitHub.Secret
+	}
+	if appConfig.Webhooks.GitLab.Enabled {
+		routerOptions.GitLabToken = appConfig.Webhooks.GitLab.Token
+	}
+
+	handler, err := router.New(log, storage, routerOptions)
+	if err != nil {
+		log.Error("Failed to configure http router", logUtil.Err(err))
+		os.Exit(1)
+	}
 
 	log.Info("starting service", slog.String("host", appConfig.HttpServer.Host))
 
 	server := &http.Server{
 		Addr:         appConfig.HttpServer.Host + ":" + appConfig.HttpServer.Port,
-		Handler:      router,
+		Handler:      handler,
 		ReadTimeout:  appConfig.HttpServer.Timeout,
 		WriteTimeout: appConfig.HttpServer.Timeout,
 		IdleTimeout:  appConfig.HttpServer.IdleTimeout,
@@ -78,3 +270,28 @@ func main() {
 	}
 
 }
+
+func rateLimitOptions(cfg config.RateLimit) rateLimitMiddleware.Options {
+	opts := rateLimitMiddleware.Options{
+		Default: ratelimit.Limit{Rate: cfg.Default.RPS, Burst: cfg.Default.Burst},
+		Routes:  make(map[string]ratelimit.Limit, len(cfg.Routes)),
+	}
+	for route, rule := range cfg.Routes {
+		opts.Routes[route] = ratelimit.Limit{Rate: rule.RPS, Burst: rule.Burst}
+	}
+	return opts
+}
+
+func newOutboxSink(cfg config.Outbox) (outbox.Sink, error) {
+	switch cfg.Sink {
+	case "stdout":
+		return outbox.NewWriterSink(os.Stdout), nil
+	case "webhook":
+		if cfg.WebhookURL == "" {
+			return nil, fmt.Errorf("outbox.webhook_url is required for webhook sink")
+		}
+		return outbox.NewWebhookSink(cfg.WebhookURL, cfg.WebhookTimeout), nil
+	default:
+		return nil, fmt.Errorf("unknown outbox sink %q", cfg.Sink)
+	}
+}
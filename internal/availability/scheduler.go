@@ -0,0 +1,153 @@
+package availability
+
+import (
+	"context"
+	"log/slog"
+	"reviewer-service/internal/domain/availability"
+	"reviewer-service/internal/domain/event"
+	"reviewer-service/internal/domain/pullrequest"
+	"reviewer-service/internal/lib/clock"
+	logUtil "reviewer-service/internal/lib/logger/slog"
+	"reviewer-service/internal/lib/random"
+	"reviewer-service/internal/storage"
+	"sync"
+	"time"
+)
+
+type Repository interface {
+	GetUnavailableAssignments(ctx context.Context, now time.Time, limit int) ([]*availability.Assignment, error)
+}
+
+type TransactionManager interface {
+	WithTransaction(ctx context.Context, fn func(context.Context) error) error
+}
+
+type Options struct {
+	PollInterval time.Duration
+	BatchSize    int
+	// Clock по умолчанию - системные часы
+	Clock clock.Clock
+	// Random выбирает замену среди равных кандидатов, по умолчанию - случайное зерно
+	Random pullrequest.Random
+}
+
+// Scheduler снимает отсутствующих пользователей с ревью открытых PR, когда
+// начинается их период отсутствия. Замена идет через pullrequest.ReassignReviewer
+// с причиной unavailable; ревью, уже снятые другим экземпляром, пропускаются
+type Scheduler struct {
+	log       *slog.Logger
+	txManager TransactionManager
+	repo      Repository
+	prRepo    pullrequest.Repository
+	syncer    pullrequest.ReviewerSyncer
+	opts      Options
+
+	wg sync.WaitGroup
+}
+
+func NewScheduler(log *slog.Logger, txManager TransactionManager, repo Repository, prRepo pullrequest.Repository, syncer pullrequest.ReviewerSyncer, opts Options) *Scheduler {
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = time.Minute
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = 100
+	}
+	if opts.Clock == nil {
+		opts.Clock = clock.Real{}
+	}
+	if opts.Random == nil {
+		opts.Random = random.New(0)
+	}
+
+	return &Scheduler{
+		log:       log.With(slog.String("component", "availability/scheduler")),
+		txManager: txManager,
+		repo:      repo,
+		prRepo:    prRepo,
+		syncer:    syncer,
+		opts:      opts,
+	}
+}
+
+// Start запускает проверку периодов отсутствия в отдельной горутине до отмены ctx
+func (s *Scheduler) Start(ctx context.Context) {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.run(ctx)
+	}()
+
+	s.log.Info("availability scheduler started", slog.String("poll_interval", s.opts.PollInterval.String()))
+}
+
+// Wait ждет остановки после отмены контекста Start
+func (s *Scheduler) Wait() {
+	s.wg.Wait()
+}
+
+func (s *Scheduler) run(ctx context.Context) {
+	ticker := time.NewTicker(s.opts.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := s.ReassignOnce(ctx); err != nil && ctx.Err() == nil {
+				s.log.Error("failed to reassign reviews of unavailable users", logUtil.Err(err))
+			}
+		}
+	}
+}
+
+// ReassignOnce снимает с ревью одну пачку отсутствующих пользователей и
+// возвращает число снятых. Ошибка отдельного PR не прерывает пачку: ревью
+// останется назначенным и будет обработано на следующем шаге
+func (s *Scheduler) ReassignOnce(ctx context.Context) (int, error) {
+	assignments, err := s.repo.GetUnavailableAssignments(ctx, s.opts.Clock.Now(), s.opts.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	reassigned := 0
+	for _, a := range assignments {
+		_, newReviewerId, err := pullrequest.ReassignReviewer(ctx, s.log, s.txManager, s.prRepo, s.syncer, s.opts.Random,
+			a.PullRequestId, a.ReviewerId, 0, event.AssignReasonUnavailable, false)
+		if err != nil {
+			if isSkipped(err) {
+				continue
+			}
+
+			s.log.Error("failed to reassign reviewer of unavailable user",
+				slog.String("pull_request_id", a.PullRequestId),
+				slog.String("reviewer_id", a.ReviewerId),
+				logUtil.Int64("period_id", a.PeriodId),
+				logUtil.Err(err))
+			continue
+		}
+		reassigned++
+
+		s.log.Info("unavailable reviewer reassigned",
+			slog.String("pull_request_id", a.PullRequestId),
+			slog.String("old_reviewer_id", a.ReviewerId),
+			slog.String("new_reviewer_id", newReviewerId),
+			logUtil.Int64("period_id", a.PeriodId))
+	}
+
+	return reassigned, nil
+}
+
+// isSkipped сообщает, что PR изменился после выборки: ревьювер уже снят или PR закрыт
+func isSkipped(err error) bool {
+	storageErr, ok := storage.IsError(err)
+	if !ok {
+		return false
+	}
+
+	switch storageErr.Code {
+	case storage.ErrReviewerNotAssigned.Code, storage.ErrPullRequestMerged.Code, storage.ErrPullRequestClosed.Code:
+		return true
+	}
+	return false
+}
@@ -9,9 +9,20 @@ import (
 )
 
 type Config struct {
-	Env        string `yaml:"env" required:"true"`
-	Datasource `yaml:"datasource" required:"true"`
-	HttpServer `yaml:"http_server" required:"true"`
+	Env           string `yaml:"env" required:"true"`
+	Datasource    `yaml:"datasource" required:"true"`
+	HttpServer    `yaml:"http_server" required:"true"`
+	GrpcServer    `yaml:"grpc_server"`
+	Auth          `yaml:"auth"`
+	Webhooks      `yaml:"webhooks"`
+	GitHost       `yaml:"git_host"`
+	Outbox        `yaml:"outbox"`
+	Subscriptions `yaml:"subscriptions"`
+	Notifications `yaml:"notifications"`
+	Stream        `yaml:"stream"`
+	SLA           `yaml:"sla"`
+	Availability  `yaml:"availability"`
+	Selection     `yaml:"selection"`
 }
 
 type Datasource struct {
@@ -28,6 +39,172 @@ type HttpServer struct {
 	Port        string        `yaml:"port" default:"8080"`
 	Timeout     time.Duration `yaml:"timeout" default:"5s"`
 	IdleTimeout time.Duration `yaml:"idle_timeout" default:"30s"`
+	RateLimit   RateLimit     `yaml:"rate_limit"`
+	OpenAPI     OpenAPI       `yaml:"openapi"`
+	// LegacyRoutes оставляет пути без /api/v1 с заголовком Deprecation
+	LegacyRoutes bool `yaml:"legacy_routes" env-default:"true"`
+}
+
+// OpenAPI - ValidateRequests отклоняет запросы, не соответствующие
+// спецификации /openapi.json, до вызова обработчиков
+type OpenAPI struct {
+	ValidateRequests bool `yaml:"validate_requests" default:"false"`
+}
+
+// GrpcServer - gRPC API на отдельном порту с той же аутентификацией, что и REST
+type GrpcServer struct {
+	Enabled bool   `yaml:"enabled" default:"false"`
+	Host    string `yaml:"host" env-default:"0.0.0.0"`
+	Port    string `yaml:"port" env-default:"9090"`
+}
+
+// RateLimit задает token bucket на клиента: rps - скорость пополнения, burst - емкость.
+// ByAddress - лимит по адресу до аутентификации (при включенной auth), общий
+// для всех путей; rps: 0 - без лимита по адресу
+type RateLimit struct {
+	Enabled   bool                     `yaml:"enabled" default:"false"`
+	Default   RateLimitRule            `yaml:"default"`
+	Routes    map[string]RateLimitRule `yaml:"routes"`
+	ByAddress RateLimitRule            `yaml:"by_address"`
+}
+
+type RateLimitRule struct {
+	RPS   float64 `yaml:"rps"`
+	Burst int     `yaml:"burst"`
+}
+
+type Auth struct {
+	Enabled      bool   `yaml:"enabled" default:"false"`
+	BootstrapKey string `yaml:"bootstrap_key" env:"AUTH_BOOTSTRAP_KEY"`
+	JWT          JWT    `yaml:"jwt"`
+}
+
+// JWT описывает проверку Bearer токенов OIDC провайдера
+type JWT struct {
+	Enabled bool `yaml:"enabled" default:"false"`
+	// JWKS - путь к файлу или http(s) URL с ключами провайдера
+	JWKS     string `yaml:"jwks"`
+	Issuer   string `yaml:"issuer"`
+	Audience string `yaml:"audience"`
+	// UserClaim - claim, значение которого совпадает с users.user_id
+	UserClaim string   `yaml:"user_claim" env-default:"sub"`
+	Scopes    []string `yaml:"scopes"`
+}
+
+type Webhooks struct {
+	GitHub GitHubWebhook `yaml:"github"`
+	GitLab GitLabWebhook `yaml:"gitlab"`
+}
+
+type GitHubWebhook struct {
+	Enabled bool `yaml:"enabled" default:"false"`
+	// Secret - секрет вебхука, которым GitHub подписывает X-Hub-Signature-256
+	Secret string `yaml:"secret" env:"GITHUB_WEBHOOK_SECRET"`
+}
+
+type GitLabWebhook struct {
+	Enabled bool `yaml:"enabled" default:"false"`
+	// Token - секретный токен, который GitLab передает в X-Gitlab-Token
+	Token string `yaml:"token" env:"GITLAB_WEBHOOK_TOKEN"`
+}
+
+// GitHost описывает исходящую синхронизацию ревьюверов с Git хостингом
+type GitHost struct {
+	GitHub GitHubAPI `yaml:"github"`
+}
+
+type GitHubAPI struct {
+	Enabled bool   `yaml:"enabled" default:"false"`
+	APIURL  string `yaml:"api_url" env-default:"https://api.github.com"`
+	// Token - токен с правом pull_requests:write
+	Token       string        `yaml:"token" env:"GITHUB_TOKEN"`
+	Timeout     time.Duration `yaml:"timeout" env-default:"10s"`
+	MaxAttempts int           `yaml:"max_attempts" env-default:"5"`
+}
+
+// Outbox описывает рассылку доменных событий из таблицы outbox
+type Outbox struct {
+	Enabled      bool          `yaml:"enabled" default:"false"`
+	PollInterval time.Duration `yaml:"poll_interval" env-default:"1s"`
+	BatchSize    int           `yaml:"batch_size" env-default:"100"`
+	// После MaxAttempts неудачных попыток событие помечается failed_at и больше
+	// не задерживает следующие события своего агрегата
+	MaxAttempts    int           `yaml:"max_attempts" env-default:"10"`
+	InitialBackoff time.Duration `yaml:"initial_backoff" env-default:"1s"`
+	MaxBackoff     time.Duration `yaml:"max_backoff" env-default:"5m"`
+	// Lease - время, на которое диспетчер забирает пачку событий на отправку
+	Lease time.Duration `yaml:"lease" env-default:"1m"`
+	// Sink - stdout или webhook
+	Sink           string        `yaml:"sink" env-default:"stdout"`
+	WebhookURL     string        `yaml:"webhook_url" env:"OUTBOX_WEBHOOK_URL"`
+	WebhookTimeout time.Duration `yaml:"webhook_timeout" env-default:"5s"`
+}
+
+// Subscriptions описывает доставку событий внешним подписчикам. События
+// поступают из outbox, поэтому он тоже должен быть включен
+type Subscriptions struct {
+	Enabled        bool          `yaml:"enabled" default:"false"`
+	PollInterval   time.Duration `yaml:"poll_interval" env-default:"1s"`
+	BatchSize      int           `yaml:"batch_size" env-default:"50"`
+	MaxAttempts    int           `yaml:"max_attempts" env-default:"8"`
+	InitialBackoff time.Duration `yaml:"initial_backoff" env-default:"5s"`
+	MaxBackoff     time.Duration `yaml:"max_backoff" env-default:"1h"`
+	Timeout        time.Duration `yaml:"timeout" env-default:"5s"`
+	// AllowedTargets - подсети (CIDR или IP), куда разрешены подписки, хотя они
+	// loopback, link-local или частные. Остальные такие адреса запрещены
+	AllowedTargets []string `yaml:"allowed_targets"`
+}
+
+// Notifications описывает уведомления о назначениях в чат команды.
+// Адрес вебхука задается для каждой команды, события берутся из outbox
+type Notifications struct {
+	Enabled bool          `yaml:"enabled" default:"false"`
+	Timeout time.Duration `yaml:"timeout" env-default:"5s"`
+	// MentionFormat - формат упоминания по chat handle, например "<@%s>" или "@%s"
+	MentionFormat string                `yaml:"mention_format" env-default:"<@%s>"`
+	Templates     NotificationTemplates `yaml:"templates"`
+}
+
+// NotificationTemplates - шаблоны text/template; пустой шаблон заменяется встроенным
+type NotificationTemplates struct {
+	Assigned   string `yaml:"assigned"`
+	Reassigned string `yaml:"reassigned"`
+}
+
+// Stream описывает поток событий GET /events/stream (SSE). События приходят
+// из outbox, поэтому он тоже должен быть включен
+type Stream struct {
+	Enabled bool `yaml:"enabled" default:"false"`
+	// BufferSize - сколько событий копится для клиента, прежде чем его отключат
+	BufferSize int           `yaml:"buffer_size" env-default:"64"`
+	Heartbeat  time.Duration `yaml:"heartbeat" env-default:"15s"`
+}
+
+// SLA описывает фоновую проверку SLA ревью. Сами сроки задаются командами
+// через POST /team/setReviewSla
+type SLA struct {
+	Enabled      bool          `yaml:"enabled" default:"false"`
+	PollInterval time.Duration `yaml:"poll_interval" env-default:"1m"`
+	BatchSize    int           `yaml:"batch_size" env-default:"100"`
+}
+
+// Availability описывает фоновую замену ревьюверов, у которых начался период отсутствия
+type Availability struct {
+	Enabled      bool          `yaml:"enabled" default:"false"`
+	PollInterval time.Duration `yaml:"poll_interval" env-default:"1m"`
+	BatchSize    int           `yaml:"batch_size" env-default:"100"`
+}
+
+// Selection описывает выбор ревьюверов среди равных кандидатов. В окружении test
+// зерно можно задать и для отдельного запроса заголовком X-Selection-Seed
+type Selection struct {
+	// Seed - зерно генератора случайного выбора, 0 - новое при каждом запуске
+	Seed int64 `yaml:"seed" env:"SELECTION_SEED"`
+}
+
+// IsTest сообщает, запущен ли сервис в тестовом окружении
+func (c *Config) IsTest() bool {
+	return c.Env == testEnv
 }
 
 func MustLoadConfig() *Config {
@@ -0,0 +1,13 @@
+package apikey
+
+import "time"
+
+type Model struct {
+	ID        int64
+	Name      string
+	Prefix    string
+	KeyHash   string
+	Scopes    []string
+	CreatedAt *time.Time
+	RevokedAt *time.Time
+}
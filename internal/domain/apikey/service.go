@@ -0,0 +1,118 @@
+package apikey
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"log/slog"
+	"reviewer-service/internal/domain/auth"
+	"reviewer-service/internal/storage"
+	"strconv"
+	"strings"
+)
+
+const (
+	keyPrefix    = "rs_"
+	prefixLength = 8
+)
+
+type Repository interface {
+	CreateAPIKey(ctx context.Context, key *Model) (int64, error)
+	GetAPIKey(ctx context.Context, id int64) (*Model, error)
+	GetAPIKeyByHash(ctx context.Context, keyHash string) (*Model, error)
+	ListAPIKeys(ctx context.Context) ([]*Model, error)
+	RevokeAPIKey(ctx context.Context, id int64) (*Model, error)
+}
+
+// CreateAPIKey выпускает новый ключ. Открытое значение возвращается только здесь,
+// в базе хранится лишь его SHA-256
+func CreateAPIKey(ctx context.Context, log *slog.Logger, repo Repository, name string, scopes []string) (*Model, string, error) {
+	for _, scope := range scopes {
+		if !auth.IsKnownScope(scope) {
+			return nil, "", storage.ErrUnknownScope
+		}
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, "", err
+	}
+	rawKey := keyPrefix + base64.RawURLEncoding.EncodeToString(secret)
+
+	key := &Model{
+		Name:    name,
+		Prefix:  rawKey[:len(keyPrefix)+prefixLength],
+		KeyHash: HashKey(rawKey),
+		Scopes:  scopes,
+	}
+
+	id, err := repo.CreateAPIKey(ctx, key)
+	if err != nil {
+		return nil, "", err
+	}
+
+	createdKey, err := repo.GetAPIKey(ctx, id)
+	if err != nil {
+		return nil, "", err
+	}
+
+	log.Info("api key created",
+		slog.Int64("id", id),
+		slog.String("name", name),
+		slog.String("actor", auth.Actor(ctx)))
+
+	return createdKey, rawKey, nil
+}
+
+func ListAPIKeys(ctx context.Context, log *slog.Logger, repo Repository) ([]*Model, error) {
+	keys, err := repo.ListAPIKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("api keys retrieved", slog.Int("count", len(keys)))
+	return keys, nil
+}
+
+func RevokeAPIKey(ctx context.Context, log *slog.Logger, repo Repository, id int64) (*Model, error) {
+	revokedKey, err := repo.RevokeAPIKey(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("api key revoked", slog.Int64("id", id), slog.String("actor", auth.Actor(ctx)))
+	return revokedKey, nil
+}
+
+// Authenticate находит действующий ключ по его открытому значению
+func Authenticate(ctx context.Context, repo Repository, rawKey string) (*auth.Principal, error) {
+	if !strings.HasPrefix(rawKey, keyPrefix) {
+		return nil, storage.ErrUnauthorized
+	}
+
+	key, err := repo.GetAPIKeyByHash(ctx, HashKey(rawKey))
+	if err != nil {
+		if storageErr, ok := storage.IsError(err); ok && storageErr == storage.ErrAPIKeyNotFound {
+			return nil, storage.ErrUnauthorized
+		}
+		return nil, err
+	}
+
+	if key.RevokedAt != nil {
+		return nil, storage.ErrUnauthorized
+	}
+
+	return &auth.Principal{
+		Kind:   auth.KindAPIKey,
+		Id:     strconv.FormatInt(key.ID, 10),
+		Name:   key.Name,
+		Scopes: key.Scopes,
+	}, nil
+}
+
+func HashKey(rawKey string) string {
+	sum := sha256.Sum256([]byte(rawKey))
+	return hex.EncodeToString(sum[:])
+}
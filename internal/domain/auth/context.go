@@ -0,0 +1,41 @@
+package auth
+
+import (
+	"context"
+	"reviewer-service/internal/storage"
+)
+
+type principalKey struct{}
+
+func WithPrincipal(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+func FromContext(ctx context.Context) (*Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(*Principal)
+	return p, ok
+}
+
+// Actor возвращает идентификатор субъекта для логов и аудита
+func Actor(ctx context.Context) string {
+	if p, ok := FromContext(ctx); ok {
+		return p.Actor()
+	}
+	return "anonymous"
+}
+
+// ResolveUserId определяет пользователя, от имени которого выполняется запрос.
+// Для пользователя с токеном идентификатор берется из токена, а чужой user_id запрещен;
+// для остальных субъектов используется переданный requestedUserId
+func ResolveUserId(ctx context.Context, requestedUserId string) (string, error) {
+	p, ok := FromContext(ctx)
+	if !ok || !p.IsUser() {
+		return requestedUserId, nil
+	}
+
+	if requestedUserId != "" && requestedUserId != p.Id {
+		return "", storage.ErrForbidden
+	}
+
+	return p.Id, nil
+}
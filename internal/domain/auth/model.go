@@ -0,0 +1,51 @@
+package auth
+
+import "slices"
+
+const (
+	KindAPIKey = "api_key"
+	KindUser   = "user"
+	KindSystem = "system"
+)
+
+const (
+	ScopeRead       = "read"
+	ScopeTeamsWrite = "teams:write"
+	ScopeUsersWrite = "users:write"
+	ScopePRsWrite   = "prs:write"
+	ScopeAdmin      = "admin"
+)
+
+var KnownScopes = []string{
+	ScopeRead,
+	ScopeTeamsWrite,
+	ScopeUsersWrite,
+	ScopePRsWrite,
+	ScopeAdmin,
+}
+
+// Principal - аутентифицированный субъект запроса.
+// Для KindUser поле Id содержит users.user_id
+type Principal struct {
+	Kind   string
+	Id     string
+	Name   string
+	Scopes []string
+}
+
+func (p *Principal) IsUser() bool {
+	return p.Kind == KindUser
+}
+
+// HasScope сообщает, выдан ли субъекту scope; admin включает все остальные
+func (p *Principal) HasScope(scope string) bool {
+	return slices.Contains(p.Scopes, ScopeAdmin) || slices.Contains(p.Scopes, scope)
+}
+
+func (p *Principal) Actor() string {
+	return p.Kind + ":" + p.Name
+}
+
+func IsKnownScope(scope string) bool {
+	return slices.Contains(KnownScopes, scope)
+}
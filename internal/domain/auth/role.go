@@ -0,0 +1,154 @@
+package auth
+
+import (
+	"context"
+	"log/slog"
+	"reviewer-service/internal/storage"
+	"slices"
+)
+
+// Роли пользователей. member есть у каждого пользователя его команды и не хранится,
+// team_lead выдается на конкретную команду, admin - глобально
+const (
+	RoleAdmin    = "admin"
+	RoleTeamLead = "team_lead"
+	RoleMember   = "member"
+)
+
+type Role struct {
+	ID       int64
+	UserId   string
+	Role     string
+	TeamName string
+}
+
+type Roles []*Role
+
+func (r Roles) IsAdmin() bool {
+	return slices.ContainsFunc(r, func(role *Role) bool {
+		return role.Role == RoleAdmin
+	})
+}
+
+func (r Roles) IsLeadOf(teamName string) bool {
+	return slices.ContainsFunc(r, func(role *Role) bool {
+		return role.Role == RoleTeamLead && role.TeamName == teamName
+	})
+}
+
+type RoleRepository interface {
+	GetUserRoles(ctx context.Context, userId string) ([]*Role, error)
+}
+
+type RoleAdminRepository interface {
+	RoleRepository
+	GrantRole(ctx context.Context, role *Role) (*Role, error)
+	RevokeRole(ctx context.Context, role *Role) error
+	ListRoles(ctx context.Context) ([]*Role, error)
+	GetTeamExists(ctx context.Context, teamName string) (bool, error)
+}
+
+// Authorize пропускает пользователя из allowedUsers, лида любой из leadOfTeams и админа.
+// Субъекты без пользователя проверку проходят всегда: API ключ - сервисный аккаунт,
+// ограниченный только scope, а системные вызовы (вебхуки, планировщики) и запросы
+// с выключенной аутентификацией субъекта не имеют. Это часть контракта API
+func Authorize(ctx context.Context, repo RoleRepository, allowedUsers []string, leadOfTeams []string) error {
+	p, ok := FromContext(ctx)
+	if !ok || !p.IsUser() {
+		return nil
+	}
+
+	if slices.Contains(allowedUsers, p.Id) {
+		return nil
+	}
+
+	roles, err := repo.GetUserRoles(ctx, p.Id)
+	if err != nil {
+		return err
+	}
+
+	if Roles(roles).IsAdmin() {
+		return nil
+	}
+	for _, teamName := range leadOfTeams {
+		if Roles(roles).IsLeadOf(teamName) {
+			return nil
+		}
+	}
+
+	return storage.ErrForbidden
+}
+
+func GrantRole(ctx context.Context, log *slog.Logger, repo RoleAdminRepository, role *Role) (*Role, error) {
+	if err := validateRole(ctx, repo, role); err != nil {
+		return nil, err
+	}
+
+	grantedRole, err := repo.GrantRole(ctx, role)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("role granted",
+		slog.String("user_id", role.UserId),
+		slog.String("role", role.Role),
+		slog.String("team_name", role.TeamName),
+		slog.String("actor", Actor(ctx)))
+
+	return grantedRole, nil
+}
+
+func RevokeRole(ctx context.Context, log *slog.Logger, repo RoleAdminRepository, role *Role) error {
+	if err := repo.RevokeRole(ctx, role); err != nil {
+		return err
+	}
+
+	log.Info("role revoked",
+		slog.String("user_id", role.UserId),
+		slog.String("role", role.Role),
+		slog.String("team_name", role.TeamName),
+		slog.String("actor", Actor(ctx)))
+
+	return nil
+}
+
+func ListRoles(ctx context.Context, log *slog.Logger, repo RoleAdminRepository, userId string) ([]*Role, error) {
+	var roles []*Role
+	var err error
+
+	if userId != "" {
+		roles, err = repo.GetUserRoles(ctx, userId)
+	} else {
+		roles, err = repo.ListRoles(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("roles retrieved", slog.Int("count", len(roles)))
+	return roles, nil
+}
+
+func validateRole(ctx context.Context, repo RoleAdminRepository, role *Role) error {
+	switch role.Role {
+	case RoleAdmin:
+		if role.TeamName != "" {
+			return storage.ErrInvalidRole
+		}
+	case RoleTeamLead:
+		if role.TeamName == "" {
+			return storage.ErrInvalidRole
+		}
+		exists, err := repo.GetTeamExists(ctx, role.TeamName)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return storage.ErrTeamNotFound
+		}
+	default:
+		return storage.ErrInvalidRole
+	}
+
+	return nil
+}
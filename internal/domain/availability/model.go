@@ -0,0 +1,22 @@
+package availability
+
+import "time"
+
+// Period - время [StartsAt, EndsAt), когда пользователь не может ревьюить. Пока
+// период идет, пользователь не выбирается ревьювером, а его ревью открытых PR
+// переназначаются
+type Period struct {
+	ID        int64
+	UserId    string
+	StartsAt  time.Time
+	EndsAt    time.Time
+	Reason    string
+	CreatedAt time.Time
+}
+
+// Assignment - ревью открытого PR, назначенное пользователю, который сейчас отсутствует
+type Assignment struct {
+	PullRequestId string
+	ReviewerId    string
+	PeriodId      int64
+}
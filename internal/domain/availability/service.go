@@ -0,0 +1,91 @@
+package availability
+
+import (
+	"context"
+	"log/slog"
+	"reviewer-service/internal/domain/auth"
+	"reviewer-service/internal/domain/user"
+)
+
+type Repository interface {
+	CreateUnavailability(ctx context.Context, p *Period) (int64, error)
+	GetUnavailability(ctx context.Context, id int64) (*Period, error)
+	ListUnavailability(ctx context.Context, userId string) ([]*Period, error)
+	DeleteUnavailability(ctx context.Context, id int64) error
+	GetUserByUserId(ctx context.Context, userId string) (*user.Model, error)
+	GetUserRoles(ctx context.Context, userId string) ([]*auth.Role, error)
+}
+
+// AddPeriod добавляет период отсутствия. Задать его может сам пользователь,
+// лид его команды или админ
+func AddPeriod(ctx context.Context, log *slog.Logger, repo Repository, p *Period) (*Period, error) {
+	if err := authorize(ctx, repo, p.UserId); err != nil {
+		return nil, err
+	}
+
+	id, err := repo.CreateUnavailability(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := repo.GetUnavailability(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("unavailability period created",
+		slog.Int64("id", id),
+		slog.String("user_id", p.UserId),
+		slog.Time("starts_at", created.StartsAt),
+		slog.Time("ends_at", created.EndsAt),
+		slog.String("actor", auth.Actor(ctx)))
+
+	return created, nil
+}
+
+// ListPeriods возвращает периоды пользователя по времени начала
+func ListPeriods(ctx context.Context, log *slog.Logger, repo Repository, userId string) ([]*Period, error) {
+	if err := authorize(ctx, repo, userId); err != nil {
+		return nil, err
+	}
+
+	periods, err := repo.ListUnavailability(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("unavailability periods retrieved", slog.String("user_id", userId), slog.Int("count", len(periods)))
+
+	return periods, nil
+}
+
+func DeletePeriod(ctx context.Context, log *slog.Logger, repo Repository, id int64) error {
+	p, err := repo.GetUnavailability(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := authorize(ctx, repo, p.UserId); err != nil {
+		return err
+	}
+
+	if err := repo.DeleteUnavailability(ctx, id); err != nil {
+		return err
+	}
+
+	log.Info("unavailability period deleted",
+		slog.Int64("id", id),
+		slog.String("user_id", p.UserId),
+		slog.String("actor", auth.Actor(ctx)))
+
+	return nil
+}
+
+func authorize(ctx context.Context, repo Repository, userId string) error {
+	targetUser, err := repo.GetUserByUserId(ctx, userId)
+	if err != nil {
+		return err
+	}
+
+	return auth.Authorize(ctx, repo, []string{userId}, []string{targetUser.TeamName})
+}
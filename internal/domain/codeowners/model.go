@@ -0,0 +1,139 @@
+package codeowners
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+var errInvalidPattern = errors.New("invalid code owners pattern")
+
+// Rule - правило в стиле CODEOWNERS: изменения файлов, подходящих под Pattern,
+// лучше ревьюить Owners. Как и в CODEOWNERS, для файла действует последнее
+// подходящее правило команды (по Position); правило без Owners снимает владельцев
+type Rule struct {
+	ID       int64
+	TeamName string
+	Position int
+	Pattern  string
+	Owners   []string
+}
+
+// Match - владелец, выбранный по измененным файлам, и шаблон правила, по которому
+// он найден
+type Match struct {
+	UserId  string
+	Pattern string
+}
+
+// UnknownHandle - владелец из файла CODEOWNERS, не сопоставленный с участником команды
+type UnknownHandle struct {
+	Line   int
+	Handle string
+}
+
+// ImportResult - итог импорта CODEOWNERS: сохраненные правила, пропущенные владельцы
+// и строки с шаблонами, которые не поддерживаются
+type ImportResult struct {
+	Rules          []*Rule
+	UnknownHandles []UnknownHandle
+	InvalidLines   []int
+}
+
+// MatchOwners возвращает владельцев для измененных файлов: в порядке файлов, внутри
+// правила - в порядке Owners, каждого владельца один раз. Правила с невалидным
+// шаблоном пропускаются
+func MatchOwners(rules []*Rule, files []string) []Match {
+	compiled := make([]*regexp.Regexp, len(rules))
+	for i, rule := range rules {
+		compiled[i], _ = compilePattern(rule.Pattern)
+	}
+
+	matches := make([]Match, 0)
+	seen := make(map[string]bool)
+
+	for _, file := range files {
+		file = strings.TrimPrefix(strings.TrimSpace(file), "/")
+		if file == "" {
+			continue
+		}
+
+		var matched *Rule
+		for i, rule := range rules {
+			if compiled[i] != nil && compiled[i].MatchString(file) {
+				matched = rule
+			}
+		}
+		if matched == nil {
+			continue
+		}
+
+		for _, owner := range matched.Owners {
+			if seen[owner] {
+				continue
+			}
+			seen[owner] = true
+			matches = append(matches, Match{UserId: owner, Pattern: matched.Pattern})
+		}
+	}
+
+	return matches
+}
+
+// ValidatePattern проверяет, что шаблон можно использовать в правиле
+func ValidatePattern(pattern string) error {
+	_, err := compilePattern(pattern)
+	return err
+}
+
+// compilePattern переводит шаблон CODEOWNERS в регулярное выражение:
+//   - "*" - любые символы, кроме "/", "?" - один такой символ, "**" - любое число каталогов;
+//   - шаблон с "/" в начале или в середине привязан к корню репозитория,
+//     без "/" - совпадает с именем на любой глубине;
+//   - шаблон, совпавший с каталогом, совпадает со всеми файлами внутри него
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	pattern = strings.TrimSpace(pattern)
+	if pattern == "" || strings.ContainsAny(pattern, " \t") || strings.HasPrefix(pattern, "!") {
+		return nil, errInvalidPattern
+	}
+
+	dirOnly := strings.HasSuffix(pattern, "/")
+	pattern = strings.TrimSuffix(pattern, "/")
+	anchored := strings.Contains(pattern, "/")
+	pattern = strings.TrimPrefix(pattern, "/")
+	if pattern == "" {
+		return nil, errInvalidPattern
+	}
+
+	var expr strings.Builder
+	if anchored {
+		expr.WriteString("^")
+	} else {
+		expr.WriteString("^(?:.*/)?")
+	}
+
+	for i := 0; i < len(pattern); i++ {
+		switch {
+		case strings.HasPrefix(pattern[i:], "**/"):
+			expr.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(pattern[i:], "**"):
+			expr.WriteString(".*")
+			i++
+		case pattern[i] == '*':
+			expr.WriteString("[^/]*")
+		case pattern[i] == '?':
+			expr.WriteString("[^/]")
+		default:
+			expr.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		}
+	}
+
+	if dirOnly {
+		expr.WriteString("/.*$")
+	} else {
+		expr.WriteString("(?:/.*)?$")
+	}
+
+	return regexp.Compile(expr.String())
+}
@@ -0,0 +1,117 @@
+package codeowners
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidatePattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		valid   bool
+	}{
+		{pattern: "*", valid: true},
+		{pattern: "*.go", valid: true},
+		{pattern: "/internal/storage/", valid: true},
+		{pattern: "docs/**/*.md", valid: true},
+		{pattern: "", valid: false},
+		{pattern: "/", valid: false},
+		{pattern: "!docs/", valid: false},
+		{pattern: "a b", valid: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			err := ValidatePattern(tt.pattern)
+			if tt.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestMatchOwners(t *testing.T) {
+	tests := []struct {
+		name  string
+		rules []*Rule
+		files []string
+		want  []Match
+	}{
+		{
+			name:  "unanchored name matches at any depth",
+			rules: []*Rule{{Pattern: "*.sql", Owners: []string{"u1"}}},
+			files: []string{"migrations/001.sql", "main.go"},
+			want:  []Match{{UserId: "u1", Pattern: "*.sql"}},
+		},
+		{
+			name:  "anchored directory matches files inside",
+			rules: []*Rule{{Pattern: "/internal/storage/", Owners: []string{"u1"}}},
+			files: []string{"/internal/storage/pg/repo.go"},
+			want:  []Match{{UserId: "u1", Pattern: "/internal/storage/"}},
+		},
+		{
+			name:  "anchored pattern does not match nested path",
+			rules: []*Rule{{Pattern: "/internal/storage/", Owners: []string{"u1"}}},
+			files: []string{"pkg/internal/storage/repo.go", "internal/storage"},
+			want:  []Match{},
+		},
+		{
+			name:  "single star does not cross directories",
+			rules: []*Rule{{Pattern: "/cmd/*.go", Owners: []string{"u1"}}},
+			files: []string{"cmd/tool/main.go"},
+			want:  []Match{},
+		},
+		{
+			name:  "double star crosses directories",
+			rules: []*Rule{{Pattern: "docs/**/*.md", Owners: []string{"u1"}}},
+			files: []string{"docs/api/v1/index.md"},
+			want:  []Match{{UserId: "u1", Pattern: "docs/**/*.md"}},
+		},
+		{
+			name: "last matching rule wins",
+			rules: []*Rule{
+				{Pattern: "*", Owners: []string{"u1"}},
+				{Pattern: "*.go", Owners: []string{"u2", "u3"}},
+			},
+			files: []string{"main.go"},
+			want:  []Match{{UserId: "u2", Pattern: "*.go"}, {UserId: "u3", Pattern: "*.go"}},
+		},
+		{
+			name: "rule without owners removes owners",
+			rules: []*Rule{
+				{Pattern: "*", Owners: []string{"u1"}},
+				{Pattern: "docs/", Owners: []string{}},
+			},
+			files: []string{"docs/guide.md"},
+			want:  []Match{},
+		},
+		{
+			name: "owner once in file order",
+			rules: []*Rule{
+				{Pattern: "*.go", Owners: []string{"u2", "u1"}},
+				{Pattern: "*.sql", Owners: []string{"u1", "u3"}},
+			},
+			files: []string{"a.sql", "b.go", "  ", "c.sql"},
+			want: []Match{
+				{UserId: "u1", Pattern: "*.sql"},
+				{UserId: "u3", Pattern: "*.sql"},
+				{UserId: "u2", Pattern: "*.go"},
+			},
+		},
+		{
+			name:  "invalid rule is skipped",
+			rules: []*Rule{{Pattern: "!main.go", Owners: []string{"u1"}}},
+			files: []string{"main.go"},
+			want:  []Match{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, MatchOwners(tt.rules, tt.files))
+		})
+	}
+}
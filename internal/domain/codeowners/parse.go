@@ -0,0 +1,51 @@
+package codeowners
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+// Entry - строка файла CODEOWNERS: шаблон и владельцы в том виде, как они записаны
+type Entry struct {
+	Line    int
+	Pattern string
+	Handles []string
+}
+
+// Parse разбирает файл CODEOWNERS в формате GitHub. Пустые строки и комментарии
+// пропускаются, "\#" в начале шаблона означает символ "#"
+func Parse(r io.Reader) ([]Entry, error) {
+	entries := make([]Entry, 0)
+
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+
+		entry := Entry{
+			Line:    line,
+			Pattern: strings.TrimPrefix(fields[0], `\`),
+			Handles: make([]string, 0, len(fields)-1),
+		}
+		for _, field := range fields[1:] {
+			if strings.HasPrefix(field, "#") {
+				break
+			}
+			entry.Handles = append(entry.Handles, field)
+		}
+
+		entries = append(entries, entry)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
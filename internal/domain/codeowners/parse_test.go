@@ -0,0 +1,64 @@
+package codeowners
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []Entry
+	}{
+		{
+			name:    "empty",
+			content: "",
+			want:    []Entry{},
+		},
+		{
+			name:    "comments and blank lines",
+			content: "# owners\n\n   \n  # indented comment\n",
+			want:    []Entry{},
+		},
+		{
+			name:    "owners",
+			content: "*.go @alice @acme/backend dev@example.com\n",
+			want:    []Entry{{Line: 1, Pattern: "*.go", Handles: []string{"@alice", "@acme/backend", "dev@example.com"}}},
+		},
+		{
+			name:    "trailing comment",
+			content: "/docs/   @bob   # documentation @carol\n",
+			want:    []Entry{{Line: 1, Pattern: "/docs/", Handles: []string{"@bob"}}},
+		},
+		{
+			name:    "pattern without owners",
+			content: "# header\ndocs/\n",
+			want:    []Entry{{Line: 2, Pattern: "docs/", Handles: []string{}}},
+		},
+		{
+			name:    "escaped hash",
+			content: `\#notes.md @alice`,
+			want:    []Entry{{Line: 1, Pattern: "#notes.md", Handles: []string{"@alice"}}},
+		},
+		{
+			name:    "tabs and crlf",
+			content: "*.sql\t@dba\r\n\r\n/api/ @alice\r\n",
+			want: []Entry{
+				{Line: 1, Pattern: "*.sql", Handles: []string{"@dba"}},
+				{Line: 3, Pattern: "/api/", Handles: []string{"@alice"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(strings.NewReader(tt.content))
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
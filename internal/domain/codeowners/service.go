@@ -0,0 +1,229 @@
+package codeowners
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"reviewer-service/internal/domain/auth"
+	"reviewer-service/internal/domain/team"
+	"reviewer-service/internal/storage"
+	"slices"
+	"strings"
+)
+
+type Repository interface {
+	ReplaceCodeOwnerRules(ctx context.Context, teamName string, rules []*Rule) error
+	GetCodeOwnerRules(ctx context.Context, teamName string) ([]*Rule, error)
+	GetTeamByName(ctx context.Context, name string) (*team.Model, error)
+	GetUserIdByGitLogin(ctx context.Context, provider string, login string) (string, error)
+	GetUserRoles(ctx context.Context, userId string) ([]*auth.Role, error)
+}
+
+type TransactionManager interface {
+	WithTransaction(ctx context.Context, fn func(context.Context) error) error
+}
+
+// SetRules заменяет правила команды целиком, порядок правил сохраняется. Владельцами
+// могут быть только участники команды. Пустой список удаляет все правила
+func SetRules(ctx context.Context, log *slog.Logger, txManager TransactionManager, repo Repository, teamName string, rules []*Rule) ([]*Rule, error) {
+	if err := auth.Authorize(ctx, repo, nil, []string{teamName}); err != nil {
+		return nil, err
+	}
+
+	var saved []*Rule
+
+	err := txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		teamModel, err := repo.GetTeamByName(txCtx, teamName)
+		if err != nil {
+			return err
+		}
+
+		members := make(map[string]bool, len(teamModel.Members))
+		for _, member := range teamModel.Members {
+			members[member.UserId] = true
+		}
+
+		for i, rule := range rules {
+			if err := ValidatePattern(rule.Pattern); err != nil {
+				return storage.ErrInvalidCodeOwnerPattern
+			}
+			for _, owner := range rule.Owners {
+				if !members[owner] {
+					return storage.ErrCodeOwnerNotMember
+				}
+			}
+			rule.TeamName = teamName
+			rule.Position = i
+		}
+
+		if err := repo.ReplaceCodeOwnerRules(txCtx, teamName, rules); err != nil {
+			return err
+		}
+
+		saved, err = repo.GetCodeOwnerRules(txCtx, teamName)
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("team code owners updated",
+		slog.String("team_name", teamName),
+		slog.Int("rules", len(saved)),
+		slog.String("actor", auth.Actor(ctx)))
+
+	return saved, nil
+}
+
+// identityProvider - хостинг, логины которого записаны в CODEOWNERS формата GitHub
+const identityProvider = "github"
+
+// Import заменяет правила команды правилами из файла CODEOWNERS формата GitHub.
+// @login сопоставляется с пользователем через git_identities, а если привязки нет -
+// с user_id, @org/team - со всеми участниками команды сервиса с таким именем. Владельцы,
+// которые не оказались участниками команды (в том числе другие команды), и строки с неподдерживаемыми шаблонами
+// не прерывают импорт, а возвращаются в результате. Правила заменяются в одной
+// транзакции, поэтому при ошибке остаются прежними
+func Import(ctx context.Context, log *slog.Logger, txManager TransactionManager, repo Repository, teamName string, content io.Reader) (*ImportResult, error) {
+	if err := auth.Authorize(ctx, repo, nil, []string{teamName}); err != nil {
+		return nil, err
+	}
+
+	entries, err := Parse(content)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ImportResult{
+		UnknownHandles: make([]UnknownHandle, 0),
+		InvalidLines:   make([]int, 0),
+	}
+
+	err = txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		teamModel, err := repo.GetTeamByName(txCtx, teamName)
+		if err != nil {
+			return err
+		}
+
+		members := make(map[string]bool, len(teamModel.Members))
+		memberIds := make([]string, 0, len(teamModel.Members))
+		for _, member := range teamModel.Members {
+			members[member.UserId] = true
+			memberIds = append(memberIds, member.UserId)
+		}
+
+		rules := make([]*Rule, 0, len(entries))
+		for _, entry := range entries {
+			if err := ValidatePattern(entry.Pattern); err != nil {
+				result.InvalidLines = append(result.InvalidLines, entry.Line)
+				continue
+			}
+
+			rule := &Rule{
+				TeamName: teamName,
+				Position: len(rules),
+				Pattern:  entry.Pattern,
+				Owners:   make([]string, 0, len(entry.Handles)),
+			}
+
+			for _, handle := range entry.Handles {
+				owners, err := resolveHandle(txCtx, repo, teamName, memberIds, handle)
+				if err != nil {
+					return err
+				}
+
+				known := false
+				for _, owner := range owners {
+					if !members[owner] {
+						continue
+					}
+					known = true
+					if !slices.Contains(rule.Owners, owner) {
+						rule.Owners = append(rule.Owners, owner)
+					}
+				}
+
+				if !known {
+					result.UnknownHandles = append(result.UnknownHandles, UnknownHandle{Line: entry.Line, Handle: handle})
+				}
+			}
+
+			rules = append(rules, rule)
+		}
+
+		if err := repo.ReplaceCodeOwnerRules(txCtx, teamName, rules); err != nil {
+			return err
+		}
+
+		result.Rules, err = repo.GetCodeOwnerRules(txCtx, teamName)
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("team code owners imported",
+		slog.String("team_name", teamName),
+		slog.Int("rules", len(result.Rules)),
+		slog.Int("unknown_handles", len(result.UnknownHandles)),
+		slog.Int("invalid_lines", len(result.InvalidLines)),
+		slog.String("actor", auth.Actor(ctx)))
+
+	return result, nil
+}
+
+// resolveHandle возвращает пользователей, которых обозначает владелец из CODEOWNERS.
+// @org/team ищется среди всех команд сервиса, адреса почты не сопоставляются
+func resolveHandle(ctx context.Context, repo Repository, teamName string, memberIds []string, handle string) ([]string, error) {
+	name, ok := strings.CutPrefix(handle, "@")
+	if !ok || name == "" {
+		return nil, nil
+	}
+
+	if _, slug, isTeam := strings.Cut(name, "/"); isTeam {
+		if strings.EqualFold(slug, teamName) {
+			return memberIds, nil
+		}
+
+		ownerTeam, err := repo.GetTeamByName(ctx, slug)
+		if err != nil {
+			if storageErr, ok := storage.IsError(err); ok && storageErr == storage.ErrTeamNotFound {
+				return nil, nil
+			}
+			return nil, err
+		}
+
+		owners := make([]string, 0, len(ownerTeam.Members))
+		for _, member := range ownerTeam.Members {
+			owners = append(owners, member.UserId)
+		}
+		return owners, nil
+	}
+
+	userId, err := repo.GetUserIdByGitLogin(ctx, identityProvider, name)
+	if err != nil {
+		if storageErr, ok := storage.IsError(err); ok && storageErr == storage.ErrIdentityNotFound {
+			return []string{name}, nil
+		}
+		return nil, err
+	}
+
+	return []string{userId}, nil
+}
+
+func GetRules(ctx context.Context, log *slog.Logger, repo Repository, teamName string) ([]*Rule, error) {
+	if _, err := repo.GetTeamByName(ctx, teamName); err != nil {
+		return nil, err
+	}
+
+	rules, err := repo.GetCodeOwnerRules(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("team code owners retrieved", slog.String("team_name", teamName), slog.Int("rules", len(rules)))
+
+	return rules, nil
+}
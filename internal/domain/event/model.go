@@ -0,0 +1,117 @@
+package event
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const (
+	AggregatePullRequest = "pull_request"
+	AggregateUser        = "user"
+)
+
+const (
+	TypePullRequestCreated  = "pr.created"
+	TypePullRequestMerged   = "pr.merged"
+	TypePullRequestClosed   = "pr.closed"
+	TypePullRequestReopened = "pr.reopened"
+	TypePullRequestDrafted  = "pr.drafted"
+	TypePullRequestReady    = "pr.ready"
+	TypeReviewerAssigned    = "reviewer.assigned"
+	TypeReviewerReassigned  = "reviewer.reassigned"
+	TypeUserActivated       = "user.activated"
+	TypeUserDeactivated     = "user.deactivated"
+	TypeSLABreached         = "sla.breached"
+)
+
+// Event - доменное событие из таблицы outbox. События одного агрегата
+// (AggregateType, AggregateId) доставляются в порядке ID. TeamName - команда,
+// к которой относится событие, по ней фильтруются подписки
+type Event struct {
+	ID            int64           `json:"id"`
+	AggregateType string          `json:"aggregate_type"`
+	AggregateId   string          `json:"aggregate_id"`
+	TeamName      string          `json:"team_name,omitempty"`
+	Type          string          `json:"type"`
+	Payload       json.RawMessage `json:"payload"`
+	CreatedAt     time.Time       `json:"created_at"`
+}
+
+// Claimed - событие, взятое диспетчером на отправку, и число прошлых попыток
+type Claimed struct {
+	Event    *Event
+	Attempts int
+}
+
+// Types - все типы событий, на которые можно подписаться
+var Types = []string{
+	TypePullRequestCreated,
+	TypePullRequestMerged,
+	TypePullRequestClosed,
+	TypePullRequestReopened,
+	TypePullRequestDrafted,
+	TypePullRequestReady,
+	TypeReviewerAssigned,
+	TypeReviewerReassigned,
+	TypeUserActivated,
+	TypeUserDeactivated,
+	TypeSLABreached,
+}
+
+type PullRequestPayload struct {
+	PullRequestId     string     `json:"pull_request_id"`
+	PullRequestName   string     `json:"pull_request_name"`
+	AuthorId          string     `json:"author_id"`
+	Status            string     `json:"status"`
+	AssignedReviewers []string   `json:"assigned_reviewers"`
+	MergedAt          *time.Time `json:"merged_at,omitempty"`
+	Version           int64      `json:"version"`
+	// UnderStaffed - при создании PR не нашлось нужного числа свободных ревьюверов
+	UnderStaffed bool `json:"under_staffed,omitempty"`
+}
+
+// Причины назначения ревьювера
+const (
+	AssignReasonCreated     = "created"
+	AssignReasonReassigned  = "reassigned"
+	AssignReasonSLABreached = "sla_breached"
+	AssignReasonUnavailable = "unavailable"
+)
+
+type ReviewerAssignedPayload struct {
+	PullRequestId   string `json:"pull_request_id"`
+	PullRequestName string `json:"pull_request_name"`
+	AuthorId        string `json:"author_id"`
+	ReviewerId      string `json:"reviewer_id"`
+	Reason          string `json:"reason"`
+	// ReplacedReviewerId - ревьювер, вместо которого назначен ReviewerId
+	ReplacedReviewerId string `json:"replaced_reviewer_id,omitempty"`
+	// MatchedRule - шаблон правила владельцев кода, по которому выбран ReviewerId
+	MatchedRule string `json:"matched_rule,omitempty"`
+}
+
+type ReviewerReassignedPayload struct {
+	PullRequestId     string   `json:"pull_request_id"`
+	OldReviewerId     string   `json:"old_reviewer_id"`
+	NewReviewerId     string   `json:"new_reviewer_id,omitempty"`
+	AssignedReviewers []string `json:"assigned_reviewers"`
+	Reason            string   `json:"reason"`
+}
+
+// SLABreachedPayload - ревьювер не взялся за PR в срок SLA команды автора
+type SLABreachedPayload struct {
+	PullRequestId   string    `json:"pull_request_id"`
+	PullRequestName string    `json:"pull_request_name"`
+	AuthorId        string    `json:"author_id"`
+	ReviewerId      string    `json:"reviewer_id"`
+	AssignedAt      time.Time `json:"assigned_at"`
+	ReviewHours     int       `json:"review_hours"`
+	AutoReassign    bool      `json:"auto_reassign"`
+}
+
+type UserPayload struct {
+	UserId   string `json:"user_id"`
+	Username string `json:"username"`
+	TeamName string `json:"team_name"`
+	IsActive bool   `json:"is_active"`
+}
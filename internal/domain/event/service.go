@@ -0,0 +1,27 @@
+package event
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// Writer сохраняет событие в outbox. Должен вызываться в транзакции
+// изменения, чтобы событие не появилось при откате
+type Writer interface {
+	AddOutboxEvent(ctx context.Context, e *Event) error
+}
+
+func Record(ctx context.Context, w Writer, aggregateType string, aggregateId string, teamName string, eventType string, payload any) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	return w.AddOutboxEvent(ctx, &Event{
+		AggregateType: aggregateType,
+		AggregateId:   aggregateId,
+		TeamName:      teamName,
+		Type:          eventType,
+		Payload:       data,
+	})
+}
@@ -0,0 +1,88 @@
+package policy
+
+import (
+	"reviewer-service/internal/domain/user"
+	"slices"
+)
+
+// Constraint - требование к составу ревьюверов PR: не меньше MinCount ревьюверов
+// уровня MinSeniority или выше
+type Constraint struct {
+	MinSeniority string
+	MinCount     int
+}
+
+// Composition - требования команды к составу ревьюверов, все должны выполняться
+type Composition []Constraint
+
+// Pair - участники, которые не ревьюят PR друг друга
+type Pair struct {
+	UserId      string
+	OtherUserId string
+}
+
+// RepeatLimit - назначать ревьювера автору не больше MaxReviews раз за WindowDays
+// дней, пока есть другие кандидаты. Нулевое значение - без ограничения
+type RepeatLimit struct {
+	MaxReviews int
+	WindowDays int
+}
+
+// Policy - правила выбора ревьюверов команды
+type Policy struct {
+	TeamName      string
+	Composition   Composition
+	ExcludedPairs []Pair
+	RepeatLimit   RepeatLimit
+}
+
+// Normalized возвращает пару с идентификаторами по возрастанию: пара симметрична
+func (p Pair) Normalized() Pair {
+	if p.OtherUserId < p.UserId {
+		return Pair{UserId: p.OtherUserId, OtherUserId: p.UserId}
+	}
+	return p
+}
+
+// Sorted возвращает требования от старшего уровня к младшему: ревьюверы,
+// выбранные под старшее требование, засчитываются и младшим
+func (c Composition) Sorted() Composition {
+	sorted := slices.Clone(c)
+	slices.SortFunc(sorted, func(a, b Constraint) int {
+		return user.SeniorityRank(b.MinSeniority) - user.SeniorityRank(a.MinSeniority)
+	})
+	return sorted
+}
+
+// Missing возвращает, сколько ревьюверов не хватает для требования при уровнях levels
+func (c Constraint) Missing(levels []string) int {
+	rank := user.SeniorityRank(c.MinSeniority)
+	have := 0
+	for _, level := range levels {
+		if user.SeniorityRank(level) >= rank {
+			have++
+		}
+	}
+	return max(c.MinCount-have, 0)
+}
+
+// Satisfied сообщает, выполняются ли все требования при уровнях ревьюверов levels
+func (c Composition) Satisfied(levels []string) bool {
+	for _, constraint := range c {
+		if constraint.Missing(levels) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// RequiredSeniority возвращает самый старший уровень среди невыполненных при
+// levels требований; пустая строка - все требования выполняются
+func (c Composition) RequiredSeniority(levels []string) string {
+	for _, constraint := range c.Sorted() {
+		if constraint.Missing(levels) > 0 {
+			return constraint.MinSeniority
+		}
+	}
+	return ""
+}
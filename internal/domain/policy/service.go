@@ -0,0 +1,126 @@
+package policy
+
+import (
+	"context"
+	"log/slog"
+	"reviewer-service/internal/domain/auth"
+	"reviewer-service/internal/domain/team"
+	"reviewer-service/internal/storage"
+	"slices"
+)
+
+type Repository interface {
+	ReplaceCompositionRules(ctx context.Context, teamName string, composition Composition) error
+	GetCompositionRules(ctx context.Context, teamName string) (Composition, error)
+	ReplaceExcludedPairs(ctx context.Context, teamName string, pairs []Pair) error
+	GetExcludedPairs(ctx context.Context, teamName string) ([]Pair, error)
+	SetRepeatLimit(ctx context.Context, teamName string, limit RepeatLimit) error
+	DeleteRepeatLimit(ctx context.Context, teamName string) error
+	GetRepeatLimit(ctx context.Context, teamName string) (RepeatLimit, error)
+	GetTeamByName(ctx context.Context, name string) (*team.Model, error)
+	GetUserRoles(ctx context.Context, userId string) ([]*auth.Role, error)
+}
+
+type TransactionManager interface {
+	WithTransaction(ctx context.Context, fn func(context.Context) error) error
+}
+
+// SetPolicy заменяет правила выбора ревьюверов команды целиком: пустой состав,
+// пустой список пар и нулевой лимит снимают соответствующие правила. Обе стороны
+// исключенной пары должны быть участниками команды. Лид команды или админ
+func SetPolicy(ctx context.Context, log *slog.Logger, txManager TransactionManager, repo Repository, p *Policy) (*Policy, error) {
+	if err := auth.Authorize(ctx, repo, nil, []string{p.TeamName}); err != nil {
+		return nil, err
+	}
+
+	var saved *Policy
+
+	err := txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		teamModel, err := repo.GetTeamByName(txCtx, p.TeamName)
+		if err != nil {
+			return err
+		}
+
+		members := make(map[string]bool, len(teamModel.Members))
+		for _, member := range teamModel.Members {
+			members[member.UserId] = true
+		}
+
+		pairs := make([]Pair, 0, len(p.ExcludedPairs))
+		for _, pair := range p.ExcludedPairs {
+			if !members[pair.UserId] || !members[pair.OtherUserId] {
+				return storage.ErrExcludedPairNotMember
+			}
+			if pair = pair.Normalized(); !slices.Contains(pairs, pair) {
+				pairs = append(pairs, pair)
+			}
+		}
+
+		if err := repo.ReplaceCompositionRules(txCtx, p.TeamName, p.Composition); err != nil {
+			return err
+		}
+
+		if err := repo.ReplaceExcludedPairs(txCtx, p.TeamName, pairs); err != nil {
+			return err
+		}
+
+		if p.RepeatLimit.MaxReviews == 0 {
+			err = repo.DeleteRepeatLimit(txCtx, p.TeamName)
+		} else {
+			err = repo.SetRepeatLimit(txCtx, p.TeamName, p.RepeatLimit)
+		}
+		if err != nil {
+			return err
+		}
+
+		saved, err = loadPolicy(txCtx, repo, p.TeamName)
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("team review policy updated",
+		slog.String("team_name", p.TeamName),
+		slog.Int("composition_rules", len(saved.Composition)),
+		slog.Int("excluded_pairs", len(saved.ExcludedPairs)),
+		slog.Int("repeat_limit", saved.RepeatLimit.MaxReviews),
+		slog.String("actor", auth.Actor(ctx)))
+
+	return saved, nil
+}
+
+func GetPolicy(ctx context.Context, log *slog.Logger, repo Repository, teamName string) (*Policy, error) {
+	if _, err := repo.GetTeamByName(ctx, teamName); err != nil {
+		return nil, err
+	}
+
+	p, err := loadPolicy(ctx, repo, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("team review policy retrieved", slog.String("team_name", teamName))
+
+	return p, nil
+}
+
+func loadPolicy(ctx context.Context, repo Repository, teamName string) (*Policy, error) {
+	composition, err := repo.GetCompositionRules(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	pairs, err := repo.GetExcludedPairs(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	limit, err := repo.GetRepeatLimit(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Policy{TeamName: teamName, Composition: composition, ExcludedPairs: pairs, RepeatLimit: limit}, nil
+}
@@ -2,6 +2,9 @@ package pullrequest
 
 import "time"
 
+// ReviewersPerPR - сколько ревьюверов назначается на новый PR
+const ReviewersPerPR = 2
+
 type Model struct {
 	ID                int64
 	PullRequestId     string
@@ -11,4 +14,19 @@ type Model struct {
 	AssignedReviewers []string
 	CreatedAt         *time.Time
 	MergedAt          *time.Time
+	Version           int64
+	// RepositoryId и Number - идентичность PR в репозитории; 0, если репозиторий
+	// не указан. PullRequestId остается для обратной совместимости
+	RepositoryId int64
+	Number       int64
+	// UnderStaffed выставляет CreatePullRequest, если свободных ревьюверов
+	// (активных, доступных и не достигших лимита) меньше, чем требуется
+	// (ReviewersPerPR или число ревьюверов репозитория)
+	UnderStaffed bool
+	// ChangedFiles - пути измененных файлов для выбора ревьюверов по правилам
+	// владельцев кода; передаются при создании и не сохраняются
+	ChangedFiles []string
+	// MatchedRules - шаблон правила, по которому выбран ревьювер (reviewer_id -> pattern).
+	// Ревьюверов, выбранных обычной стратегией, здесь нет
+	MatchedRules map[string]string
 }
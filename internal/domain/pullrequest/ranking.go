@@ -0,0 +1,111 @@
+package pullrequest
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Candidate - участник команды автора, которого сейчас можно назначить ревьювером:
+// активен, не отсутствует, не достиг лимита открытых ревью и не исключен в паре
+// с автором
+type Candidate struct {
+	UserId      string
+	Seniority   string
+	OpenReviews int
+	// RepeatLimitReached - за окно лимита команды кандидат уже ревьюил автора
+	// максимальное число раз
+	RepeatLimitReached bool
+	// LastReviewedAt - когда кандидата последний раз назначали на PR автора;
+	// nil - не назначали
+	LastReviewedAt *time.Time
+}
+
+// Random задает порядок среди равных кандидатов; nil - порядок user_id
+type Random interface {
+	Shuffle(n int, swap func(i, j int))
+}
+
+// Веса оценки кандидата: владение измененными файлами важнее нагрузки,
+// нагрузка важнее давности последнего ревью автора
+const (
+	scoreOwner = 40
+	// scoreLoad получает кандидат без открытых ревью, каждое открытое ревью
+	// снимает scoreLoadStep
+	scoreLoad     = 30
+	scoreLoadStep = 10
+	// scoreRecency получает кандидат, который не ревьюил автора дольше
+	// recencyWindow; ревьюивший недавно - пропорционально меньше
+	scoreRecency  = 20
+	recencyWindow = 14 * 24 * time.Hour
+)
+
+// scoredCandidate - кандидат с оценкой rankCandidates и причинами (SelectReason*),
+// по которым он получил полный балл за владение, нагрузку или давность
+type scoredCandidate struct {
+	*Candidate
+	Score   int
+	Reasons []string
+}
+
+// scoreCandidate оценивает кандидата на момент now; owner - он владеет
+// измененными файлами PR
+func scoreCandidate(candidate *Candidate, owner bool, now time.Time) *scoredCandidate {
+	scored := &scoredCandidate{Candidate: candidate, Reasons: make([]string, 0, 3)}
+
+	if owner {
+		scored.Score += scoreOwner
+		scored.Reasons = append(scored.Reasons, SelectReasonCodeOwner)
+	}
+
+	scored.Score += max(0, scoreLoad-scoreLoadStep*candidate.OpenReviews)
+	if candidate.OpenReviews == 0 {
+		scored.Reasons = append(scored.Reasons, SelectReasonLowLoad)
+	}
+
+	elapsed := recencyWindow
+	if candidate.LastReviewedAt != nil {
+		elapsed = min(max(now.Sub(*candidate.LastReviewedAt), 0), recencyWindow)
+	}
+	scored.Score += int(scoreRecency * elapsed / recencyWindow)
+	if elapsed == recencyWindow {
+		scored.Reasons = append(scored.Reasons, SelectReasonNotRecentlyReviewed)
+	}
+
+	return scored
+}
+
+// rankCandidates возвращает кандидатов в порядке выбора: не достигшие лимита
+// повторных ревью раньше остальных, затем по убыванию оценки scoreCandidate,
+// а среди равных - по user_id или в порядке rnd. owners - владельцы измененных
+// файлов. Перемешивание начинается с порядка user_id, поэтому при одном зерне
+// результат не зависит от порядка строк в выборке
+func rankCandidates(candidates []*Candidate, owners map[string]bool, now time.Time, rnd Random) []*scoredCandidate {
+	ranked := make([]*scoredCandidate, 0, len(candidates))
+	for _, candidate := range candidates {
+		ranked = append(ranked, scoreCandidate(candidate, owners[candidate.UserId], now))
+	}
+
+	slices.SortFunc(ranked, func(a, b *scoredCandidate) int {
+		return strings.Compare(a.UserId, b.UserId)
+	})
+
+	if rnd != nil {
+		rnd.Shuffle(len(ranked), func(i, j int) {
+			ranked[i], ranked[j] = ranked[j], ranked[i]
+		})
+	}
+
+	slices.SortStableFunc(ranked, func(a, b *scoredCandidate) int {
+		if a.RepeatLimitReached != b.RepeatLimitReached {
+			if a.RepeatLimitReached {
+				return 1
+			}
+			return -1
+		}
+		return cmp.Compare(b.Score, a.Score)
+	})
+
+	return ranked
+}
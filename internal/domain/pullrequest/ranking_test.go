@@ -0,0 +1,176 @@
+package pullrequest
+
+import (
+	"reviewer-service/internal/lib/random"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScoreCandidate(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	ago := func(d time.Duration) *time.Time {
+		at := now.Add(-d)
+		return &at
+	}
+
+	tests := []struct {
+		name        string
+		candidate   *Candidate
+		owner       bool
+		wantScore   int
+		wantReasons []string
+	}{
+		{
+			name:        "free owner never reviewed author",
+			candidate:   &Candidate{UserId: "u1"},
+			owner:       true,
+			wantScore:   scoreOwner + scoreLoad + scoreRecency,
+			wantReasons: []string{SelectReasonCodeOwner, SelectReasonLowLoad, SelectReasonNotRecentlyReviewed},
+		},
+		{
+			name:        "one open review",
+			candidate:   &Candidate{UserId: "u1", OpenReviews: 1},
+			wantScore:   scoreLoad - scoreLoadStep + scoreRecency,
+			wantReasons: []string{SelectReasonNotRecentlyReviewed},
+		},
+		{
+			name:        "load score does not go below zero",
+			candidate:   &Candidate{UserId: "u1", OpenReviews: 10},
+			wantScore:   scoreRecency,
+			wantReasons: []string{SelectReasonNotRecentlyReviewed},
+		},
+		{
+			name:        "reviewed author just now",
+			candidate:   &Candidate{UserId: "u1", LastReviewedAt: ago(0)},
+			wantScore:   scoreLoad,
+			wantReasons: []string{SelectReasonLowLoad},
+		},
+		{
+			name:        "reviewed author half a window ago",
+			candidate:   &Candidate{UserId: "u1", LastReviewedAt: ago(recencyWindow / 2)},
+			wantScore:   scoreLoad + scoreRecency/2,
+			wantReasons: []string{SelectReasonLowLoad},
+		},
+		{
+			name:        "reviewed author a window ago",
+			candidate:   &Candidate{UserId: "u1", LastReviewedAt: ago(recencyWindow)},
+			wantScore:   scoreLoad + scoreRecency,
+			wantReasons: []string{SelectReasonLowLoad, SelectReasonNotRecentlyReviewed},
+		},
+		{
+			name:        "review in the future counts as just now",
+			candidate:   &Candidate{UserId: "u1", OpenReviews: 3, LastReviewedAt: ago(-time.Hour)},
+			wantScore:   0,
+			wantReasons: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := scoreCandidate(tt.candidate, tt.owner, now)
+			assert.Equal(t, tt.wantScore, got.Score)
+			assert.Equal(t, tt.wantReasons, got.Reasons)
+		})
+	}
+}
+
+func TestRankCandidates(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	recently := now.Add(-time.Hour)
+
+	userIds := func(ranked []*scoredCandidate) []string {
+		ids := make([]string, 0, len(ranked))
+		for _, candidate := range ranked {
+			ids = append(ids, candidate.UserId)
+		}
+		return ids
+	}
+
+	tests := []struct {
+		name       string
+		candidates []*Candidate
+		owners     map[string]bool
+		want       []string
+	}{
+		{
+			name: "ties in user_id order",
+			candidates: []*Candidate{
+				{UserId: "u3"}, {UserId: "u1"}, {UserId: "u2"},
+			},
+			want: []string{"u1", "u2", "u3"},
+		},
+		{
+			name: "less loaded first",
+			candidates: []*Candidate{
+				{UserId: "u1", OpenReviews: 2}, {UserId: "u2"}, {UserId: "u3", OpenReviews: 1},
+			},
+			want: []string{"u2", "u3", "u1"},
+		},
+		{
+			name: "recent reviewer of author last",
+			candidates: []*Candidate{
+				{UserId: "u1", LastReviewedAt: &recently}, {UserId: "u2"},
+			},
+			want: []string{"u2", "u1"},
+		},
+		{
+			name: "owner outweighs load",
+			candidates: []*Candidate{
+				{UserId: "u1"}, {UserId: "u2", OpenReviews: 2},
+			},
+			owners: map[string]bool{"u2": true},
+			want:   []string{"u2", "u1"},
+		},
+		{
+			name: "repeat limit goes last regardless of score",
+			candidates: []*Candidate{
+				{UserId: "u1", RepeatLimitReached: true}, {UserId: "u2", OpenReviews: 3, LastReviewedAt: &recently},
+			},
+			owners: map[string]bool{"u1": true},
+			want:   []string{"u2", "u1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, userIds(rankCandidates(tt.candidates, tt.owners, now, nil)))
+		})
+	}
+}
+
+func TestRankCandidates_Seeded(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	candidates := func() []*Candidate {
+		return []*Candidate{
+			{UserId: "u5", OpenReviews: 1}, {UserId: "u1"}, {UserId: "u4"}, {UserId: "u2"}, {UserId: "u3"},
+		}
+	}
+
+	orders := make(map[string]bool)
+	for seed := int64(1); seed <= 20; seed++ {
+		ranked := rankCandidates(candidates(), nil, now, random.New(seed))
+
+		// Перемешиваются только равные по оценке, нагруженный u5 всегда последний
+		assert.Equal(t, "u5", ranked[len(ranked)-1].UserId)
+
+		order := ""
+		for _, candidate := range ranked {
+			order += candidate.UserId
+		}
+		orders[order] = true
+
+		// Одно зерно дает один порядок независимо от порядка кандидатов на входе
+		reversed := candidates()
+		for i, j := 0, len(reversed)-1; i < j; i, j = i+1, j-1 {
+			reversed[i], reversed[j] = reversed[j], reversed[i]
+		}
+		again := rankCandidates(reversed, nil, now, random.New(seed))
+		for i := range ranked {
+			assert.Equal(t, ranked[i].UserId, again[i].UserId)
+		}
+	}
+
+	assert.Greater(t, len(orders), 1)
+}
@@ -2,29 +2,64 @@ package pullrequest
 
 import (
 	"context"
+	"errors"
 	"log/slog"
+	"reviewer-service/internal/domain/auth"
+	"reviewer-service/internal/domain/codeowners"
+	"reviewer-service/internal/domain/event"
+	"reviewer-service/internal/domain/policy"
+	"reviewer-service/internal/domain/repository"
+	"reviewer-service/internal/domain/team"
 	"reviewer-service/internal/domain/user"
 	"reviewer-service/internal/storage"
+	"slices"
+	"time"
 )
 
 type Repository interface {
 	CreatePullRequest(ctx context.Context, pr *Model) (int64, error)
 	GetPullRequestById(ctx context.Context, pullRequestId string) (*Model, error)
+	GetPullRequestByIdForUpdate(ctx context.Context, pullRequestId string) (*Model, error)
+	IncrementPullRequestVersion(ctx context.Context, pullRequestId string) error
 	AssignReviewer(ctx context.Context, pullRequestId string, reviewerId string) error
-	GetPullRequestsByReviewer(ctx context.Context, reviewerId string) ([]*Model, error)
+	GetPullRequestsByReviewer(ctx context.Context, reviewerId string, repositoryId int64) ([]*Model, error)
 	MergePullRequest(ctx context.Context, pullRequestId string) (*Model, error)
+	ClosePullRequest(ctx context.Context, pullRequestId string) (*Model, error)
+	ReopenPullRequest(ctx context.Context, pullRequestId string) (*Model, error)
+	DraftPullRequest(ctx context.Context, pullRequestId string) (*Model, error)
+	ReadyPullRequest(ctx context.Context, pullRequestId string) (*Model, error)
 	RemoveReviewer(ctx context.Context, pullRequestId string, reviewerId string) error
 	GetUserByUserId(ctx context.Context, userId string) (*user.Model, error)
-	GetActiveReviewersByTeam(ctx context.Context, teamName string, excludeUserId string, limit int) ([]string, error)
-	GetActiveReviewersByTeamExcluding(ctx context.Context, teamName string, excludeUserIds []string, limit int) ([]string, error)
+	GetReviewerCandidates(ctx context.Context, teamName string, authorId string) ([]*Candidate, error)
+	GetUserSeniorities(ctx context.Context, userIds []string) (map[string]string, error)
+	GetCompositionRules(ctx context.Context, teamName string) (policy.Composition, error)
+	GetTeamByName(ctx context.Context, name string) (*team.Model, error)
+	GetCodeOwnerRules(ctx context.Context, teamName string) ([]*codeowners.Rule, error)
+	GetRepositoryById(ctx context.Context, id int64) (*repository.Model, error)
+	GetUserRoles(ctx context.Context, userId string) ([]*auth.Role, error)
+	AddOutboxEvent(ctx context.Context, e *event.Event) error
 }
 
 type TransactionManager interface {
 	WithTransaction(ctx context.Context, fn func(context.Context) error) error
 }
 
-func CreatePullRequest(ctx context.Context, log *slog.Logger, txManager TransactionManager, repo Repository, pr *Model) (*Model, error) {
+// ReviewerSyncer переносит изменения ревьюверов на Git хостинг. Вызывается
+// после коммита и не должен блокировать; nil отключает синхронизацию
+type ReviewerSyncer interface {
+	SyncReviewers(pullRequestId string, added []string, removed []string)
+}
+
+// errDryRun откатывает транзакцию пробного запуска
+var errDryRun = errors.New("dry run")
+
+// CreatePullRequest создает PR и назначает ревьюверов. Если указан репозиторий,
+// пустой PullRequestId выводится из имени репозитория и номера PR, а число
+// ревьюверов берется из настройки репозитория. Равных кандидатов упорядочивает
+// rnd. С dryRun все изменения откатываются, а возвращается PR, который был бы создан
+func CreatePullRequest(ctx context.Context, log *slog.Logger, txManager TransactionManager, repo Repository, syncer ReviewerSyncer, rnd Random, pr *Model, dryRun bool) (*Model, error) {
 	var createdPR *Model
+	reviewersCount := ReviewersPerPR
 
 	err := txManager.WithTransaction(ctx, func(txCtx context.Context) error {
 		author, err := repo.GetUserByUserId(txCtx, pr.AuthorId)
@@ -32,19 +67,37 @@ func CreatePullRequest(ctx context.Context, log *slog.Logger, txManager Transact
 			return err
 		}
 
-		reviewers, err := repo.GetActiveReviewersByTeam(txCtx, author.TeamName, pr.AuthorId, 2)
+		if pr.RepositoryId != 0 {
+			prRepository, err := repo.GetRepositoryById(txCtx, pr.RepositoryId)
+			if err != nil {
+				return err
+			}
+			if pr.PullRequestId == "" {
+				pr.PullRequestId = prRepository.PullRequestId(pr.Number)
+			}
+			if prRepository.ReviewersCount > 0 {
+				reviewersCount = prRepository.ReviewersCount
+			}
+		}
+
+		composition, err := repo.GetCompositionRules(txCtx, author.TeamName)
+		if err != nil {
+			return err
+		}
+
+		selected, err := selectReviewers(txCtx, repo, rnd, author, pr.ChangedFiles, reviewersCount, composition)
 		if err != nil {
 			return err
 		}
 
-		pr.AssignedReviewers = reviewers
+		pr.AssignedReviewers = selected.Reviewers
 
 		_, err = repo.CreatePullRequest(txCtx, pr)
 		if err != nil {
 			return err
 		}
 
-		for _, reviewerId := range reviewers {
+		for _, reviewerId := range selected.Reviewers {
 			err := repo.AssignReviewer(txCtx, pr.PullRequestId, reviewerId)
 			if err != nil {
 				return err
@@ -55,64 +108,450 @@ func CreatePullRequest(ctx context.Context, log *slog.Logger, txManager Transact
 		if err != nil {
 			return err
 		}
+		createdPR.UnderStaffed = len(createdPR.AssignedReviewers) < reviewersCount || !selected.CompositionMet
+		createdPR.MatchedRules = selected.MatchedRules
+
+		if err := recordEvent(txCtx, repo, author.TeamName, event.TypePullRequestCreated, createdPR); err != nil {
+			return err
+		}
 
+		for _, reviewerId := range createdPR.AssignedReviewers {
+			if err := recordAssigned(txCtx, repo, author.TeamName, createdPR, reviewerId, "", event.AssignReasonCreated); err != nil {
+				return err
+			}
+		}
+
+		if dryRun {
+			return errDryRun
+		}
 		return nil
 	})
 
+	if dryRun && errors.Is(err, errDryRun) {
+		log.Info("pull request creation dry run",
+			slog.String("pull_request_id", pr.PullRequestId),
+			slog.Any("assigned_reviewers", createdPR.AssignedReviewers))
+		return createdPR, nil
+	}
+
 	if err != nil {
 		return nil, err
 	}
 
-	log.Info("pull request created", slog.String("pull_request_id", pr.PullRequestId))
+	log.Info("pull request created", slog.String("pull_request_id", pr.PullRequestId), slog.String("actor", auth.Actor(ctx)))
+
+	if createdPR.UnderStaffed {
+		log.Warn("pull request is under-staffed",
+			slog.String("pull_request_id", pr.PullRequestId),
+			slog.Int("assigned_reviewers", len(createdPR.AssignedReviewers)))
+	}
+
+	if syncer != nil && len(createdPR.AssignedReviewers) > 0 {
+		syncer.SyncReviewers(createdPR.PullRequestId, createdPR.AssignedReviewers, nil)
+	}
 
 	return createdPR, nil
 }
 
-func MergePullRequest(ctx context.Context, log *slog.Logger, txManager TransactionManager, repo Repository, pullRequestId string) (*Model, error) {
+// selection - результат selectReviewers
+type selection struct {
+	Reviewers []string
+	// Reasons - почему выбран каждый ревьювер (SelectReason*)
+	Reasons map[string]string
+	// MatchedRules - шаблоны правил выбранных владельцев кода
+	MatchedRules map[string]string
+	// OwnerRules - шаблоны правил всех владельцев измененных файлов,
+	// в том числе не выбранных
+	OwnerRules     map[string]string
+	CompositionMet bool
+	// Ranked - все кандидаты в порядке rankCandidates с их оценками
+	Ranked []*scoredCandidate
+}
+
+// selectReviewers выбирает до count ревьюверов из команды автора: сначала
+// доступных владельцев измененных файлов по правилам команды, затем недостающих
+// для требований composition ревьюверов нужного уровня, остальных - по порядку
+// rankCandidates с источником rnd. Владельцы младше требуемого уровня уступают место, если иначе
+// требование не выполнить, а владельцы сверх лимита повторных ревью не получают
+// приоритета. Исключенные пары отсекает выборка кандидатов
+func selectReviewers(ctx context.Context, repo Repository, rnd Random, author *user.Model, changedFiles []string, count int, composition policy.Composition) (*selection, error) {
+	reviewers := make([]string, 0, count)
+	reasons := make(map[string]string)
+	matchedRules := make(map[string]string)
+	ownerRules := make(map[string]string)
+
+	candidates, err := repo.GetReviewerCandidates(ctx, author.TeamName, author.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	var owners []codeowners.Match
+	if len(changedFiles) > 0 {
+		rules, err := repo.GetCodeOwnerRules(ctx, author.TeamName)
+		if err != nil {
+			return nil, err
+		}
+		owners = codeowners.MatchOwners(rules, changedFiles)
+	}
+
+	isOwner := make(map[string]bool, len(owners))
+	for _, owner := range owners {
+		ownerRules[owner.UserId] = owner.Pattern
+		isOwner[owner.UserId] = true
+	}
+
+	ranked := rankCandidates(candidates, isOwner, time.Now(), rnd)
+	seniorities := make(map[string]string, len(ranked))
+	byId := make(map[string]*scoredCandidate, len(ranked))
+	for _, candidate := range ranked {
+		seniorities[candidate.UserId] = candidate.Seniority
+		byId[candidate.UserId] = candidate
+	}
+
+	for _, owner := range owners {
+		if len(reviewers) == count {
+			break
+		}
+		if candidate, ok := byId[owner.UserId]; ok && !candidate.RepeatLimitReached {
+			reviewers = append(reviewers, owner.UserId)
+			reasons[owner.UserId] = SelectReasonCodeOwner
+			matchedRules[owner.UserId] = owner.Pattern
+		}
+	}
+
+	for _, constraint := range composition.Sorted() {
+		missing := constraint.Missing(levelsOf(reviewers, seniorities))
+		if missing == 0 {
+			continue
+		}
+
+		rank := user.SeniorityRank(constraint.MinSeniority)
+		for count-len(reviewers) < missing {
+			i := slices.IndexFunc(reviewers, func(userId string) bool {
+				return user.SeniorityRank(seniorities[userId]) < rank
+			})
+			if i < 0 {
+				break
+			}
+			delete(reasons, reviewers[i])
+			delete(matchedRules, reviewers[i])
+			reviewers = slices.Delete(reviewers, i, i+1)
+		}
+
+		for _, candidate := range ranked {
+			if missing == 0 || len(reviewers) == count {
+				break
+			}
+			if user.SeniorityRank(candidate.Seniority) >= rank && !slices.Contains(reviewers, candidate.UserId) {
+				reviewers = append(reviewers, candidate.UserId)
+				reasons[candidate.UserId] = SelectReasonComposition
+				missing--
+			}
+		}
+	}
+
+	compositionMet := composition.Satisfied(levelsOf(reviewers, seniorities))
+
+	for _, candidate := range ranked {
+		if len(reviewers) == count {
+			break
+		}
+		if !slices.Contains(reviewers, candidate.UserId) {
+			reviewers = append(reviewers, candidate.UserId)
+			reasons[candidate.UserId] = SelectReasonRank
+		}
+	}
+
+	return &selection{
+		Reviewers:      reviewers,
+		Reasons:        reasons,
+		MatchedRules:   matchedRules,
+		OwnerRules:     ownerRules,
+		CompositionMet: compositionMet,
+		Ranked:         ranked,
+	}, nil
+}
+
+// levelsOf возвращает уровни ревьюверов, без уровня - пустая строка
+func levelsOf(userIds []string, seniorities map[string]string) []string {
+	levels := make([]string, len(userIds))
+	for i, userId := range userIds {
+		levels[i] = seniorities[userId]
+	}
+	return levels
+}
+
+// MergePullRequest помечает PR как MERGED. Если expectedVersion не равен нулю,
+// версия PR должна с ним совпадать (If-Match), иначе возвращается ErrPullRequestVersion
+func MergePullRequest(ctx context.Context, log *slog.Logger, txManager TransactionManager, repo Repository, pullRequestId string, expectedVersion int64) (*Model, error) {
 	var mergedPR *Model
 
 	err := txManager.WithTransaction(ctx, func(txCtx context.Context) error {
-		pr, err := repo.GetPullRequestById(txCtx, pullRequestId)
+		pr, err := repo.GetPullRequestByIdForUpdate(txCtx, pullRequestId)
 		if err != nil {
 			return err
 		}
 
+		// Мержить может только автор PR или админ. Права проверяются до версии,
+		// чтобы постороннему не раскрывалась текущая версия PR
+		if err := auth.Authorize(txCtx, repo, []string{pr.AuthorId}, nil); err != nil {
+			return err
+		}
+
+		if expectedVersion != 0 && pr.Version != expectedVersion {
+			return storage.ErrPullRequestVersion
+		}
+
 		if pr.Status == "MERGED" {
 			mergedPR = pr
 			return nil
 		}
 
+		if pr.Status == "CLOSED" {
+			return storage.ErrPullRequestClosed
+		}
+
+		if pr.Status == "DRAFT" {
+			return storage.ErrPullRequestDraft
+		}
+
 		mergedPR, err = repo.MergePullRequest(txCtx, pullRequestId)
 		if err != nil {
 			return err
 		}
 
-		return nil
+		teamName, err := authorTeam(txCtx, repo, mergedPR.AuthorId)
+		if err != nil {
+			return err
+		}
+
+		return recordEvent(txCtx, repo, teamName, event.TypePullRequestMerged, mergedPR)
 	})
 
 	if err != nil {
 		return nil, err
 	}
 
-	log.Info("pull request merged", slog.String("pull_request_id", pullRequestId))
+	log.Info("pull request merged", slog.String("pull_request_id", pullRequestId), slog.String("actor", auth.Actor(ctx)))
 
 	return mergedPR, nil
 }
 
-func ReassignReviewer(ctx context.Context, log *slog.Logger, txManager TransactionManager, repo Repository, pullRequestId string, oldReviewerId string) (*Model, string, error) {
+// ClosePullRequest помечает открытый PR или черновик как CLOSED (закрыт без мержа).
+// Для уже закрытого или смерженного PR ничего не меняется
+func ClosePullRequest(ctx context.Context, log *slog.Logger, txManager TransactionManager, repo Repository, pullRequestId string) (*Model, error) {
+	var closedPR *Model
+
+	err := txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		pr, err := repo.GetPullRequestByIdForUpdate(txCtx, pullRequestId)
+		if err != nil {
+			return err
+		}
+
+		if err := auth.Authorize(txCtx, repo, []string{pr.AuthorId}, nil); err != nil {
+			return err
+		}
+
+		if pr.Status != "OPEN" && pr.Status != "DRAFT" {
+			closedPR = pr
+			return nil
+		}
+
+		closedPR, err = repo.ClosePullRequest(txCtx, pullRequestId)
+		if err != nil {
+			return err
+		}
+
+		teamName, err := authorTeam(txCtx, repo, closedPR.AuthorId)
+		if err != nil {
+			return err
+		}
+
+		return recordEvent(txCtx, repo, teamName, event.TypePullRequestClosed, closedPR)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("pull request closed", slog.String("pull_request_id", pullRequestId), slog.String("actor", auth.Actor(ctx)))
+
+	return closedPR, nil
+}
+
+// ReopenPullRequest возвращает закрытый PR в статус OPEN с прежними ревьюверами
+func ReopenPullRequest(ctx context.Context, log *slog.Logger, txManager TransactionManager, repo Repository, pullRequestId string) (*Model, error) {
+	var reopenedPR *Model
+
+	err := txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		pr, err := repo.GetPullRequestByIdForUpdate(txCtx, pullRequestId)
+		if err != nil {
+			return err
+		}
+
+		if err := auth.Authorize(txCtx, repo, []string{pr.AuthorId}, nil); err != nil {
+			return err
+		}
+
+		if pr.Status == "MERGED" {
+			return storage.ErrPullRequestMerged
+		}
+
+		if pr.Status != "CLOSED" {
+			reopenedPR = pr
+			return nil
+		}
+
+		reopenedPR, err = repo.ReopenPullRequest(txCtx, pullRequestId)
+		if err != nil {
+			return err
+		}
+
+		teamName, err := authorTeam(txCtx, repo, reopenedPR.AuthorId)
+		if err != nil {
+			return err
+		}
+
+		return recordEvent(txCtx, repo, teamName, event.TypePullRequestReopened, reopenedPR)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("pull request reopened", slog.String("pull_request_id", pullRequestId), slog.String("actor", auth.Actor(ctx)))
+
+	return reopenedPR, nil
+}
+
+// DraftPullRequest переводит открытый PR в статус DRAFT: ревьюверы сохраняются,
+// но PR не учитывается в их нагрузке и SLA до ReadyPullRequest.
+// Для PR в другом статусе ничего не меняется
+func DraftPullRequest(ctx context.Context, log *slog.Logger, txManager TransactionManager, repo Repository, pullRequestId string) (*Model, error) {
+	var draftPR *Model
+
+	err := txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		pr, err := repo.GetPullRequestByIdForUpdate(txCtx, pullRequestId)
+		if err != nil {
+			return err
+		}
+
+		if err := auth.Authorize(txCtx, repo, []string{pr.AuthorId}, nil); err != nil {
+			return err
+		}
+
+		if pr.Status != "OPEN" {
+			draftPR = pr
+			return nil
+		}
+
+		draftPR, err = repo.DraftPullRequest(txCtx, pullRequestId)
+		if err != nil {
+			return err
+		}
+
+		teamName, err := authorTeam(txCtx, repo, draftPR.AuthorId)
+		if err != nil {
+			return err
+		}
+
+		return recordEvent(txCtx, repo, teamName, event.TypePullRequestDrafted, draftPR)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("pull request converted to draft", slog.String("pull_request_id", pullRequestId), slog.String("actor", auth.Actor(ctx)))
+
+	return draftPR, nil
+}
+
+// ReadyPullRequest возвращает черновик в статус OPEN с прежними ревьюверами.
+// Для PR в другом статусе ничего не меняется
+func ReadyPullRequest(ctx context.Context, log *slog.Logger, txManager TransactionManager, repo Repository, pullRequestId string) (*Model, error) {
+	var readyPR *Model
+
+	err := txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		pr, err := repo.GetPullRequestByIdForUpdate(txCtx, pullRequestId)
+		if err != nil {
+			return err
+		}
+
+		if err := auth.Authorize(txCtx, repo, []string{pr.AuthorId}, nil); err != nil {
+			return err
+		}
+
+		if pr.Status != "DRAFT" {
+			readyPR = pr
+			return nil
+		}
+
+		readyPR, err = repo.ReadyPullRequest(txCtx, pullRequestId)
+		if err != nil {
+			return err
+		}
+
+		teamName, err := authorTeam(txCtx, repo, readyPR.AuthorId)
+		if err != nil {
+			return err
+		}
+
+		return recordEvent(txCtx, repo, teamName, event.TypePullRequestReady, readyPR)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("pull request ready for review", slog.String("pull_request_id", pullRequestId), slog.String("actor", auth.Actor(ctx)))
+
+	return readyPR, nil
+}
+
+// ReassignReviewer заменяет ревьювера на другого активного участника его команды.
+// Строка PR блокируется на время транзакции, поэтому конкурентные переназначения
+// одного PR выполняются последовательно; expectedVersion работает как в MergePullRequest.
+// reason (event.AssignReason*) сохраняется в событиях переназначения. При нарушении
+// SLA ревьювер без замены не снимается, возвращается ErrNoReplacementCandidate.
+// Если без старого ревьювера нарушатся требования команды к составу, замена
+// выбирается из того же уровня; когда такой замены нет, возвращается
+// ErrNoReplacementCandidate, а для отсутствующего ревьювера - обычная замена.
+// Среди равных кандидатов замену выбирает rnd. С dryRun изменения откатываются
+func ReassignReviewer(ctx context.Context, log *slog.Logger, txManager TransactionManager, repo Repository, syncer ReviewerSyncer, rnd Random, pullRequestId string, oldReviewerId string, expectedVersion int64, reason string, dryRun bool) (*Model, string, error) {
 	var updatedPR *Model
 	var newReviewerId string
 
 	err := txManager.WithTransaction(ctx, func(txCtx context.Context) error {
-		pr, err := repo.GetPullRequestById(txCtx, pullRequestId)
+		pr, err := repo.GetPullRequestByIdForUpdate(txCtx, pullRequestId)
 		if err != nil {
 			return err
 		}
 
+		oldReviewer, err := repo.GetUserByUserId(txCtx, oldReviewerId)
+		if err != nil {
+			return err
+		}
+
+		// Переназначать может автор, назначенный ревьювер, лид команды ревьюверов или админ.
+		// Права проверяются до версии и статуса PR
+		allowedUsers := append([]string{pr.AuthorId}, pr.AssignedReviewers...)
+		if err := auth.Authorize(txCtx, repo, allowedUsers, []string{oldReviewer.TeamName}); err != nil {
+			return err
+		}
+
+		if expectedVersion != 0 && pr.Version != expectedVersion {
+			return storage.ErrPullRequestVersion
+		}
+
 		if pr.Status == "MERGED" {
 			return storage.ErrPullRequestMerged
 		}
 
+		if pr.Status == "CLOSED" {
+			return storage.ErrPullRequestClosed
+		}
+
 		isAssigned := false
 		for _, reviewerId := range pr.AssignedReviewers {
 			if reviewerId == oldReviewerId {
@@ -125,21 +564,41 @@ func ReassignReviewer(ctx context.Context, log *slog.Logger, txManager Transacti
 			return storage.ErrReviewerNotAssigned
 		}
 
-		oldReviewer, err := repo.GetUserByUserId(txCtx, oldReviewerId)
+		requiredSeniority, err := replacementSeniority(txCtx, repo, oldReviewer.TeamName, pr.AssignedReviewers, oldReviewerId)
 		if err != nil {
 			return err
 		}
 
-		excludeList := []string{oldReviewerId, pr.AuthorId}
-		for _, reviewerId := range pr.AssignedReviewers {
-			if reviewerId != oldReviewerId {
-				excludeList = append(excludeList, reviewerId)
+		candidates, err := repo.GetReviewerCandidates(txCtx, oldReviewer.TeamName, pr.AuthorId)
+		if err != nil {
+			return err
+		}
+
+		candidates = slices.DeleteFunc(candidates, func(candidate *Candidate) bool {
+			return slices.Contains(pr.AssignedReviewers, candidate.UserId)
+		})
+		// Измененные файлы при замене неизвестны, владение не учитывается
+		ranked := rankCandidates(candidates, nil, time.Now(), rnd)
+
+		if requiredSeniority != "" {
+			rank := user.SeniorityRank(requiredSeniority)
+			i := slices.IndexFunc(ranked, func(candidate *scoredCandidate) bool {
+				return user.SeniorityRank(candidate.Seniority) >= rank
+			})
+
+			if i >= 0 {
+				newReviewerId = ranked[i].UserId
+			} else if reason != event.AssignReasonUnavailable {
+				return storage.ErrNoReplacementCandidate
 			}
 		}
 
-		candidates, err := repo.GetActiveReviewersByTeamExcluding(txCtx, oldReviewer.TeamName, excludeList, 1)
-		if err != nil {
-			return err
+		if newReviewerId == "" && len(ranked) > 0 {
+			newReviewerId = ranked[0].UserId
+		}
+
+		if newReviewerId == "" && reason == event.AssignReasonSLABreached {
+			return storage.ErrNoReplacementCandidate
 		}
 
 		err = repo.RemoveReviewer(txCtx, pullRequestId, oldReviewerId)
@@ -147,22 +606,55 @@ func ReassignReviewer(ctx context.Context, log *slog.Logger, txManager Transacti
 			return err
 		}
 
-		if len(candidates) > 0 {
-			newReviewerId = candidates[0]
+		if newReviewerId != "" {
 			err = repo.AssignReviewer(txCtx, pullRequestId, newReviewerId)
 			if err != nil {
 				return err
 			}
 		}
 
+		err = repo.IncrementPullRequestVersion(txCtx, pullRequestId)
+		if err != nil {
+			return err
+		}
+
 		updatedPR, err = repo.GetPullRequestById(txCtx, pullRequestId)
 		if err != nil {
 			return err
 		}
 
+		err = event.Record(txCtx, repo, event.AggregatePullRequest, pullRequestId, oldReviewer.TeamName, event.TypeReviewerReassigned, event.ReviewerReassignedPayload{
+			PullRequestId:     pullRequestId,
+			OldReviewerId:     oldReviewerId,
+			NewReviewerId:     newReviewerId,
+			AssignedReviewers: nonNil(updatedPR.AssignedReviewers),
+			Reason:            reason,
+		})
+		if err != nil {
+			return err
+		}
+
+		if newReviewerId != "" {
+			err = recordAssigned(txCtx, repo, oldReviewer.TeamName, updatedPR, newReviewerId, oldReviewerId, reason)
+			if err != nil {
+				return err
+			}
+		}
+
+		if dryRun {
+			return errDryRun
+		}
 		return nil
 	})
 
+	if dryRun && errors.Is(err, errDryRun) {
+		log.Info("reviewer reassignment dry run",
+			slog.String("pull_request_id", pullRequestId),
+			slog.String("old_reviewer_id", oldReviewerId),
+			slog.String("new_reviewer_id", newReviewerId))
+		return updatedPR, newReviewerId, nil
+	}
+
 	if err != nil {
 		return nil, "", err
 	}
@@ -170,22 +662,112 @@ func ReassignReviewer(ctx context.Context, log *slog.Logger, txManager Transacti
 	log.Info("reviewer reassigned",
 		slog.String("pull_request_id", pullRequestId),
 		slog.String("old_reviewer_id", oldReviewerId),
-		slog.String("new_reviewer_id", newReviewerId))
+		slog.String("new_reviewer_id", newReviewerId),
+		slog.String("reason", reason),
+		slog.String("actor", auth.Actor(ctx)))
+
+	if syncer != nil {
+		var added []string
+		if newReviewerId != "" {
+			added = []string{newReviewerId}
+		}
+		syncer.SyncReviewers(pullRequestId, added, []string{oldReviewerId})
+	}
 
 	return updatedPR, newReviewerId, nil
 }
 
-func GetPullRequests(ctx context.Context, log *slog.Logger, repo Repository, userId string) ([]*Model, error) {
-	_, err := repo.GetUserByUserId(ctx, userId)
+// replacementSeniority возвращает уровень, не ниже которого должна быть замена
+// oldReviewerId: пустая строка, если требования команды к составу не нарушатся
+// или уже не выполнялись со старым ревьювером
+func replacementSeniority(ctx context.Context, repo Repository, teamName string, assignedReviewers []string, oldReviewerId string) (string, error) {
+	composition, err := repo.GetCompositionRules(ctx, teamName)
+	if err != nil || len(composition) == 0 {
+		return "", err
+	}
+
+	seniorities, err := repo.GetUserSeniorities(ctx, assignedReviewers)
+	if err != nil {
+		return "", err
+	}
+
+	if !composition.Satisfied(levelsOf(assignedReviewers, seniorities)) {
+		return "", nil
+	}
+
+	remaining := slices.DeleteFunc(slices.Clone(assignedReviewers), func(userId string) bool {
+		return userId == oldReviewerId
+	})
+
+	return composition.RequiredSeniority(levelsOf(remaining, seniorities)), nil
+}
+
+// GetPullRequests возвращает PR, где пользователь ревьювер; repositoryId, отличный
+// от нуля, оставляет только PR этого репозитория
+func GetPullRequests(ctx context.Context, log *slog.Logger, repo Repository, userId string, repositoryId int64) ([]*Model, error) {
+	userId, err := auth.ResolveUserId(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = repo.GetUserByUserId(ctx, userId)
 	if err != nil {
 		return nil, err
 	}
 
 	log.Info("user retrieved", slog.String("user_id", userId))
-	prs, err := repo.GetPullRequestsByReviewer(ctx, userId)
+	prs, err := repo.GetPullRequestsByReviewer(ctx, userId, repositoryId)
 	if err != nil {
 		return nil, err
 	}
 
 	return prs, nil
 }
+
+// recordEvent пишет событие PR в outbox в текущей транзакции
+func recordEvent(ctx context.Context, repo Repository, teamName string, eventType string, pr *Model) error {
+	return event.Record(ctx, repo, event.AggregatePullRequest, pr.PullRequestId, teamName, eventType, event.PullRequestPayload{
+		PullRequestId:     pr.PullRequestId,
+		PullRequestName:   pr.PullRequestName,
+		AuthorId:          pr.AuthorId,
+		Status:            pr.Status,
+		AssignedReviewers: nonNil(pr.AssignedReviewers),
+		MergedAt:          pr.MergedAt,
+		Version:           pr.Version,
+		UnderStaffed:      pr.UnderStaffed,
+	})
+}
+
+// recordAssigned пишет событие о назначении ревьювера; replacedReviewerId
+// задается при переназначении
+func recordAssigned(ctx context.Context, repo Repository, teamName string, pr *Model, reviewerId string, replacedReviewerId string, reason string) error {
+	return event.Record(ctx, repo, event.AggregatePullRequest, pr.PullRequestId, teamName, event.TypeReviewerAssigned, event.ReviewerAssignedPayload{
+		PullRequestId:      pr.PullRequestId,
+		PullRequestName:    pr.PullRequestName,
+		AuthorId:           pr.AuthorId,
+		ReviewerId:         reviewerId,
+		Reason:             reason,
+		ReplacedReviewerId: replacedReviewerId,
+		MatchedRule:        pr.MatchedRules[reviewerId],
+	})
+}
+
+// authorTeam возвращает команду автора PR для событий. Удаленный автор
+// не мешает смене статуса, событие просто остается без команды
+func authorTeam(ctx context.Context, repo Repository, authorId string) (string, error) {
+	author, err := repo.GetUserByUserId(ctx, authorId)
+	if err != nil {
+		if storageErr, ok := storage.IsError(err); ok && storageErr == storage.ErrUserNotFound {
+			return "", nil
+		}
+		return "", err
+	}
+	return author.TeamName, nil
+}
+
+func nonNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
@@ -0,0 +1,181 @@
+package pullrequest
+
+import (
+	"context"
+	"log/slog"
+	"reviewer-service/internal/domain/auth"
+	"reviewer-service/internal/domain/team"
+	"slices"
+	"time"
+)
+
+// Причины места кандидата в подборе ревьюверов
+const (
+	SelectReasonCodeOwner   = "code_owner"
+	SelectReasonComposition = "composition"
+	SelectReasonRank        = "rank"
+	// SelectReasonLowLoad - у кандидата нет открытых ревью
+	SelectReasonLowLoad = "low_load"
+	// SelectReasonNotRecentlyReviewed - кандидат не ревьюил автора дольше
+	// recencyWindow или не ревьюил вовсе
+	SelectReasonNotRecentlyReviewed = "not_recently_reviewed_author"
+	// SelectReasonRepeatLimit - кандидат достиг лимита повторных ревью автора
+	// и стоит после остальных
+	SelectReasonRepeatLimit = "repeat_limit_reached"
+	SelectReasonInactive    = "inactive"
+	// SelectReasonUnavailable - участник отсутствует, достиг лимита открытых
+	// ревью или исключен в паре с автором
+	SelectReasonUnavailable = "unavailable"
+)
+
+// Suggestion - участник команды автора с объяснением его места в подборе
+type Suggestion struct {
+	UserId    string
+	Seniority string
+	// Score - оценка rankCandidates: владение измененными файлами, нагрузка и
+	// давность последнего ревью автора; 0 - недоступен
+	Score int
+	// Selected - кандидат вошел бы в ревьюверы нового PR
+	Selected       bool
+	Available      bool
+	OpenReviews    int
+	LastReviewedAt *time.Time
+	// OwnerPattern - шаблон правила владельцев, по которому участник владеет
+	// измененными файлами
+	OwnerPattern string
+	Reasons      []string
+}
+
+// Suggestions - результат SuggestReviewers
+type Suggestions struct {
+	AuthorId     string
+	TeamName     string
+	Candidates   []*Suggestion
+	UnderStaffed bool
+}
+
+// SuggestReviewers показывает, кого и почему CreatePullRequest назначил бы на PR
+// автора authorId с count ревьюверами и измененными файлами changedFiles. С тем же
+// источником rnd первые кандидаты совпадают с выбором selectReviewers, за ними идут остальные доступные
+// в порядке rankCandidates, в конце - недоступные участники команды
+func SuggestReviewers(ctx context.Context, log *slog.Logger, repo Repository, rnd Random, authorId string, changedFiles []string, count int) (*Suggestions, error) {
+	authorId, err := auth.ResolveUserId(ctx, authorId)
+	if err != nil {
+		return nil, err
+	}
+
+	author, err := repo.GetUserByUserId(ctx, authorId)
+	if err != nil {
+		return nil, err
+	}
+
+	composition, err := repo.GetCompositionRules(ctx, author.TeamName)
+	if err != nil {
+		return nil, err
+	}
+
+	selected, err := selectReviewers(ctx, repo, rnd, author, changedFiles, count, composition)
+	if err != nil {
+		return nil, err
+	}
+
+	authorTeam, err := repo.GetTeamByName(ctx, author.TeamName)
+	if err != nil {
+		return nil, err
+	}
+
+	suggestions := &Suggestions{
+		AuthorId:     author.UserId,
+		TeamName:     author.TeamName,
+		Candidates:   suggestAvailable(selected),
+		UnderStaffed: len(selected.Reviewers) < count || !selected.CompositionMet,
+	}
+	suggestions.Candidates = append(suggestions.Candidates, suggestUnavailable(authorTeam, author.UserId, selected)...)
+
+	log.Info("reviewers suggested",
+		slog.String("author_id", author.UserId),
+		slog.Any("selected", selected.Reviewers))
+
+	return suggestions, nil
+}
+
+// suggestAvailable упорядочивает доступных кандидатов: выбранные ревьюверы
+// в порядке выбора, затем остальные в порядке rankCandidates. Причины - причина
+// выбора, затем факторы оценки
+func suggestAvailable(selected *selection) []*Suggestion {
+	byId := make(map[string]*scoredCandidate, len(selected.Ranked))
+	for _, candidate := range selected.Ranked {
+		byId[candidate.UserId] = candidate
+	}
+
+	ordered := make([]*scoredCandidate, 0, len(selected.Ranked))
+	for _, userId := range selected.Reviewers {
+		ordered = append(ordered, byId[userId])
+	}
+	for _, candidate := range selected.Ranked {
+		if _, ok := selected.Reasons[candidate.UserId]; !ok {
+			ordered = append(ordered, candidate)
+		}
+	}
+
+	suggestions := make([]*Suggestion, 0, len(ordered))
+	for _, candidate := range ordered {
+		suggestion := &Suggestion{
+			UserId:         candidate.UserId,
+			Seniority:      candidate.Seniority,
+			Score:          candidate.Score,
+			Available:      true,
+			OpenReviews:    candidate.OpenReviews,
+			LastReviewedAt: candidate.LastReviewedAt,
+			OwnerPattern:   selected.OwnerRules[candidate.UserId],
+			Reasons:        make([]string, 0, 4),
+		}
+
+		if reason, ok := selected.Reasons[candidate.UserId]; ok {
+			suggestion.Selected = true
+			suggestion.Reasons = append(suggestion.Reasons, reason)
+		}
+		for _, reason := range candidate.Reasons {
+			if !slices.Contains(suggestion.Reasons, reason) {
+				suggestion.Reasons = append(suggestion.Reasons, reason)
+			}
+		}
+		if candidate.RepeatLimitReached {
+			suggestion.Reasons = append(suggestion.Reasons, SelectReasonRepeatLimit)
+		}
+
+		suggestions = append(suggestions, suggestion)
+	}
+
+	return suggestions
+}
+
+// suggestUnavailable возвращает участников команды, кроме автора, которых нет
+// среди кандидатов, в порядке команды
+func suggestUnavailable(authorTeam *team.Model, authorId string, selected *selection) []*Suggestion {
+	available := make(map[string]bool, len(selected.Ranked))
+	for _, candidate := range selected.Ranked {
+		available[candidate.UserId] = true
+	}
+
+	suggestions := make([]*Suggestion, 0)
+	for _, member := range authorTeam.Members {
+		if member.UserId == authorId || available[member.UserId] {
+			continue
+		}
+
+		reason := SelectReasonUnavailable
+		if !member.IsActive {
+			reason = SelectReasonInactive
+		}
+
+		suggestions = append(suggestions, &Suggestion{
+			UserId:       member.UserId,
+			Seniority:    member.Seniority,
+			OwnerPattern: selected.OwnerRules[member.UserId],
+			Reasons:      []string{reason},
+		})
+	}
+
+	return suggestions
+}
@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"strconv"
+	"time"
+)
+
+// Model - репозиторий на Git хостинге, принадлежащий команде. Номер PR уникален
+// только внутри репозитория
+type Model struct {
+	ID       int64
+	Host     string
+	Name     string
+	TeamName string
+	// ReviewersCount переопределяет число ревьюверов новых PR, 0 - по умолчанию
+	ReviewersCount int
+	CreatedAt      time.Time
+}
+
+// PullRequestId строит строковый идентификатор PR вида owner/repo#42 - такой же,
+// как у PR из вебхуков GitHub
+func (m *Model) PullRequestId(number int64) string {
+	return m.Name + "#" + strconv.FormatInt(number, 10)
+}
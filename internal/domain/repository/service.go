@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+	"reviewer-service/internal/domain/auth"
+	"strings"
+)
+
+type Repository interface {
+	CreateRepository(ctx context.Context, m *Model) (int64, error)
+	GetRepositoryById(ctx context.Context, id int64) (*Model, error)
+	GetRepositoryByName(ctx context.Context, host string, name string) (*Model, error)
+	ListRepositories(ctx context.Context, teamName string) ([]*Model, error)
+	UpdateRepositoryReviewersCount(ctx context.Context, id int64, reviewersCount int) error
+	GetUserRoles(ctx context.Context, userId string) ([]*auth.Role, error)
+}
+
+// Register добавляет репозиторий команды. Регистрирует лид команды или админ
+func Register(ctx context.Context, log *slog.Logger, repo Repository, m *Model) (*Model, error) {
+	if err := auth.Authorize(ctx, repo, nil, []string{m.TeamName}); err != nil {
+		return nil, err
+	}
+
+	m.Host = NormalizeHost(m.Host)
+	m.Name = strings.Trim(m.Name, "/")
+
+	id, err := repo.CreateRepository(ctx, m)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := repo.GetRepositoryById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("repository registered",
+		slog.Int64("id", id),
+		slog.String("host", created.Host),
+		slog.String("name", created.Name),
+		slog.String("team_name", created.TeamName),
+		slog.String("actor", auth.Actor(ctx)))
+
+	return created, nil
+}
+
+// List возвращает репозитории команды, пустой teamName - все репозитории
+func List(ctx context.Context, log *slog.Logger, repo Repository, teamName string) ([]*Model, error) {
+	repositories, err := repo.ListRepositories(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("repositories retrieved", slog.String("team_name", teamName), slog.Int("count", len(repositories)))
+
+	return repositories, nil
+}
+
+// SetReviewersCount задает число ревьюверов новых PR репозитория, 0 возвращает
+// значение по умолчанию
+func SetReviewersCount(ctx context.Context, log *slog.Logger, repo Repository, id int64, reviewersCount int) (*Model, error) {
+	m, err := repo.GetRepositoryById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := auth.Authorize(ctx, repo, nil, []string{m.TeamName}); err != nil {
+		return nil, err
+	}
+
+	if err := repo.UpdateRepositoryReviewersCount(ctx, id, reviewersCount); err != nil {
+		return nil, err
+	}
+
+	m.ReviewersCount = reviewersCount
+
+	log.Info("repository reviewers count updated",
+		slog.Int64("id", id),
+		slog.Int("reviewers_count", reviewersCount),
+		slog.String("actor", auth.Actor(ctx)))
+
+	return m, nil
+}
+
+// NormalizeHost приводит хост к виду, в котором он хранится: github.com
+func NormalizeHost(host string) string {
+	return strings.ToLower(strings.TrimSpace(host))
+}
@@ -0,0 +1,65 @@
+package sla
+
+import "time"
+
+// Policy - обещание команды: каждый назначенный ревьювер берется за ревью
+// в течение ReviewWithin. При WorkingDaysOnly суббота и воскресенье (UTC) не считаются
+type Policy struct {
+	TeamName        string
+	ReviewWithin    time.Duration
+	WorkingDaysOnly bool
+	AutoReassign    bool
+	UpdatedAt       time.Time
+}
+
+// Assignment - назначение ревьювера на открытый PR, еще не нарушившее SLA
+type Assignment struct {
+	PullRequestId   string
+	PullRequestName string
+	AuthorId        string
+	ReviewerId      string
+	AssignedAt      time.Time
+	Policy          *Policy
+}
+
+// Elapsed возвращает время, учитываемое SLA, между from и to
+func (p *Policy) Elapsed(from time.Time, to time.Time) time.Duration {
+	if !to.After(from) {
+		return 0
+	}
+	if !p.WorkingDaysOnly {
+		return to.Sub(from)
+	}
+
+	from, to = from.UTC(), to.UTC()
+
+	var elapsed time.Duration
+	for day := startOfDay(from); day.Before(to); day = day.AddDate(0, 0, 1) {
+		if isWeekend(day) {
+			continue
+		}
+		start, end := day, day.AddDate(0, 0, 1)
+		if from.After(start) {
+			start = from
+		}
+		if to.Before(end) {
+			end = to
+		}
+		elapsed += end.Sub(start)
+	}
+
+	return elapsed
+}
+
+// Breached сообщает, истекло ли SLA назначения, сделанного в assignedAt
+func (p *Policy) Breached(assignedAt time.Time, now time.Time) bool {
+	return p.Elapsed(assignedAt, now) >= p.ReviewWithin
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func isWeekend(day time.Time) bool {
+	return day.Weekday() == time.Saturday || day.Weekday() == time.Sunday
+}
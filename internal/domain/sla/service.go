@@ -0,0 +1,56 @@
+package sla
+
+import (
+	"context"
+	"log/slog"
+	"reviewer-service/internal/domain/auth"
+)
+
+type Repository interface {
+	SetTeamSLA(ctx context.Context, p *Policy) (*Policy, error)
+	GetTeamSLA(ctx context.Context, teamName string) (*Policy, error)
+	DeleteTeamSLA(ctx context.Context, teamName string) error
+	GetUserRoles(ctx context.Context, userId string) ([]*auth.Role, error)
+}
+
+// SetPolicy задает SLA ревью команды. Нулевой ReviewWithin отключает SLA,
+// тогда возвращается nil
+func SetPolicy(ctx context.Context, log *slog.Logger, repo Repository, p *Policy) (*Policy, error) {
+	if err := auth.Authorize(ctx, repo, nil, []string{p.TeamName}); err != nil {
+		return nil, err
+	}
+
+	if p.ReviewWithin == 0 {
+		if err := repo.DeleteTeamSLA(ctx, p.TeamName); err != nil {
+			return nil, err
+		}
+
+		log.Info("team review sla disabled", slog.String("team_name", p.TeamName), slog.String("actor", auth.Actor(ctx)))
+		return nil, nil
+	}
+
+	saved, err := repo.SetTeamSLA(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("team review sla updated",
+		slog.String("team_name", p.TeamName),
+		slog.String("review_within", p.ReviewWithin.String()),
+		slog.Bool("working_days_only", p.WorkingDaysOnly),
+		slog.Bool("auto_reassign", p.AutoReassign),
+		slog.String("actor", auth.Actor(ctx)))
+
+	return saved, nil
+}
+
+func GetPolicy(ctx context.Context, log *slog.Logger, repo Repository, teamName string) (*Policy, error) {
+	p, err := repo.GetTeamSLA(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("team review sla retrieved", slog.String("team_name", teamName))
+
+	return p, nil
+}
@@ -0,0 +1,54 @@
+package subscription
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Статусы доставки. dead - попытки исчерпаны, доставка ждет ручного повтора
+const (
+	DeliveryPending   = "pending"
+	DeliveryDelivered = "delivered"
+	DeliveryDead      = "dead"
+)
+
+// Subscription - внешний получатель событий. Пустой TeamName означает
+// события всех команд
+type Subscription struct {
+	ID         int64
+	URL        string
+	Secret     string
+	EventTypes []string
+	TeamName   string
+	Active     bool
+	CreatedAt  time.Time
+}
+
+// Delivery - доставка одного события одной подписке. Payload - тело запроса
+type Delivery struct {
+	ID             int64
+	SubscriptionId int64
+	EventId        int64
+	EventType      string
+	Payload        json.RawMessage
+	Status         string
+	Attempts       int
+	NextAttemptAt  time.Time
+	LastStatusCode int
+	LastError      string
+	CreatedAt      time.Time
+	DeliveredAt    *time.Time
+}
+
+// Target - доставка вместе с адресом и секретом подписки для отправки
+type Target struct {
+	Delivery *Delivery
+	URL      string
+	Secret   string
+}
+
+type DeliveryFilter struct {
+	SubscriptionId int64
+	Status         string
+	Limit          int
+}
@@ -0,0 +1,177 @@
+package subscription
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"log/slog"
+	"reviewer-service/internal/domain/auth"
+	"reviewer-service/internal/domain/event"
+	"reviewer-service/internal/storage"
+	"slices"
+)
+
+const (
+	secretPrefix        = "whsec_"
+	defaultDeliveryPage = 50
+	maxDeliveryPage     = 500
+)
+
+type Repository interface {
+	CreateSubscription(ctx context.Context, s *Subscription) (int64, error)
+	GetSubscription(ctx context.Context, id int64) (*Subscription, error)
+	ListSubscriptions(ctx context.Context, teamName string) ([]*Subscription, error)
+	DeleteSubscription(ctx context.Context, id int64) error
+	ListDeliveries(ctx context.Context, filter DeliveryFilter) ([]*Delivery, error)
+	GetDelivery(ctx context.Context, id int64) (*Delivery, error)
+	RetryDelivery(ctx context.Context, id int64) (*Delivery, error)
+	GetTeamExists(ctx context.Context, teamName string) (bool, error)
+	GetUserRoles(ctx context.Context, userId string) ([]*auth.Role, error)
+}
+
+// TargetPolicy проверяет адрес получателя, чтобы подписка не вела
+// во внутреннюю сеть сервиса
+type TargetPolicy interface {
+	CheckURL(rawURL string) error
+}
+
+// CreateSubscription регистрирует получателя. Если секрет не задан, он
+// генерируется; секрет возвращается только здесь и нужен для проверки подписи.
+// Подписку на команду создает ее лид, подписку на все команды - админ
+func CreateSubscription(ctx context.Context, log *slog.Logger, repo Repository, targets TargetPolicy, s *Subscription) (*Subscription, error) {
+	for _, eventType := range s.EventTypes {
+		if !slices.Contains(event.Types, eventType) {
+			return nil, storage.ErrUnknownEventType
+		}
+	}
+
+	if err := targets.CheckURL(s.URL); err != nil {
+		log.Warn("subscription url rejected", slog.String("url", s.URL), slog.String("reason", err.Error()))
+		return nil, storage.ErrForbiddenTarget
+	}
+
+	if err := authorize(ctx, repo, s.TeamName); err != nil {
+		return nil, err
+	}
+
+	if s.TeamName != "" {
+		exists, err := repo.GetTeamExists(ctx, s.TeamName)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, storage.ErrTeamNotFound
+		}
+	}
+
+	if s.Secret == "" {
+		secret := make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			return nil, err
+		}
+		s.Secret = secretPrefix + base64.RawURLEncoding.EncodeToString(secret)
+	}
+	s.EventTypes = slices.Compact(slices.Sorted(slices.Values(s.EventTypes)))
+	s.Active = true
+
+	id, err := repo.CreateSubscription(ctx, s)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := repo.GetSubscription(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("subscription created",
+		slog.Int64("id", id),
+		slog.String("url", created.URL),
+		slog.String("team_name", created.TeamName),
+		slog.String("actor", auth.Actor(ctx)))
+
+	return created, nil
+}
+
+func ListSubscriptions(ctx context.Context, log *slog.Logger, repo Repository, teamName string) ([]*Subscription, error) {
+	if err := authorize(ctx, repo, teamName); err != nil {
+		return nil, err
+	}
+
+	return repo.ListSubscriptions(ctx, teamName)
+}
+
+func DeleteSubscription(ctx context.Context, log *slog.Logger, repo Repository, id int64) error {
+	if _, err := getSubscription(ctx, repo, id); err != nil {
+		return err
+	}
+
+	if err := repo.DeleteSubscription(ctx, id); err != nil {
+		return err
+	}
+
+	log.Info("subscription deleted", slog.Int64("id", id), slog.String("actor", auth.Actor(ctx)))
+
+	return nil
+}
+
+// ListDeliveries возвращает журнал доставок подписки, новые первыми
+func ListDeliveries(ctx context.Context, log *slog.Logger, repo Repository, filter DeliveryFilter) ([]*Delivery, error) {
+	if _, err := getSubscription(ctx, repo, filter.SubscriptionId); err != nil {
+		return nil, err
+	}
+
+	if filter.Limit <= 0 {
+		filter.Limit = defaultDeliveryPage
+	}
+	filter.Limit = min(filter.Limit, maxDeliveryPage)
+
+	return repo.ListDeliveries(ctx, filter)
+}
+
+// RetryDelivery возвращает доставку в очередь со сброшенным счетчиком попыток,
+// в том числе из статуса dead
+func RetryDelivery(ctx context.Context, log *slog.Logger, repo Repository, id int64) (*Delivery, error) {
+	delivery, err := repo.GetDelivery(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := getSubscription(ctx, repo, delivery.SubscriptionId); err != nil {
+		return nil, err
+	}
+
+	retried, err := repo.RetryDelivery(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("subscription delivery requeued",
+		slog.Int64("id", id),
+		slog.Int64("subscription_id", retried.SubscriptionId),
+		slog.String("actor", auth.Actor(ctx)))
+
+	return retried, nil
+}
+
+// getSubscription загружает подписку и проверяет права на нее
+func getSubscription(ctx context.Context, repo Repository, id int64) (*Subscription, error) {
+	s, err := repo.GetSubscription(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorize(ctx, repo, s.TeamName); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func authorize(ctx context.Context, repo Repository, teamName string) error {
+	var leadOfTeams []string
+	if teamName != "" {
+		leadOfTeams = []string{teamName}
+	}
+	return auth.Authorize(ctx, repo, nil, leadOfTeams)
+}
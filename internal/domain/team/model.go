@@ -6,4 +6,6 @@ type Model struct {
 	ID      int64
 	Name    string
 	Members []*user.Model
+	// DefaultMaxOpenReviews - лимит открытых ревью участников без своего лимита; 0 - без лимита
+	DefaultMaxOpenReviews int
 }
@@ -3,7 +3,9 @@ package team
 import (
 	"context"
 	"log/slog"
+	"reviewer-service/internal/domain/auth"
 	"reviewer-service/internal/domain/user"
+	"reviewer-service/internal/storage"
 )
 
 type Repository interface {
@@ -12,8 +14,9 @@ type Repository interface {
 	GetTeamByName(ctx context.Context, name string) (*Model, error)
 	CreateUser(ctx context.Context, u *user.Model) (int64, error)
 	GetUserByUserId(ctx context.Context, userId string) (*user.Model, error)
-	GetActiveReviewersByTeam(ctx context.Context, teamName string, excludeUserId string, limit int) ([]string, error)
-	GetActiveReviewersByTeamExcluding(ctx context.Context, teamName string, excludeUserIds []string, limit int) ([]string, error)
+	GetUserRoles(ctx context.Context, userId string) ([]*auth.Role, error)
+	UpdateTeamChatWebhook(ctx context.Context, teamName string, webhookURL string) error
+	UpdateTeamDefaultMaxOpenReviews(ctx context.Context, teamName string, maxOpenReviews int) error
 }
 
 type TransactionManager interface {
@@ -32,6 +35,10 @@ func SaveTeam(ctx context.Context, log *slog.Logger, txManager TransactionManage
 		}
 
 		for _, member := range t.Members {
+			if err := authorizeMemberMove(txCtx, repo, member, t.Name); err != nil {
+				return err
+			}
+
 			_, err := repo.CreateUser(txCtx, member)
 			if err != nil {
 				return err
@@ -50,11 +57,29 @@ func SaveTeam(ctx context.Context, log *slog.Logger, txManager TransactionManage
 		return nil, err
 	}
 
-	log.Info("team saved", slog.Int64("id", teamID))
+	log.Info("team saved", slog.Int64("id", teamID), slog.String("actor", auth.Actor(ctx)))
 
 	return savedTeam, nil
 }
 
+// authorizeMemberMove разрешает переводить существующего пользователя из другой команды
+// только лидам его текущей команды и админам
+func authorizeMemberMove(ctx context.Context, repo Repository, member *user.Model, teamName string) error {
+	existing, err := repo.GetUserByUserId(ctx, member.UserId)
+	if err != nil {
+		if storageErr, ok := storage.IsError(err); ok && storageErr == storage.ErrUserNotFound {
+			return nil
+		}
+		return err
+	}
+
+	if existing.TeamName == teamName {
+		return nil
+	}
+
+	return auth.Authorize(ctx, repo, nil, []string{existing.TeamName})
+}
+
 func GetTeamByName(ctx context.Context, log *slog.Logger, repo Repository, name string) (*Model, error) {
 	teamModel, err := repo.GetTeamByName(ctx, name)
 	if err != nil {
@@ -64,3 +89,41 @@ func GetTeamByName(ctx context.Context, log *slog.Logger, repo Repository, name
 	log.Info("team retrieved", slog.String("name", name))
 	return teamModel, nil
 }
+
+// SetChatWebhook задает адрес входящего вебхука чата (Slack-совместимого), куда
+// отправляются уведомления о назначениях в команде. Пустой адрес их отключает
+func SetChatWebhook(ctx context.Context, log *slog.Logger, repo Repository, teamName string, webhookURL string) error {
+	if err := auth.Authorize(ctx, repo, nil, []string{teamName}); err != nil {
+		return err
+	}
+
+	if err := repo.UpdateTeamChatWebhook(ctx, teamName, webhookURL); err != nil {
+		return err
+	}
+
+	log.Info("team chat webhook updated",
+		slog.String("team_name", teamName),
+		slog.Bool("enabled", webhookURL != ""),
+		slog.String("actor", auth.Actor(ctx)))
+
+	return nil
+}
+
+// SetDefaultMaxOpenReviews задает лимит открытых ревью для участников команды без
+// своего лимита; 0 снимает лимит. Лид команды или админ
+func SetDefaultMaxOpenReviews(ctx context.Context, log *slog.Logger, repo Repository, teamName string, maxOpenReviews int) error {
+	if err := auth.Authorize(ctx, repo, nil, []string{teamName}); err != nil {
+		return err
+	}
+
+	if err := repo.UpdateTeamDefaultMaxOpenReviews(ctx, teamName, maxOpenReviews); err != nil {
+		return err
+	}
+
+	log.Info("team default max open reviews updated",
+		slog.String("team_name", teamName),
+		slog.Int("default_max_open_reviews", maxOpenReviews),
+		slog.String("actor", auth.Actor(ctx)))
+
+	return nil
+}
@@ -1,14 +1,54 @@
 package user
 
 type Model struct {
-	ID                int64
-	UserId            string
-	Username          string
-	TeamName          string
-	IsActive          bool
+	ID         int64
+	UserId     string
+	Username   string
+	TeamName   string
+	IsActive   bool
+	ChatHandle string
+	// MaxOpenReviews - сколько открытых PR пользователь ревьюит одновременно;
+	// 0 - действует лимит команды
+	MaxOpenReviews int
+	// Seniority - уровень пользователя, пустая строка - не задан
+	Seniority         string
 	PullRequestShorts []*PullRequestShort
 }
 
+// Уровни пользователей по возрастанию
+const (
+	SeniorityJunior = "junior"
+	SeniorityMiddle = "middle"
+	SenioritySenior = "senior"
+	SeniorityLead   = "lead"
+)
+
+var SeniorityLevels = []string{
+	SeniorityJunior,
+	SeniorityMiddle,
+	SenioritySenior,
+	SeniorityLead,
+}
+
+// SeniorityRank возвращает позицию уровня, начиная с 1; 0 - уровень не задан или неизвестен
+func SeniorityRank(level string) int {
+	for i, known := range SeniorityLevels {
+		if known == level {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// LevelsFrom возвращает уровни не ниже level
+func LevelsFrom(level string) []string {
+	rank := SeniorityRank(level)
+	if rank == 0 {
+		return nil
+	}
+	return SeniorityLevels[rank-1:]
+}
+
 type PullRequestShort struct {
 	PullRequestId   string
 	PullRequestName string
@@ -3,12 +3,19 @@ package user
 import (
 	"context"
 	"log/slog"
+	"reviewer-service/internal/domain/auth"
+	"reviewer-service/internal/domain/event"
 )
 
 type Repository interface {
 	UpdateUserIsActive(ctx context.Context, userId string, isActive bool) (int64, error)
+	UpdateUserChatHandle(ctx context.Context, userId string, chatHandle string) (int64, error)
+	UpdateUserMaxOpenReviews(ctx context.Context, userId string, maxOpenReviews int) (int64, error)
+	UpdateUserSeniority(ctx context.Context, userId string, seniority string) (int64, error)
 	GetUser(ctx context.Context, id int64) (*Model, error)
 	GetUserByUserId(ctx context.Context, userId string) (*Model, error)
+	GetUserRoles(ctx context.Context, userId string) ([]*auth.Role, error)
+	AddOutboxEvent(ctx context.Context, e *event.Event) error
 }
 
 type TransactionManager interface {
@@ -19,6 +26,16 @@ func SetUserIsActive(ctx context.Context, log *slog.Logger, txManager Transactio
 	var updatedUser *Model
 
 	err := txManager.WithTransaction(ctx, func(ctx context.Context) error {
+		targetUser, err := repo.GetUserByUserId(ctx, userId)
+		if err != nil {
+			return err
+		}
+
+		// Активность участника меняют только лиды его команды и админы
+		if err := auth.Authorize(ctx, repo, nil, []string{targetUser.TeamName}); err != nil {
+			return err
+		}
+
 		updatedUserId, err := repo.UpdateUserIsActive(ctx, userId, isActive)
 
 		if err != nil {
@@ -29,14 +46,119 @@ func SetUserIsActive(ctx context.Context, log *slog.Logger, txManager Transactio
 		if err != nil {
 			return err
 		}
-		return nil
+
+		if targetUser.IsActive == isActive {
+			return nil
+		}
+
+		eventType := event.TypeUserDeactivated
+		if isActive {
+			eventType = event.TypeUserActivated
+		}
+
+		return event.Record(ctx, repo, event.AggregateUser, userId, updatedUser.TeamName, eventType, event.UserPayload{
+			UserId:   updatedUser.UserId,
+			Username: updatedUser.Username,
+			TeamName: updatedUser.TeamName,
+			IsActive: updatedUser.IsActive,
+		})
 	})
 
 	if err != nil {
 		return nil, err
 	}
 
-	log.Info("user is_active updated", slog.String("user_id", userId), slog.Bool("is_active", isActive))
+	log.Info("user is_active updated",
+		slog.String("user_id", userId),
+		slog.Bool("is_active", isActive),
+		slog.String("actor", auth.Actor(ctx)))
+
+	return updatedUser, nil
+}
+
+// SetUserChatHandle задает идентификатор пользователя в чате, по которому его
+// упоминают в уведомлениях. Менять его может сам пользователь, лид его команды и админ
+func SetUserChatHandle(ctx context.Context, log *slog.Logger, repo Repository, userId string, chatHandle string) (*Model, error) {
+	targetUser, err := repo.GetUserByUserId(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := auth.Authorize(ctx, repo, []string{userId}, []string{targetUser.TeamName}); err != nil {
+		return nil, err
+	}
+
+	updatedUserId, err := repo.UpdateUserChatHandle(ctx, userId, chatHandle)
+	if err != nil {
+		return nil, err
+	}
+
+	updatedUser, err := repo.GetUser(ctx, updatedUserId)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("user chat handle updated", slog.String("user_id", userId), slog.String("actor", auth.Actor(ctx)))
+
+	return updatedUser, nil
+}
+
+// SetUserMaxOpenReviews задает, сколько открытых PR пользователь ревьюит одновременно;
+// 0 возвращает лимит команды. Менять лимит могут лид команды пользователя и админ
+func SetUserMaxOpenReviews(ctx context.Context, log *slog.Logger, repo Repository, userId string, maxOpenReviews int) (*Model, error) {
+	targetUser, err := repo.GetUserByUserId(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := auth.Authorize(ctx, repo, nil, []string{targetUser.TeamName}); err != nil {
+		return nil, err
+	}
+
+	updatedUserId, err := repo.UpdateUserMaxOpenReviews(ctx, userId, maxOpenReviews)
+	if err != nil {
+		return nil, err
+	}
+
+	updatedUser, err := repo.GetUser(ctx, updatedUserId)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("user max open reviews updated",
+		slog.String("user_id", userId),
+		slog.Int("max_open_reviews", maxOpenReviews),
+		slog.String("actor", auth.Actor(ctx)))
+
+	return updatedUser, nil
+}
+
+// SetUserSeniority задает уровень пользователя; пустая строка снимает уровень.
+// Менять уровень могут лид команды пользователя и админ
+func SetUserSeniority(ctx context.Context, log *slog.Logger, repo Repository, userId string, seniority string) (*Model, error) {
+	targetUser, err := repo.GetUserByUserId(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := auth.Authorize(ctx, repo, nil, []string{targetUser.TeamName}); err != nil {
+		return nil, err
+	}
+
+	updatedUserId, err := repo.UpdateUserSeniority(ctx, userId, seniority)
+	if err != nil {
+		return nil, err
+	}
+
+	updatedUser, err := repo.GetUser(ctx, updatedUserId)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("user seniority updated",
+		slog.String("user_id", userId),
+		slog.String("seniority", seniority),
+		slog.String("actor", auth.Actor(ctx)))
 
 	return updatedUser, nil
 }
@@ -0,0 +1,71 @@
+package webhook
+
+const (
+	ProviderGitHub = "github"
+	ProviderGitLab = "gitlab"
+)
+
+var KnownProviders = []string{
+	ProviderGitHub,
+	ProviderGitLab,
+}
+
+// Действия, к которым приводятся события PR разных Git хостингов
+const (
+	ActionOpen   = "open"
+	ActionReopen = "reopen"
+	ActionMerge  = "merge"
+	ActionClose  = "close"
+	// ActionDraft - открытый PR снова стал черновиком, ревью приостанавливается
+	// (статус DRAFT)
+	ActionDraft = "draft"
+	// ActionReady - с PR снят статус черновика: регистрирует новый PR или
+	// возвращает черновик в OPEN
+	ActionReady = "ready"
+)
+
+const (
+	StatusProcessed = "processed"
+	StatusIgnored   = "ignored"
+	StatusDuplicate = "duplicate"
+)
+
+// Event - событие PR, приведенное к общему для всех хостингов виду.
+// Пустой Action означает событие, которое сервис не обрабатывает
+type Event struct {
+	Provider   string
+	DeliveryId string
+	// Name - исходное имя события хостинга, пишется в журнал доставок
+	Name          string
+	Action        string
+	PullRequestId string
+	Title         string
+	// AuthorLogin - логин автора, пустой, если хостинг его не передал
+	AuthorLogin string
+	// AuthorExternalId - числовой id автора на хостинге; если он есть, автор
+	// сначала ищется по нему
+	AuthorExternalId string
+	// RepositoryHost, RepositoryName и Number связывают PR с зарегистрированным
+	// репозиторием; события незарегистрированных репозиториев обрабатываются по PullRequestId
+	RepositoryHost string
+	RepositoryName string
+	Number         int64
+}
+
+type Result struct {
+	Status        string
+	Action        string
+	PullRequestId string
+	Reason        string
+}
+
+// Identity сопоставляет логин на Git хостинге с users.user_id. ExternalId -
+// числовой id пользователя на хостинге (GitLab передает в событиях MR только его),
+// пустой - не задан
+type Identity struct {
+	ID         int64
+	Provider   string
+	Login      string
+	UserId     string
+	ExternalId string
+}
@@ -0,0 +1,320 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"reviewer-service/internal/domain/auth"
+	"reviewer-service/internal/domain/pullrequest"
+	"reviewer-service/internal/domain/repository"
+	"reviewer-service/internal/storage"
+)
+
+type Repository interface {
+	pullrequest.Repository
+	// RecordWebhookDelivery возвращает false, если доставка уже была обработана
+	RecordWebhookDelivery(ctx context.Context, provider string, deliveryId string, event string) (bool, error)
+	GetIdentity(ctx context.Context, provider string, login string) (*Identity, error)
+	GetIdentityByExternalId(ctx context.Context, provider string, externalId string) (*Identity, error)
+	GetRepositoryByName(ctx context.Context, host string, name string) (*repository.Model, error)
+	GetPullRequestIdByNumber(ctx context.Context, repositoryId int64, number int64) (string, error)
+}
+
+type IdentityRepository interface {
+	SetIdentity(ctx context.Context, identity *Identity) (*Identity, error)
+	DeleteIdentity(ctx context.Context, provider string, login string) error
+	ListIdentities(ctx context.Context, provider string) ([]*Identity, error)
+}
+
+type TransactionManager interface {
+	WithTransaction(ctx context.Context, fn func(context.Context) error) error
+}
+
+// ignoredError откатывает транзакцию обработки, чтобы пропущенное событие
+// не попало в журнал доставок и его можно было доставить повторно
+type ignoredError struct {
+	reason string
+}
+
+func (e *ignoredError) Error() string {
+	return e.reason
+}
+
+// Process применяет событие к PR от имени системного субъекта. Доставка
+// записывается в журнал в той же транзакции, повторная доставка с тем же
+// DeliveryId ничего не меняет. Ревьюверы созданного PR передаются в syncer
+// после коммита
+func Process(ctx context.Context, log *slog.Logger, txManager TransactionManager, repo Repository, syncer pullrequest.ReviewerSyncer, rnd pullrequest.Random, event *Event) (*Result, error) {
+	ctx = auth.WithPrincipal(ctx, &auth.Principal{
+		Kind: auth.KindSystem,
+		Id:   event.Provider,
+		Name: "webhook-" + event.Provider,
+	})
+
+	log = log.With(
+		slog.String("provider", event.Provider),
+		slog.String("delivery_id", event.DeliveryId),
+		slog.String("pull_request_id", event.PullRequestId),
+	)
+
+	result := &Result{
+		Status:        StatusProcessed,
+		Action:        event.Action,
+		PullRequestId: event.PullRequestId,
+	}
+
+	var createdPR *pullrequest.Model
+
+	err := txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		if event.DeliveryId != "" {
+			recorded, err := repo.RecordWebhookDelivery(txCtx, event.Provider, event.DeliveryId, event.Name)
+			if err != nil {
+				return err
+			}
+			if !recorded {
+				result.Status = StatusDuplicate
+				return nil
+			}
+		}
+
+		repositoryId, err := resolveRepository(txCtx, repo, event)
+		if err != nil {
+			return err
+		}
+		result.PullRequestId = event.PullRequestId
+
+		createdPR, err = apply(txCtx, log, txManager, repo, rnd, event, repositoryId)
+		return err
+	})
+
+	var ignored *ignoredError
+	if errors.As(err, &ignored) {
+		log.Info("webhook event ignored", slog.String("action", event.Action), slog.String("reason", ignored.reason))
+		result.Status = StatusIgnored
+		result.Reason = ignored.reason
+		return result, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("webhook event handled", slog.String("action", event.Action), slog.String("status", result.Status))
+
+	if syncer != nil && createdPR != nil && len(createdPR.AssignedReviewers) > 0 {
+		syncer.SyncReviewers(createdPR.PullRequestId, createdPR.AssignedReviewers, nil)
+	}
+
+	return result, nil
+}
+
+// apply возвращает PR, если событие его создало
+func apply(ctx context.Context, log *slog.Logger, txManager TransactionManager, repo Repository, rnd pullrequest.Random, event *Event, repositoryId int64) (*pullrequest.Model, error) {
+	switch event.Action {
+	case ActionOpen:
+		exists, err := pullRequestExists(ctx, repo, event.PullRequestId)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			return nil, &ignoredError{reason: "pull request already exists"}
+		}
+
+		return create(ctx, log, txManager, repo, rnd, event, repositoryId)
+
+	case ActionReopen:
+		exists, err := pullRequestExists(ctx, repo, event.PullRequestId)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return create(ctx, log, txManager, repo, rnd, event, repositoryId)
+		}
+
+		_, err = pullrequest.ReopenPullRequest(ctx, log, txManager, repo, event.PullRequestId)
+		return nil, err
+
+	case ActionReady:
+		pr, err := findPullRequest(ctx, repo, event.PullRequestId)
+		if err != nil {
+			return nil, err
+		}
+		if pr == nil {
+			return create(ctx, log, txManager, repo, rnd, event, repositoryId)
+		}
+		if pr.Status != "DRAFT" {
+			return nil, &ignoredError{reason: "pull request already exists"}
+		}
+
+		_, err = pullrequest.ReadyPullRequest(ctx, log, txManager, repo, event.PullRequestId)
+		return nil, err
+
+	case ActionDraft:
+		pr, err := findPullRequest(ctx, repo, event.PullRequestId)
+		if err != nil {
+			return nil, err
+		}
+		if pr == nil {
+			return nil, &ignoredError{reason: "pull request is not registered"}
+		}
+		if pr.Status != "OPEN" {
+			return nil, &ignoredError{reason: "pull request is not open"}
+		}
+
+		_, err = pullrequest.DraftPullRequest(ctx, log, txManager, repo, event.PullRequestId)
+		return nil, err
+
+	case ActionMerge, ActionClose:
+		exists, err := pullRequestExists(ctx, repo, event.PullRequestId)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, &ignoredError{reason: "pull request is not registered"}
+		}
+
+		if event.Action == ActionMerge {
+			_, err = pullrequest.MergePullRequest(ctx, log, txManager, repo, event.PullRequestId, 0)
+		} else {
+			_, err = pullrequest.ClosePullRequest(ctx, log, txManager, repo, event.PullRequestId)
+		}
+		return nil, err
+
+	default:
+		return nil, &ignoredError{reason: "event is not handled"}
+	}
+}
+
+// resolveRepository возвращает id зарегистрированного репозитория события или 0.
+// Если PR с этим номером уже есть в репозитории, event.PullRequestId заменяется
+// его идентификатором: PR мог быть создан через API с другим pull_request_id
+func resolveRepository(ctx context.Context, repo Repository, event *Event) (int64, error) {
+	if event.RepositoryName == "" || event.Number == 0 {
+		return 0, nil
+	}
+
+	registered, err := repo.GetRepositoryByName(ctx, repository.NormalizeHost(event.RepositoryHost), event.RepositoryName)
+	if err != nil {
+		if storageErr, ok := storage.IsError(err); ok && storageErr == storage.ErrRepositoryNotFound {
+			return 0, nil
+		}
+		return 0, err
+	}
+
+	pullRequestId, err := repo.GetPullRequestIdByNumber(ctx, registered.ID, event.Number)
+	if err != nil {
+		if storageErr, ok := storage.IsError(err); ok && storageErr == storage.ErrPullRequestNotFound {
+			return registered.ID, nil
+		}
+		return 0, err
+	}
+
+	event.PullRequestId = pullRequestId
+	return registered.ID, nil
+}
+
+// create регистрирует PR, автор определяется через git_identities
+func create(ctx context.Context, log *slog.Logger, txManager TransactionManager, repo Repository, rnd pullrequest.Random, event *Event, repositoryId int64) (*pullrequest.Model, error) {
+	identity, err := resolveAuthor(ctx, repo, event)
+	if err != nil {
+		return nil, err
+	}
+
+	// syncer не передается: транзакция вебхука еще не закоммичена
+	pr := &pullrequest.Model{
+		PullRequestId:     event.PullRequestId,
+		PullRequestName:   event.Title,
+		AuthorId:          identity.UserId,
+		Status:            "OPEN",
+		AssignedReviewers: []string{},
+	}
+	if repositoryId != 0 {
+		pr.RepositoryId = repositoryId
+		pr.Number = event.Number
+	}
+
+	return pullrequest.CreatePullRequest(ctx, log, txManager, repo, nil, rnd, pr, false)
+}
+
+// resolveAuthor ищет автора сначала по числовому id на хостинге, затем по логину.
+// Если сопоставления нет, событие пропускается с указанием, что нужно сопоставить
+func resolveAuthor(ctx context.Context, repo Repository, event *Event) (*Identity, error) {
+	if event.AuthorExternalId != "" {
+		identity, err := repo.GetIdentityByExternalId(ctx, event.Provider, event.AuthorExternalId)
+		if err == nil {
+			return identity, nil
+		}
+		if storageErr, ok := storage.IsError(err); !ok || storageErr != storage.ErrIdentityNotFound {
+			return nil, err
+		}
+	}
+
+	if event.AuthorLogin == "" {
+		return nil, &ignoredError{reason: "no user is mapped to " + event.Provider + " user id " + event.AuthorExternalId}
+	}
+
+	identity, err := repo.GetIdentity(ctx, event.Provider, event.AuthorLogin)
+	if err != nil {
+		if storageErr, ok := storage.IsError(err); ok && storageErr == storage.ErrIdentityNotFound {
+			return nil, &ignoredError{reason: "no user is mapped to login " + event.AuthorLogin}
+		}
+		return nil, err
+	}
+
+	return identity, nil
+}
+
+// findPullRequest возвращает PR или nil, если он не зарегистрирован
+func findPullRequest(ctx context.Context, repo Repository, pullRequestId string) (*pullrequest.Model, error) {
+	pr, err := repo.GetPullRequestById(ctx, pullRequestId)
+	if err != nil {
+		if storageErr, ok := storage.IsError(err); ok && storageErr == storage.ErrPullRequestNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return pr, nil
+}
+
+func pullRequestExists(ctx context.Context, repo Repository, pullRequestId string) (bool, error) {
+	_, err := repo.GetPullRequestById(ctx, pullRequestId)
+	if err != nil {
+		if storageErr, ok := storage.IsError(err); ok && storageErr == storage.ErrPullRequestNotFound {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+func SetIdentity(ctx context.Context, log *slog.Logger, repo IdentityRepository, identity *Identity) (*Identity, error) {
+	saved, err := repo.SetIdentity(ctx, identity)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("git identity mapped",
+		slog.String("provider", saved.Provider),
+		slog.String("login", saved.Login),
+		slog.String("user_id", saved.UserId),
+		slog.String("external_id", saved.ExternalId),
+		slog.String("actor", auth.Actor(ctx)))
+
+	return saved, nil
+}
+
+func DeleteIdentity(ctx context.Context, log *slog.Logger, repo IdentityRepository, provider string, login string) error {
+	if err := repo.DeleteIdentity(ctx, provider, login); err != nil {
+		return err
+	}
+
+	log.Info("git identity removed",
+		slog.String("provider", provider),
+		slog.String("login", login),
+		slog.String("actor", auth.Actor(ctx)))
+
+	return nil
+}
+
+func ListIdentities(ctx context.Context, log *slog.Logger, repo IdentityRepository, provider string) ([]*Identity, error) {
+	return repo.ListIdentities(ctx, provider)
+}
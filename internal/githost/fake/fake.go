@@ -0,0 +1,84 @@
+package fake
+
+import (
+	"context"
+	"errors"
+	"reviewer-service/internal/githost"
+	"slices"
+	"sync"
+)
+
+const (
+	MethodRequest = "request"
+	MethodRemove  = "remove"
+)
+
+var ErrUnavailable = errors.New("fake git host is unavailable")
+
+type Call struct {
+	Method string
+	Ref    githost.PullRequestRef
+	Logins []string
+}
+
+// Client запоминает вызовы вместо обращения к хостингу. Первые FailTimes
+// вызовов завершаются ошибкой ErrUnavailable
+type Client struct {
+	provider string
+
+	mu        sync.Mutex
+	calls     []Call
+	failTimes int
+	attempts  int
+}
+
+func NewClient(provider string) *Client {
+	return &Client{provider: provider}
+}
+
+// FailNext заставляет следующие n вызовов вернуть ErrUnavailable
+func (c *Client) FailNext(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.failTimes = n
+}
+
+func (c *Client) Provider() string {
+	return c.provider
+}
+
+func (c *Client) RequestReviewers(ctx context.Context, ref githost.PullRequestRef, logins []string) error {
+	return c.record(MethodRequest, ref, logins)
+}
+
+func (c *Client) RemoveReviewers(ctx context.Context, ref githost.PullRequestRef, logins []string) error {
+	return c.record(MethodRemove, ref, logins)
+}
+
+// Calls возвращает успешные вызовы в порядке выполнения
+func (c *Client) Calls() []Call {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return slices.Clone(c.calls)
+}
+
+// Attempts возвращает число всех вызовов, включая неуспешные
+func (c *Client) Attempts() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.attempts
+}
+
+func (c *Client) record(method string, ref githost.PullRequestRef, logins []string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.attempts++
+	if c.failTimes > 0 {
+		c.failTimes--
+		return ErrUnavailable
+	}
+
+	c.calls = append(c.calls, Call{Method: method, Ref: ref, Logins: slices.Clone(logins)})
+	return nil
+}
@@ -0,0 +1,50 @@
+package githost
+
+import (
+	"context"
+	"regexp"
+	"strconv"
+)
+
+// Client переносит изменения ревьюверов в PR на Git хостинге
+type Client interface {
+	// Provider совпадает с webhook.Provider* и используется для поиска логинов
+	Provider() string
+	RequestReviewers(ctx context.Context, ref PullRequestRef, logins []string) error
+	RemoveReviewers(ctx context.Context, ref PullRequestRef, logins []string) error
+}
+
+// PullRequestRef - адрес PR на хостинге
+type PullRequestRef struct {
+	Owner  string
+	Repo   string
+	Number int64
+}
+
+func (r PullRequestRef) String() string {
+	return r.Owner + "/" + r.Repo + "#" + strconv.FormatInt(r.Number, 10)
+}
+
+// RetryableError сообщает, имеет ли смысл повторить вызов
+type RetryableError interface {
+	error
+	Retryable() bool
+}
+
+var githubIdPattern = regexp.MustCompile(`^([^/\s]+)/([^/#\s]+)#([0-9]+)$`)
+
+// ParseGitHubId разбирает идентификатор PR вида owner/repo#42,
+// который присваивают PR вебхуки GitHub
+func ParseGitHubId(pullRequestId string) (PullRequestRef, bool) {
+	match := githubIdPattern.FindStringSubmatch(pullRequestId)
+	if match == nil {
+		return PullRequestRef{}, false
+	}
+
+	number, err := strconv.ParseInt(match[3], 10, 64)
+	if err != nil {
+		return PullRequestRef{}, false
+	}
+
+	return PullRequestRef{Owner: match[1], Repo: match[2], Number: number}, true
+}
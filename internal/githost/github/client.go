@@ -0,0 +1,102 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"reviewer-service/internal/domain/webhook"
+	"reviewer-service/internal/githost"
+	"strings"
+	"time"
+)
+
+const DefaultAPIURL = "https://api.github.com"
+
+// Client вызывает GitHub REST API requested_reviewers
+type Client struct {
+	apiURL string
+	token  string
+	http   *http.Client
+}
+
+func NewClient(apiURL string, token string, timeout time.Duration) *Client {
+	if apiURL == "" {
+		apiURL = DefaultAPIURL
+	}
+
+	return &Client{
+		apiURL: strings.TrimRight(apiURL, "/"),
+		token:  token,
+		http:   &http.Client{Timeout: timeout},
+	}
+}
+
+// StatusError - неуспешный ответ API
+type StatusError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("github api: status %d: %s", e.StatusCode, e.Body)
+}
+
+// Retryable - 5xx, 429 и 403 (вторичный rate limit) стоит повторить,
+// остальные 4xx не изменятся при повторе
+func (e *StatusError) Retryable() bool {
+	return e.StatusCode >= 500 ||
+		e.StatusCode == http.StatusTooManyRequests ||
+		e.StatusCode == http.StatusForbidden
+}
+
+type reviewersRequest struct {
+	Reviewers []string `json:"reviewers"`
+}
+
+func (c *Client) Provider() string {
+	return webhook.ProviderGitHub
+}
+
+func (c *Client) RequestReviewers(ctx context.Context, ref githost.PullRequestRef, logins []string) error {
+	return c.do(ctx, http.MethodPost, ref, logins)
+}
+
+func (c *Client) RemoveReviewers(ctx context.Context, ref githost.PullRequestRef, logins []string) error {
+	return c.do(ctx, http.MethodDelete, ref, logins)
+}
+
+func (c *Client) do(ctx context.Context, method string, ref githost.PullRequestRef, logins []string) error {
+	body, err := json.Marshal(reviewersRequest{Reviewers: logins})
+	if err != nil {
+		return err
+	}
+
+	url := fmt.Sprintf("%s/repos/%s/%s/pulls/%d/requested_reviewers", c.apiURL, ref.Owner, ref.Repo, ref.Number)
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	return &StatusError{StatusCode: resp.StatusCode, Body: strings.TrimSpace(string(respBody))}
+}
@@ -0,0 +1,250 @@
+package githost
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"math/rand/v2"
+	"reviewer-service/internal/domain/webhook"
+	logUtil "reviewer-service/internal/lib/logger/slog"
+	"reviewer-service/internal/storage"
+	"sync"
+	"time"
+)
+
+type IdentityRepository interface {
+	GetIdentityByUserId(ctx context.Context, provider string, userId string) (*webhook.Identity, error)
+}
+
+type Options struct {
+	Workers        int
+	QueueSize      int
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	// CallTimeout ограничивает один вызов Client
+	CallTimeout time.Duration
+}
+
+func (o Options) withDefaults() Options {
+	if o.Workers <= 0 {
+		o.Workers = 2
+	}
+	if o.QueueSize <= 0 {
+		o.QueueSize = 256
+	}
+	if o.MaxAttempts <= 0 {
+		o.MaxAttempts = 5
+	}
+	if o.InitialBackoff <= 0 {
+		o.InitialBackoff = time.Second
+	}
+	if o.MaxBackoff <= 0 {
+		o.MaxBackoff = time.Minute
+	}
+	if o.CallTimeout <= 0 {
+		o.CallTimeout = 10 * time.Second
+	}
+	return o
+}
+
+// job - изменение ревьюверов одного PR; выполненные шаги очищаются,
+// чтобы повтор продолжал с места ошибки
+type job struct {
+	pullRequestId string
+	ref           PullRequestRef
+	added         []string
+	removed       []string
+	attempt       int
+}
+
+// Syncer асинхронно переносит назначения ревьюверов на хостинг. Вызовы
+// выполняются после коммита транзакции и повторяются с экспоненциальной
+// задержкой; ошибки хостинга только логируются
+type Syncer struct {
+	log        *slog.Logger
+	client     Client
+	identities IdentityRepository
+	opts       Options
+
+	queue chan *job
+	wg    sync.WaitGroup
+	ctx   context.Context
+}
+
+func NewSyncer(log *slog.Logger, client Client, identities IdentityRepository, opts Options) *Syncer {
+	opts = opts.withDefaults()
+
+	return &Syncer{
+		log: log.With(
+			slog.String("component", "githost/syncer"),
+			slog.String("provider", client.Provider()),
+		),
+		client:     client,
+		identities: identities,
+		opts:       opts,
+		queue:      make(chan *job, opts.QueueSize),
+	}
+}
+
+// Start запускает воркеры, они работают до отмены ctx
+func (s *Syncer) Start(ctx context.Context) {
+	s.ctx = ctx
+
+	for i := 0; i < s.opts.Workers; i++ {
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.work(ctx)
+		}()
+	}
+
+	s.log.Info("git host syncer started", slog.Int("workers", s.opts.Workers))
+}
+
+// Wait ждет завершения воркеров после отмены контекста Start
+func (s *Syncer) Wait() {
+	s.wg.Wait()
+}
+
+// SyncReviewers ставит в очередь запрос новых ревьюверов added и снятие removed.
+// Не блокирует: при переполненной очереди изменение пропускается с предупреждением
+func (s *Syncer) SyncReviewers(pullRequestId string, added []string, removed []string) {
+	ref, ok := s.parseId(pullRequestId)
+	if !ok {
+		s.log.Debug("pull request is not hosted by provider, skipping", slog.String("pull_request_id", pullRequestId))
+		return
+	}
+
+	if len(added) == 0 && len(removed) == 0 {
+		return
+	}
+
+	s.enqueue(&job{pullRequestId: pullRequestId, ref: ref, added: added, removed: removed})
+}
+
+func (s *Syncer) parseId(pullRequestId string) (PullRequestRef, bool) {
+	if s.client.Provider() == webhook.ProviderGitHub {
+		return ParseGitHubId(pullRequestId)
+	}
+	return PullRequestRef{}, false
+}
+
+func (s *Syncer) enqueue(j *job) {
+	select {
+	case s.queue <- j:
+	default:
+		s.log.Warn("git host sync queue is full, dropping change",
+			slog.String("pull_request_id", j.pullRequestId))
+	}
+}
+
+func (s *Syncer) work(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case j := <-s.queue:
+			s.process(ctx, j)
+		}
+	}
+}
+
+func (s *Syncer) process(ctx context.Context, j *job) {
+	log := s.log.With(
+		slog.String("pull_request_id", j.pullRequestId),
+		slog.Int("attempt", j.attempt+1),
+	)
+
+	// Сначала снимаем, чтобы не упереться в лимит ревьюверов на хостинге
+	if len(j.removed) > 0 {
+		if err := s.call(ctx, log.With(slog.String("method", "remove")), j.ref, j.removed, s.client.RemoveReviewers); err != nil {
+			s.retry(log, j, err)
+			return
+		}
+		j.removed = nil
+	}
+
+	if len(j.added) > 0 {
+		if err := s.call(ctx, log.With(slog.String("method", "request")), j.ref, j.added, s.client.RequestReviewers); err != nil {
+			s.retry(log, j, err)
+			return
+		}
+		j.added = nil
+	}
+}
+
+type clientCall func(ctx context.Context, ref PullRequestRef, logins []string) error
+
+func (s *Syncer) call(ctx context.Context, log *slog.Logger, ref PullRequestRef, userIds []string, fn clientCall) error {
+	logins, err := s.resolveLogins(ctx, userIds)
+	if err != nil {
+		return err
+	}
+	if len(logins) == 0 {
+		log.Warn("no git host logins for reviewers, skipping", slog.Any("user_ids", userIds))
+		return nil
+	}
+
+	callCtx, cancel := context.WithTimeout(ctx, s.opts.CallTimeout)
+	defer cancel()
+
+	if err := fn(callCtx, ref, logins); err != nil {
+		return err
+	}
+
+	log.Info("reviewers synced to git host", slog.Any("logins", logins))
+	return nil
+}
+
+// resolveLogins переводит user_id в логины хостинга, пользователи без
+// сопоставления пропускаются
+func (s *Syncer) resolveLogins(ctx context.Context, userIds []string) ([]string, error) {
+	logins := make([]string, 0, len(userIds))
+	for _, userId := range userIds {
+		identity, err := s.identities.GetIdentityByUserId(ctx, s.client.Provider(), userId)
+		if err != nil {
+			if storageErr, ok := storage.IsError(err); ok && storageErr == storage.ErrIdentityNotFound {
+				continue
+			}
+			return nil, err
+		}
+		logins = append(logins, identity.Login)
+	}
+	return logins, nil
+}
+
+func (s *Syncer) retry(log *slog.Logger, j *job, err error) {
+	var retryable RetryableError
+	if errors.As(err, &retryable) && !retryable.Retryable() {
+		log.Error("git host rejected reviewer change", logUtil.Err(err))
+		return
+	}
+
+	j.attempt++
+	if j.attempt >= s.opts.MaxAttempts {
+		log.Error("giving up syncing reviewers to git host", logUtil.Err(err))
+		return
+	}
+
+	delay := s.backoff(j.attempt)
+	log.Warn("failed to sync reviewers to git host, retrying",
+		slog.String("retry_in", delay.String()),
+		logUtil.Err(err))
+
+	time.AfterFunc(delay, func() {
+		if s.ctx != nil && s.ctx.Err() != nil {
+			return
+		}
+		s.enqueue(j)
+	})
+}
+
+// backoff - экспоненциальная задержка с jitter до 20%
+func (s *Syncer) backoff(attempt int) time.Duration {
+	delay := s.opts.InitialBackoff << (attempt - 1)
+	if delay <= 0 || delay > s.opts.MaxBackoff {
+		delay = s.opts.MaxBackoff
+	}
+	return delay + time.Duration(rand.Int64N(int64(delay)/5+1))
+}
@@ -0,0 +1,61 @@
+package grpcserver
+
+import (
+	"reviewer-service/internal/storage"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const errorDomain = "reviewer-service"
+
+// toStatus переводит ошибку домена в статус gRPC. Код storage.Error
+// передается в детали ErrorInfo.Reason, чтобы клиент мог его различить
+func toStatus(err error) error {
+	storageErr, ok := storage.IsError(err)
+	if !ok {
+		return newStatus(codes.Internal, "INTERNAL_ERROR", err.Error())
+	}
+
+	return newStatus(getCodeForError(storageErr.Code), storageErr.Code, storageErr.Message)
+}
+
+func invalidArgument(message string) error {
+	return newStatus(codes.InvalidArgument, "VALIDATION_ERROR", message)
+}
+
+func newStatus(code codes.Code, reason string, message string) error {
+	st := status.New(code, message)
+
+	withDetails, err := st.WithDetails(&errdetails.ErrorInfo{
+		Reason: reason,
+		Domain: errorDomain,
+	})
+	if err != nil {
+		return st.Err()
+	}
+
+	return withDetails.Err()
+}
+
+func getCodeForError(errorCode string) codes.Code {
+	switch errorCode {
+	case "NOT_FOUND":
+		return codes.NotFound
+	case "TEAM_EXISTS", "USER_EXISTS", "PR_EXISTS", "REPOSITORY_EXISTS", "IDENTITY_EXISTS":
+		return codes.AlreadyExists
+	case "PR_MERGED", "PR_CLOSED", "PR_DRAFT", "NOT_ASSIGNED", "NO_CANDIDATE":
+		return codes.FailedPrecondition
+	case "VERSION_CONFLICT":
+		return codes.Aborted
+	case "UNAUTHORIZED", "INVALID_SIGNATURE":
+		return codes.Unauthenticated
+	case "FORBIDDEN":
+		return codes.PermissionDenied
+	case "VALIDATION_ERROR", "INVALID_REQUEST", "INVALID_SCOPE", "INVALID_ROLE", "INVALID_EVENT_TYPE", "INVALID_URL", "INVALID_PATTERN", "UNKNOWN_OWNER", "UNKNOWN_MEMBER":
+		return codes.InvalidArgument
+	default:
+		return codes.Internal
+	}
+}
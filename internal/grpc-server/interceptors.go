@@ -0,0 +1,185 @@
+package grpcserver
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	domainAuth "reviewer-service/internal/domain/auth"
+	authMiddleware "reviewer-service/internal/http-server/middleware/auth"
+	logUtil "reviewer-service/internal/lib/logger/slog"
+	"reviewer-service/internal/storage"
+	reviewerv1 "reviewer-service/pkg/api/reviewer/v1"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const requestIdMetadata = "x-request-id"
+
+// methodScopes - scope, необходимый для метода, как у соответствующего маршрута
+// REST API. Методы не из списка и не из publicMethodPrefixes запрещены, чтобы
+// новый метод без записи здесь не оказался доступен без аутентификации
+var methodScopes = map[string]string{
+	reviewerv1.TeamService_AddTeam_FullMethodName:                  domainAuth.ScopeTeamsWrite,
+	reviewerv1.TeamService_GetTeam_FullMethodName:                  domainAuth.ScopeRead,
+	reviewerv1.TeamService_SetChatWebhook_FullMethodName:           domainAuth.ScopeTeamsWrite,
+	reviewerv1.UserService_SetIsActive_FullMethodName:              domainAuth.ScopeUsersWrite,
+	reviewerv1.UserService_SetChatHandle_FullMethodName:            domainAuth.ScopeUsersWrite,
+	reviewerv1.UserService_GetReview_FullMethodName:                domainAuth.ScopeRead,
+	reviewerv1.PullRequestService_CreatePullRequest_FullMethodName: domainAuth.ScopePRsWrite,
+	reviewerv1.PullRequestService_MergePullRequest_FullMethodName:  domainAuth.ScopePRsWrite,
+	reviewerv1.PullRequestService_ReassignReviewer_FullMethodName:  domainAuth.ScopePRsWrite,
+}
+
+// publicMethodPrefixes - сервисы, доступные без аутентификации: health и reflection
+var publicMethodPrefixes = []string{
+	"/grpc.health.v1.Health/",
+	"/grpc.reflection.",
+}
+
+func isPublicMethod(fullMethod string) bool {
+	for _, prefix := range publicMethodPrefixes {
+		if strings.HasPrefix(fullMethod, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// requestIdInterceptor берет x-request-id из метаданных или выдает новый и кладет
+// его в контекст под тем же ключом, что и chi middleware.RequestID
+func requestIdInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		requestId := firstMetadata(ctx, requestIdMetadata)
+		if requestId == "" {
+			requestId = fmt.Sprintf("grpc-%06d", middleware.NextRequestID())
+		}
+
+		_ = grpc.SetHeader(ctx, metadata.Pairs(requestIdMetadata, requestId))
+
+		return handler(context.WithValue(ctx, middleware.RequestIDKey, requestId), req)
+	}
+}
+
+func loggerInterceptor(log *slog.Logger) grpc.UnaryServerInterceptor {
+	log = log.With(
+		slog.String("component", "grpc/logger"),
+	)
+
+	log.Info("grpc logger interceptor enabled")
+
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+
+		resp, err := handler(ctx, req)
+
+		log.Info("request completed",
+			slog.String("method", info.FullMethod),
+			slog.String("request_id", middleware.GetReqID(ctx)),
+			slog.String("code", status.Code(err).String()),
+			slog.String("duration", time.Since(start).String()),
+		)
+
+		return resp, err
+	}
+}
+
+func recoveryInterceptor(log *slog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+		defer func() {
+			if rec := recover(); rec != nil {
+				log.Error("panic in grpc handler",
+					slog.String("method", info.FullMethod),
+					slog.String("request_id", middleware.GetReqID(ctx)),
+					slog.Any("panic", rec),
+				)
+				err = status.Error(codes.Internal, "internal error")
+			}
+		}()
+
+		return handler(ctx, req)
+	}
+}
+
+// authInterceptor аутентифицирует по метаданным x-api-key или authorization
+// (Bearer JWT) так же, как HTTP middleware, и проверяет scope метода
+func authInterceptor(log *slog.Logger, opts authMiddleware.Options) grpc.UnaryServerInterceptor {
+	log = log.With(
+		slog.String("component", "grpc/auth"),
+	)
+
+	log.Info("grpc auth interceptor enabled")
+
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if isPublicMethod(info.FullMethod) {
+			return handler(ctx, req)
+		}
+
+		scope, ok := methodScopes[info.FullMethod]
+		if !ok {
+			log.Error("method has no required scope",
+				slog.String("request_id", middleware.GetReqID(ctx)),
+				slog.String("method", info.FullMethod),
+			)
+			return nil, newStatus(codes.PermissionDenied, storage.ErrForbidden.Code, "method is not allowed")
+		}
+
+		principal, err := authMiddleware.ResolvePrincipal(ctx, opts,
+			firstMetadata(ctx, "x-api-key"),
+			firstMetadata(ctx, "authorization"),
+		)
+		if err != nil {
+			log.Warn("authentication failed",
+				slog.String("request_id", middleware.GetReqID(ctx)),
+				slog.String("method", info.FullMethod),
+				logUtil.Err(err),
+			)
+			return nil, toStatus(err)
+		}
+		if principal == nil {
+			return nil, toStatus(storage.ErrUnauthorized)
+		}
+
+		if !principal.HasScope(scope) {
+			return nil, newStatus(codes.PermissionDenied, storage.ErrForbidden.Code, "scope "+scope+" is required")
+		}
+
+		return handler(domainAuth.WithPrincipal(ctx, principal), req)
+	}
+}
+
+// streamAuthInterceptor пропускает только потоковые методы health и reflection:
+// у API нет потоковых методов, а scope для них не определены
+func streamAuthInterceptor(log *slog.Logger) grpc.StreamServerInterceptor {
+	log = log.With(
+		slog.String("component", "grpc/auth"),
+	)
+
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if isPublicMethod(info.FullMethod) {
+			return handler(srv, stream)
+		}
+
+		log.Error("stream method has no required scope", slog.String("method", info.FullMethod))
+		return newStatus(codes.PermissionDenied, storage.ErrForbidden.Code, "method is not allowed")
+	}
+}
+
+func firstMetadata(ctx context.Context, key string) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+
+	values := md.Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+
+	return values[0]
+}
@@ -0,0 +1,67 @@
+package grpcserver
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	authMiddleware "reviewer-service/internal/http-server/middleware/auth"
+	reviewerv1 "reviewer-service/pkg/api/reviewer/v1"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestAuthInterceptor_DeniesByDefault(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	interceptor := authInterceptor(log, authMiddleware.Options{})
+
+	tests := []struct {
+		name     string
+		method   string
+		wantCode codes.Code
+	}{
+		{name: "health", method: "/grpc.health.v1.Health/Check", wantCode: codes.OK},
+		{name: "unknown method", method: "/reviewer.v1.TeamService/DeleteTeam", wantCode: codes.PermissionDenied},
+		{name: "health lookalike", method: "/grpc.health.v1.HealthAdmin/Drop", wantCode: codes.PermissionDenied},
+		{name: "scoped method without credentials", method: reviewerv1.TeamService_GetTeam_FullMethodName, wantCode: codes.Unauthenticated},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called := false
+			handler := func(ctx context.Context, req any) (any, error) {
+				called = true
+				return nil, nil
+			}
+
+			_, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: tt.method}, handler)
+			assert.Equal(t, tt.wantCode, status.Code(err))
+			assert.Equal(t, tt.wantCode == codes.OK, called)
+		})
+	}
+}
+
+func TestStreamAuthInterceptor_AllowsOnlyPublicServices(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	interceptor := streamAuthInterceptor(log)
+	handler := func(srv any, stream grpc.ServerStream) error { return nil }
+
+	tests := []struct {
+		method   string
+		wantCode codes.Code
+	}{
+		{method: "/grpc.health.v1.Health/Watch", wantCode: codes.OK},
+		{method: "/grpc.reflection.v1.ServerReflection/ServerReflectionInfo", wantCode: codes.OK},
+		{method: "/reviewer.v1.PullRequestService/WatchPullRequests", wantCode: codes.PermissionDenied},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method, func(t *testing.T) {
+			err := interceptor(nil, nil, &grpc.StreamServerInfo{FullMethod: tt.method}, handler)
+			assert.Equal(t, tt.wantCode, status.Code(err))
+		})
+	}
+}
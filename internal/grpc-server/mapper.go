@@ -0,0 +1,112 @@
+package grpcserver
+
+import (
+	"reviewer-service/internal/domain/pullrequest"
+	"reviewer-service/internal/domain/team"
+	"reviewer-service/internal/domain/user"
+	reviewerv1 "reviewer-service/pkg/api/reviewer/v1"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func toTeamDomain(t *reviewerv1.Team) *team.Model {
+	users := make([]*user.Model, len(t.GetMembers()))
+	for i, member := range t.GetMembers() {
+		users[i] = &user.Model{
+			UserId:         member.GetUserId(),
+			Username:       member.GetUsername(),
+			TeamName:       t.GetTeamName(),
+			IsActive:       member.GetIsActive(),
+			ChatHandle:     member.GetChatHandle(),
+			MaxOpenReviews: int(member.GetMaxOpenReviews()),
+			Seniority:      member.GetSeniority(),
+		}
+	}
+
+	return &team.Model{
+		Name:                  t.GetTeamName(),
+		Members:               users,
+		DefaultMaxOpenReviews: int(t.GetDefaultMaxOpenReviews()),
+	}
+}
+
+func toTeamProto(teamModel *team.Model) *reviewerv1.Team {
+	members := make([]*reviewerv1.TeamMember, len(teamModel.Members))
+	for i, userModel := range teamModel.Members {
+		members[i] = &reviewerv1.TeamMember{
+			UserId:         userModel.UserId,
+			Username:       userModel.Username,
+			IsActive:       userModel.IsActive,
+			ChatHandle:     userModel.ChatHandle,
+			MaxOpenReviews: int32(userModel.MaxOpenReviews),
+			Seniority:      userModel.Seniority,
+		}
+	}
+
+	return &reviewerv1.Team{
+		TeamName:              teamModel.Name,
+		Members:               members,
+		DefaultMaxOpenReviews: int32(teamModel.DefaultMaxOpenReviews),
+	}
+}
+
+func toUserProto(userModel *user.Model) *reviewerv1.User {
+	return &reviewerv1.User{
+		UserId:         userModel.UserId,
+		Username:       userModel.Username,
+		TeamName:       userModel.TeamName,
+		IsActive:       userModel.IsActive,
+		ChatHandle:     userModel.ChatHandle,
+		MaxOpenReviews: int32(userModel.MaxOpenReviews),
+		Seniority:      userModel.Seniority,
+	}
+}
+
+func toPullRequestDomain(req *reviewerv1.CreatePullRequestRequest) *pullrequest.Model {
+	now := time.Now()
+	return &pullrequest.Model{
+		PullRequestId:     req.GetPullRequestId(),
+		PullRequestName:   req.GetPullRequestName(),
+		AuthorId:          req.GetAuthorId(),
+		RepositoryId:      req.GetRepositoryId(),
+		Number:            req.GetNumber(),
+		ChangedFiles:      req.GetChangedFiles(),
+		Status:            "OPEN",
+		AssignedReviewers: []string{},
+		CreatedAt:         &now,
+		MergedAt:          nil,
+	}
+}
+
+func toPullRequestProto(pr *pullrequest.Model) *reviewerv1.PullRequest {
+	result := &reviewerv1.PullRequest{
+		PullRequestId:     pr.PullRequestId,
+		PullRequestName:   pr.PullRequestName,
+		AuthorId:          pr.AuthorId,
+		Status:            pr.Status,
+		AssignedReviewers: pr.AssignedReviewers,
+		Version:           pr.Version,
+		RepositoryId:      pr.RepositoryId,
+		Number:            pr.Number,
+	}
+	if pr.MergedAt != nil {
+		result.MergedAt = timestamppb.New(*pr.MergedAt)
+	}
+	return result
+}
+
+func toPullRequestShortProtos(prs []*pullrequest.Model) []*reviewerv1.PullRequestShort {
+	result := make([]*reviewerv1.PullRequestShort, 0, len(prs))
+	for _, pr := range prs {
+		result = append(result, &reviewerv1.PullRequestShort{
+			PullRequestId:   pr.PullRequestId,
+			PullRequestName: pr.PullRequestName,
+			AuthorId:        pr.AuthorId,
+			Status:          pr.Status,
+			RepositoryId:    pr.RepositoryId,
+			Number:          pr.Number,
+		})
+	}
+	return result
+}
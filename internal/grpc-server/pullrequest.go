@@ -0,0 +1,113 @@
+package grpcserver
+
+import (
+	"context"
+	"log/slog"
+	"reviewer-service/internal/domain/event"
+	"reviewer-service/internal/domain/pullrequest"
+	logUtil "reviewer-service/internal/lib/logger/slog"
+	reviewerv1 "reviewer-service/pkg/api/reviewer/v1"
+
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+type PullRequestService struct {
+	reviewerv1.UnimplementedPullRequestServiceServer
+
+	log       *slog.Logger
+	txManager pullrequest.TransactionManager
+	repo      pullrequest.Repository
+	syncer    pullrequest.ReviewerSyncer
+	rnd       pullrequest.Random
+}
+
+func NewPullRequestService(log *slog.Logger, txManager pullrequest.TransactionManager, repo pullrequest.Repository, syncer pullrequest.ReviewerSyncer, rnd pullrequest.Random) *PullRequestService {
+	return &PullRequestService{
+		log:       log,
+		txManager: txManager,
+		repo:      repo,
+		syncer:    syncer,
+		rnd:       rnd,
+	}
+}
+
+func (s *PullRequestService) CreatePullRequest(ctx context.Context, req *reviewerv1.CreatePullRequestRequest) (*reviewerv1.CreatePullRequestResponse, error) {
+	const op = "grpc.pullrequest.CreatePullRequest"
+	log := s.log.With(
+		slog.String("operation", op),
+		slog.String("request_id", middleware.GetReqID(ctx)),
+	)
+
+	if req.GetPullRequestName() == "" || req.GetAuthorId() == "" {
+		return nil, invalidArgument("pull_request_name and author_id are required")
+	}
+	if req.GetRepositoryId() < 0 || req.GetNumber() < 0 || (req.GetRepositoryId() == 0) != (req.GetNumber() == 0) {
+		return nil, invalidArgument("repository_id and number must be positive and set together")
+	}
+	if req.GetPullRequestId() == "" && req.GetRepositoryId() == 0 {
+		return nil, invalidArgument("pull_request_id or repository_id with number is required")
+	}
+
+	createdPR, err := pullrequest.CreatePullRequest(ctx, log, s.txManager, s.repo, s.syncer, s.rnd, toPullRequestDomain(req), req.GetDryRun())
+	if err != nil {
+		log.Error("failed to create pull request", logUtil.Err(err))
+		return nil, toStatus(err)
+	}
+
+	return &reviewerv1.CreatePullRequestResponse{
+		Pr:           toPullRequestProto(createdPR),
+		UnderStaffed: createdPR.UnderStaffed,
+		MatchedRules: createdPR.MatchedRules,
+		DryRun:       req.GetDryRun(),
+	}, nil
+}
+
+func (s *PullRequestService) MergePullRequest(ctx context.Context, req *reviewerv1.MergePullRequestRequest) (*reviewerv1.MergePullRequestResponse, error) {
+	const op = "grpc.pullrequest.MergePullRequest"
+	log := s.log.With(
+		slog.String("operation", op),
+		slog.String("request_id", middleware.GetReqID(ctx)),
+	)
+
+	if req.GetPullRequestId() == "" {
+		return nil, invalidArgument("field pull_request_id is a required field")
+	}
+	if req.GetExpectedVersion() < 0 {
+		return nil, invalidArgument("expected_version must not be negative")
+	}
+
+	mergedPR, err := pullrequest.MergePullRequest(ctx, log, s.txManager, s.repo, req.GetPullRequestId(), req.GetExpectedVersion())
+	if err != nil {
+		log.Error("failed to merge pull request", logUtil.Err(err))
+		return nil, toStatus(err)
+	}
+
+	return &reviewerv1.MergePullRequestResponse{Pr: toPullRequestProto(mergedPR)}, nil
+}
+
+func (s *PullRequestService) ReassignReviewer(ctx context.Context, req *reviewerv1.ReassignReviewerRequest) (*reviewerv1.ReassignReviewerResponse, error) {
+	const op = "grpc.pullrequest.ReassignReviewer"
+	log := s.log.With(
+		slog.String("operation", op),
+		slog.String("request_id", middleware.GetReqID(ctx)),
+	)
+
+	if req.GetPullRequestId() == "" || req.GetOldReviewerId() == "" {
+		return nil, invalidArgument("pull_request_id and old_reviewer_id are required")
+	}
+	if req.GetExpectedVersion() < 0 {
+		return nil, invalidArgument("expected_version must not be negative")
+	}
+
+	updatedPR, newReviewerId, err := pullrequest.ReassignReviewer(ctx, log, s.txManager, s.repo, s.syncer, s.rnd, req.GetPullRequestId(), req.GetOldReviewerId(), req.GetExpectedVersion(), event.AssignReasonReassigned, req.GetDryRun())
+	if err != nil {
+		log.Error("failed to reassign reviewer", logUtil.Err(err))
+		return nil, toStatus(err)
+	}
+
+	return &reviewerv1.ReassignReviewerResponse{
+		Pr:         toPullRequestProto(updatedPR),
+		ReplacedBy: newReviewerId,
+		DryRun:     req.GetDryRun(),
+	}, nil
+}
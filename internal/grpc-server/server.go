@@ -0,0 +1,52 @@
+package grpcserver
+
+import (
+	"log/slog"
+	authMiddleware "reviewer-service/internal/http-server/middleware/auth"
+	reviewerv1 "reviewer-service/pkg/api/reviewer/v1"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+)
+
+type Options struct {
+	// Auth - те же настройки, что у HTTP middleware; nil отключает аутентификацию
+	Auth *authMiddleware.Options
+}
+
+// New создает gRPC сервер с перехватчиками, health и reflection.
+// Сервисы API регистрируются вызывающей стороной
+func New(log *slog.Logger, opts Options) *grpc.Server {
+	interceptors := []grpc.UnaryServerInterceptor{
+		requestIdInterceptor(),
+		loggerInterceptor(log),
+		recoveryInterceptor(log),
+	}
+	var streamInterceptors []grpc.StreamServerInterceptor
+	if opts.Auth != nil {
+		interceptors = append(interceptors, authInterceptor(log, *opts.Auth))
+		streamInterceptors = append(streamInterceptors, streamAuthInterceptor(log))
+	}
+
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(interceptors...),
+		grpc.ChainStreamInterceptor(streamInterceptors...),
+	)
+
+	healthServer := health.NewServer()
+	for _, service := range []string{
+		"",
+		reviewerv1.TeamService_ServiceDesc.ServiceName,
+		reviewerv1.UserService_ServiceDesc.ServiceName,
+		reviewerv1.PullRequestService_ServiceDesc.ServiceName,
+	} {
+		healthServer.SetServingStatus(service, healthpb.HealthCheckResponse_SERVING)
+	}
+	healthpb.RegisterHealthServer(server, healthServer)
+
+	reflection.Register(server)
+
+	return server
+}
@@ -0,0 +1,106 @@
+package grpcserver
+
+import (
+	"context"
+	"log/slog"
+	"reviewer-service/internal/domain/team"
+	"reviewer-service/internal/domain/user"
+	logUtil "reviewer-service/internal/lib/logger/slog"
+	reviewerv1 "reviewer-service/pkg/api/reviewer/v1"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-playground/validator/v10"
+)
+
+type TeamService struct {
+	reviewerv1.UnimplementedTeamServiceServer
+
+	log       *slog.Logger
+	txManager team.TransactionManager
+	repo      team.Repository
+}
+
+func NewTeamService(log *slog.Logger, txManager team.TransactionManager, repo team.Repository) *TeamService {
+	return &TeamService{
+		log:       log,
+		txManager: txManager,
+		repo:      repo,
+	}
+}
+
+func (s *TeamService) AddTeam(ctx context.Context, req *reviewerv1.AddTeamRequest) (*reviewerv1.AddTeamResponse, error) {
+	const op = "grpc.team.AddTeam"
+	log := s.log.With(
+		slog.String("operation", op),
+		slog.String("request_id", middleware.GetReqID(ctx)),
+	)
+
+	if req.GetTeam().GetTeamName() == "" {
+		return nil, invalidArgument("field team.team_name is a required field")
+	}
+	if req.GetTeam().GetDefaultMaxOpenReviews() < 0 {
+		return nil, invalidArgument("field team.default_max_open_reviews must be 0 or greater")
+	}
+	for _, member := range req.GetTeam().GetMembers() {
+		if member.GetMaxOpenReviews() < 0 {
+			return nil, invalidArgument("field team.members.max_open_reviews must be 0 or greater")
+		}
+		if member.GetSeniority() != "" && user.SeniorityRank(member.GetSeniority()) == 0 {
+			return nil, invalidArgument("field team.members.seniority must be one of junior, middle, senior, lead")
+		}
+	}
+
+	savedTeam, err := team.SaveTeam(ctx, log, s.txManager, s.repo, toTeamDomain(req.GetTeam()))
+	if err != nil {
+		log.Error("failed to save team", logUtil.Err(err))
+		return nil, toStatus(err)
+	}
+
+	return &reviewerv1.AddTeamResponse{Team: toTeamProto(savedTeam)}, nil
+}
+
+func (s *TeamService) GetTeam(ctx context.Context, req *reviewerv1.GetTeamRequest) (*reviewerv1.GetTeamResponse, error) {
+	const op = "grpc.team.GetTeam"
+	log := s.log.With(
+		slog.String("operation", op),
+		slog.String("request_id", middleware.GetReqID(ctx)),
+	)
+
+	if req.GetTeamName() == "" {
+		return nil, invalidArgument("field team_name is a required field")
+	}
+
+	teamModel, err := team.GetTeamByName(ctx, log, s.repo, req.GetTeamName())
+	if err != nil {
+		log.Error("failed to get team", slog.String("team_name", req.GetTeamName()), logUtil.Err(err))
+		return nil, toStatus(err)
+	}
+
+	return &reviewerv1.GetTeamResponse{Team: toTeamProto(teamModel)}, nil
+}
+
+func (s *TeamService) SetChatWebhook(ctx context.Context, req *reviewerv1.SetChatWebhookRequest) (*reviewerv1.SetChatWebhookResponse, error) {
+	const op = "grpc.team.SetChatWebhook"
+	log := s.log.With(
+		slog.String("operation", op),
+		slog.String("request_id", middleware.GetReqID(ctx)),
+	)
+
+	if req.GetTeamName() == "" {
+		return nil, invalidArgument("field team_name is a required field")
+	}
+	if err := validator.New().Var(req.GetWebhookUrl(), "omitempty,http_url"); err != nil {
+		return nil, invalidArgument("field webhook_url is not a valid URL")
+	}
+
+	err := team.SetChatWebhook(ctx, log, s.repo, req.GetTeamName(), req.GetWebhookUrl())
+	if err != nil {
+		log.Error("failed to set team chat webhook", slog.String("team_name", req.GetTeamName()), logUtil.Err(err))
+		return nil, toStatus(err)
+	}
+
+	return &reviewerv1.SetChatWebhookResponse{
+		TeamName:              req.GetTeamName(),
+		ChatWebhookConfigured: req.GetWebhookUrl() != "",
+	}, nil
+}
@@ -0,0 +1,106 @@
+package grpcserver
+
+import (
+	"context"
+	"log/slog"
+	"reviewer-service/internal/domain/auth"
+	"reviewer-service/internal/domain/pullrequest"
+	"reviewer-service/internal/domain/user"
+	logUtil "reviewer-service/internal/lib/logger/slog"
+	"reviewer-service/internal/storage"
+	reviewerv1 "reviewer-service/pkg/api/reviewer/v1"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"google.golang.org/grpc/codes"
+)
+
+type UserService struct {
+	reviewerv1.UnimplementedUserServiceServer
+
+	log       *slog.Logger
+	txManager user.TransactionManager
+	repo      user.Repository
+	prRepo    pullrequest.Repository
+}
+
+func NewUserService(log *slog.Logger, txManager user.TransactionManager, repo user.Repository, prRepo pullrequest.Repository) *UserService {
+	return &UserService{
+		log:       log,
+		txManager: txManager,
+		repo:      repo,
+		prRepo:    prRepo,
+	}
+}
+
+func (s *UserService) SetIsActive(ctx context.Context, req *reviewerv1.SetIsActiveRequest) (*reviewerv1.SetIsActiveResponse, error) {
+	const op = "grpc.user.SetIsActive"
+	log := s.log.With(
+		slog.String("operation", op),
+		slog.String("request_id", middleware.GetReqID(ctx)),
+	)
+
+	if req.GetUserId() == "" {
+		return nil, invalidArgument("field user_id is a required field")
+	}
+
+	updatedUser, err := user.SetUserIsActive(ctx, log, s.txManager, s.repo, req.GetUserId(), req.GetIsActive())
+	if err != nil {
+		log.Error("failed to update user", slog.String("user_id", req.GetUserId()), logUtil.Err(err))
+		return nil, toStatus(err)
+	}
+
+	return &reviewerv1.SetIsActiveResponse{User: toUserProto(updatedUser)}, nil
+}
+
+func (s *UserService) SetChatHandle(ctx context.Context, req *reviewerv1.SetChatHandleRequest) (*reviewerv1.SetChatHandleResponse, error) {
+	const op = "grpc.user.SetChatHandle"
+	log := s.log.With(
+		slog.String("operation", op),
+		slog.String("request_id", middleware.GetReqID(ctx)),
+	)
+
+	if req.GetUserId() == "" {
+		return nil, invalidArgument("field user_id is a required field")
+	}
+
+	updatedUser, err := user.SetUserChatHandle(ctx, log, s.repo, req.GetUserId(), req.GetChatHandle())
+	if err != nil {
+		log.Error("failed to update user chat handle", slog.String("user_id", req.GetUserId()), logUtil.Err(err))
+		return nil, toStatus(err)
+	}
+
+	return &reviewerv1.SetChatHandleResponse{User: toUserProto(updatedUser)}, nil
+}
+
+func (s *UserService) GetReview(ctx context.Context, req *reviewerv1.GetReviewRequest) (*reviewerv1.GetReviewResponse, error) {
+	const op = "grpc.user.GetReview"
+	log := s.log.With(
+		slog.String("operation", op),
+		slog.String("request_id", middleware.GetReqID(ctx)),
+	)
+
+	userId, err := auth.ResolveUserId(ctx, req.GetUserId())
+	if err != nil {
+		log.Error("user_id does not match token", logUtil.Err(err))
+		return nil, newStatus(codes.PermissionDenied, storage.ErrForbidden.Code, "user_id must match the authenticated user")
+	}
+
+	if userId == "" {
+		return nil, invalidArgument("field user_id is a required field")
+	}
+
+	if req.GetRepositoryId() < 0 {
+		return nil, invalidArgument("field repository_id must be positive")
+	}
+
+	prs, err := pullrequest.GetPullRequests(ctx, log, s.prRepo, userId, req.GetRepositoryId())
+	if err != nil {
+		log.Error("failed to get pull requests", slog.String("user_id", userId), logUtil.Err(err))
+		return nil, toStatus(err)
+	}
+
+	return &reviewerv1.GetReviewResponse{
+		UserId:       userId,
+		PullRequests: toPullRequestShortProtos(prs),
+	}, nil
+}
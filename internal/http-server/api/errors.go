@@ -0,0 +1,103 @@
+package api
+
+import (
+	"net/http"
+	"reviewer-service/internal/storage"
+
+	"github.com/go-chi/render"
+)
+
+const (
+	CodeInvalidRequest   = "INVALID_REQUEST"
+	CodeValidationError  = "VALIDATION_ERROR"
+	CodeInternalError    = "INTERNAL_ERROR"
+	CodeRateLimited      = "RATE_LIMITED"
+	CodeMethodNotAllowed = "METHOD_NOT_ALLOWED"
+)
+
+// ErrorResponse - единый формат ошибки REST API: {"error": {...}}
+type ErrorResponse struct {
+	Code    string         `json:"code"`
+	Message string         `json:"message"`
+	Details []*ErrorDetail `json:"details,omitempty"`
+}
+
+// ErrorDetail описывает одно невалидное поле запроса
+type ErrorDetail struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+type ErrorEnvelope struct {
+	Error *ErrorResponse `json:"error"`
+}
+
+// StatusCode - единственное сопоставление кодов ошибок с HTTP статусами
+func StatusCode(code string) int {
+	switch code {
+	case "NOT_FOUND":
+		return http.StatusNotFound
+	case "TEAM_EXISTS", "USER_EXISTS", "REPOSITORY_EXISTS", "IDENTITY_EXISTS":
+		return http.StatusBadRequest
+	case "PR_EXISTS", "PR_MERGED", "PR_CLOSED", "PR_DRAFT", "NOT_ASSIGNED", "NO_CANDIDATE":
+		return http.StatusConflict
+	case "VERSION_CONFLICT":
+		return http.StatusPreconditionFailed
+	case "UNAUTHORIZED", "INVALID_SIGNATURE":
+		return http.StatusUnauthorized
+	case "FORBIDDEN":
+		return http.StatusForbidden
+	case CodeRateLimited:
+		return http.StatusTooManyRequests
+	case CodeMethodNotAllowed:
+		return http.StatusMethodNotAllowed
+	case CodeValidationError, CodeInvalidRequest, "INVALID_SCOPE", "INVALID_ROLE", "INVALID_EVENT_TYPE", "INVALID_URL", "INVALID_PATTERN", "UNKNOWN_OWNER", "UNKNOWN_MEMBER":
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// ResponseError пишет ошибку со статусом, соответствующим коду
+func ResponseError(w http.ResponseWriter, r *http.Request, code, message string) {
+	responseError(w, r, &ErrorResponse{
+		Code:    code,
+		Message: message,
+	})
+}
+
+// ResponseStorageError пишет ошибку домена. Ошибки не из storage
+// считаются внутренними
+func ResponseStorageError(w http.ResponseWriter, r *http.Request, err error) {
+	if storageErr, ok := storage.IsError(err); ok {
+		ResponseError(w, r, storageErr.Code, storageErr.Message)
+		return
+	}
+	ResponseError(w, r, CodeInternalError, err.Error())
+}
+
+// ResponseValidationError пишет VALIDATION_ERROR с описанием каждого поля в details
+func ResponseValidationError(w http.ResponseWriter, r *http.Request, message string, details []*ErrorDetail) {
+	responseError(w, r, &ErrorResponse{
+		Code:    CodeValidationError,
+		Message: message,
+		Details: details,
+	})
+}
+
+// render.Status выставляет статус до записи тела, иначе
+// WriteHeader отправил бы ответ без Content-Type
+func responseError(w http.ResponseWriter, r *http.Request, errResponse *ErrorResponse) {
+	render.Status(r, StatusCode(errResponse.Code))
+	render.JSON(w, r, ErrorEnvelope{Error: errResponse})
+}
+
+// NotFound отвечает на неизвестные пути в формате ошибок API
+func NotFound(w http.ResponseWriter, r *http.Request) {
+	ResponseError(w, r, "NOT_FOUND", "route not found")
+}
+
+// MethodNotAllowed отвечает на известный путь с неподдерживаемым методом
+func MethodNotAllowed(w http.ResponseWriter, r *http.Request) {
+	ResponseError(w, r, CodeMethodNotAllowed, "method not allowed")
+}
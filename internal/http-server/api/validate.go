@@ -0,0 +1,93 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = newValidator()
+
+// newValidator называет поля в ошибках по json тегам, как их видит клиент
+func newValidator() *validator.Validate {
+	v := validator.New()
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			return ""
+		}
+		if name == "" {
+			return field.Name
+		}
+		return name
+	})
+	return v
+}
+
+// Validate проверяет запрос по тегам validate
+func Validate(req interface{}) error {
+	return validate.Struct(req)
+}
+
+// ResponseInvalidRequest пишет ошибку Validate: по полю на каждое нарушенное правило
+func ResponseInvalidRequest(w http.ResponseWriter, r *http.Request, err error) {
+	var validateErrs validator.ValidationErrors
+	if !errors.As(err, &validateErrs) {
+		ResponseError(w, r, CodeValidationError, err.Error())
+		return
+	}
+
+	details := make([]*ErrorDetail, 0, len(validateErrs))
+	messages := make([]string, 0, len(validateErrs))
+	for _, fieldErr := range validateErrs {
+		detail := &ErrorDetail{
+			Field:   fieldPath(fieldErr),
+			Message: fieldMessage(fieldErr),
+		}
+		details = append(details, detail)
+		messages = append(messages, detail.Field+" "+detail.Message)
+	}
+
+	ResponseValidationError(w, r, strings.Join(messages, ", "), details)
+}
+
+// fieldPath отрезает имя структуры запроса: SaveRequest.team.members[0].username -> team.members[0].username
+func fieldPath(fieldErr validator.FieldError) string {
+	_, path, found := strings.Cut(fieldErr.Namespace(), ".")
+	if !found {
+		return fieldErr.Field()
+	}
+	return path
+}
+
+func fieldMessage(fieldErr validator.FieldError) string {
+	switch fieldErr.ActualTag() {
+	case "required":
+		return "is required"
+	case "url", "http_url":
+		return "must be a valid URL"
+	case "oneof":
+		return "must be one of: " + fieldErr.Param()
+	case "min", "gte":
+		return fmt.Sprintf("must be at least %s%s", fieldErr.Param(), unit(fieldErr.Kind()))
+	case "max", "lte":
+		return fmt.Sprintf("must be at most %s%s", fieldErr.Param(), unit(fieldErr.Kind()))
+	default:
+		return "is not valid"
+	}
+}
+
+func unit(kind reflect.Kind) string {
+	switch kind {
+	case reflect.String:
+		return " characters"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return " items"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,40 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+// BasePath - префикс текущей версии REST API
+const BasePath = "/api/v1"
+
+// TrimBasePath возвращает путь без префикса версии. Так настройки маршрутов
+// и спецификация одинаково применяются к /api/v1 и к устаревшим путям
+func TrimBasePath(path string) string {
+	if trimmed, ok := strings.CutPrefix(path, BasePath); ok && strings.HasPrefix(trimmed, "/") {
+		return trimmed
+	}
+	return path
+}
+
+type legacyKey struct{}
+
+// Deprecated помечает устаревшие пути без версии: Deprecation и Link на тот же маршрут в /api/v1.
+// Обработчики узнают такой запрос через IsLegacy
+func Deprecated(next http.Handler) http.Handler {
+	fn := func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Deprecation", "true")
+		w.Header().Set("Link", "<"+BasePath+r.URL.Path+">; rel=\"successor-version\"")
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), legacyKey{}, true)))
+	}
+
+	return http.HandlerFunc(fn)
+}
+
+// IsLegacy сообщает, что запрос пришел по устаревшему пути без /api/v1.
+// Такие ответы сохраняют прежние имена полей
+func IsLegacy(ctx context.Context) bool {
+	legacy, _ := ctx.Value(legacyKey{}).(bool)
+	return legacy
+}
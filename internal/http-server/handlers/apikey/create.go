@@ -0,0 +1,57 @@
+package apikey
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"reviewer-service/internal/domain/apikey"
+	"reviewer-service/internal/http-server/api"
+	logUtil "reviewer-service/internal/lib/logger/slog"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+)
+
+func Create(log *slog.Logger, repo apikey.Repository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.apikey.Create"
+		log = log.With(
+			slog.String("operation", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		var req CreateRequest
+		err := render.DecodeJSON(r.Body, &req)
+		if errors.Is(err, io.EOF) {
+			log.Error("request body is empty")
+			api.ResponseError(w, r, "INVALID_REQUEST", "request body is empty")
+			return
+		}
+		if err != nil {
+			log.Error("failed to decode request body", logUtil.Err(err))
+			api.ResponseError(w, r, "INVALID_REQUEST", "failed to decode request")
+			return
+		}
+
+		if err := api.Validate(req); err != nil {
+			log.Error("invalid request", logUtil.Err(err))
+			api.ResponseInvalidRequest(w, r, err)
+			return
+		}
+
+		createdKey, rawKey, err := apikey.CreateAPIKey(r.Context(), log, repo, req.Name, req.Scopes)
+		if err != nil {
+			log.Error("failed to create api key", logUtil.Err(err))
+
+			api.ResponseStorageError(w, r, err)
+			return
+		}
+
+		render.Status(r, http.StatusCreated)
+		render.JSON(w, r, CreateResponse{
+			APIKey: toDto(createdKey),
+			Key:    rawKey,
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package apikey
+
+import (
+	"time"
+)
+
+type CreateRequest struct {
+	Name   string   `json:"name" validate:"required"`
+	Scopes []string `json:"scopes" validate:"required,min=1"`
+}
+
+type RevokeRequest struct {
+	ID int64 `json:"id" validate:"required"`
+}
+
+type APIKeyResponse struct {
+	ID        int64      `json:"id"`
+	Name      string     `json:"name"`
+	Prefix    string     `json:"prefix"`
+	Scopes    []string   `json:"scopes"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+}
+
+type CreateResponse struct {
+	APIKey *APIKeyResponse `json:"api_key,omitempty"`
+	Key    string          `json:"key,omitempty"`
+}
+
+type ListResponse struct {
+	APIKeys []*APIKeyResponse `json:"api_keys"`
+}
+
+type RevokeResponse struct {
+	APIKey *APIKeyResponse `json:"api_key,omitempty"`
+}
@@ -0,0 +1,33 @@
+package apikey
+
+import (
+	"log/slog"
+	"net/http"
+	"reviewer-service/internal/domain/apikey"
+	"reviewer-service/internal/http-server/api"
+	logUtil "reviewer-service/internal/lib/logger/slog"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+)
+
+func List(log *slog.Logger, repo apikey.Repository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.apikey.List"
+		log = log.With(
+			slog.String("operation", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		keys, err := apikey.ListAPIKeys(r.Context(), log, repo)
+		if err != nil {
+			log.Error("failed to list api keys", logUtil.Err(err))
+			api.ResponseError(w, r, "INTERNAL_ERROR", err.Error())
+			return
+		}
+
+		render.JSON(w, r, ListResponse{
+			APIKeys: toDtos(keys),
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package apikey
+
+import "reviewer-service/internal/domain/apikey"
+
+func toDto(key *apikey.Model) *APIKeyResponse {
+	scopes := key.Scopes
+	if scopes == nil {
+		scopes = []string{}
+	}
+	return &APIKeyResponse{
+		ID:        key.ID,
+		Name:      key.Name,
+		Prefix:    key.Prefix,
+		Scopes:    scopes,
+		CreatedAt: key.CreatedAt,
+		RevokedAt: key.RevokedAt,
+	}
+}
+
+func toDtos(keys []*apikey.Model) []*APIKeyResponse {
+	result := make([]*APIKeyResponse, 0, len(keys))
+	for _, key := range keys {
+		result = append(result, toDto(key))
+	}
+	return result
+}
@@ -0,0 +1,56 @@
+package availability
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"reviewer-service/internal/domain/availability"
+	"reviewer-service/internal/http-server/api"
+	logUtil "reviewer-service/internal/lib/logger/slog"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+)
+
+func Create(log *slog.Logger, repo availability.Repository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.availability.Create"
+		log = log.With(
+			slog.String("operation", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		var req CreateRequest
+		err := render.DecodeJSON(r.Body, &req)
+		if errors.Is(err, io.EOF) {
+			log.Error("request body is empty")
+			api.ResponseError(w, r, "INVALID_REQUEST", "request body is empty")
+			return
+		}
+		if err != nil {
+			log.Error("failed to decode request body", logUtil.Err(err))
+			api.ResponseError(w, r, "INVALID_REQUEST", "failed to decode request")
+			return
+		}
+
+		if err := api.Validate(req); err != nil {
+			log.Error("invalid request", logUtil.Err(err))
+			api.ResponseInvalidRequest(w, r, err)
+			return
+		}
+
+		created, err := availability.AddPeriod(r.Context(), log, repo, toDomain(&req))
+		if err != nil {
+			log.Error("failed to create unavailability period", slog.String("user_id", req.UserId), logUtil.Err(err))
+
+			api.ResponseStorageError(w, r, err)
+			return
+		}
+
+		render.Status(r, http.StatusCreated)
+		render.JSON(w, r, CreateResponse{
+			Period: toDto(created),
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package availability
+
+import "time"
+
+type CreateRequest struct {
+	UserId   string    `json:"user_id" validate:"required"`
+	StartsAt time.Time `json:"starts_at" validate:"required"`
+	EndsAt   time.Time `json:"ends_at" validate:"required,gtfield=StartsAt"`
+	Reason   string    `json:"reason" validate:"max=255"`
+}
+
+type DeleteRequest struct {
+	ID int64 `json:"id" validate:"required"`
+}
+
+type PeriodResponse struct {
+	ID        int64     `json:"id"`
+	UserId    string    `json:"user_id"`
+	StartsAt  time.Time `json:"starts_at"`
+	EndsAt    time.Time `json:"ends_at"`
+	Reason    string    `json:"reason,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type CreateResponse struct {
+	Period *PeriodResponse `json:"period,omitempty"`
+}
+
+type ListResponse struct {
+	Periods []*PeriodResponse `json:"periods"`
+}
+
+type DeleteResponse struct {
+	ID int64 `json:"id,omitempty"`
+}
@@ -0,0 +1,40 @@
+package availability
+
+import (
+	"log/slog"
+	"net/http"
+	"reviewer-service/internal/domain/availability"
+	"reviewer-service/internal/http-server/api"
+	logUtil "reviewer-service/internal/lib/logger/slog"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+)
+
+func List(log *slog.Logger, repo availability.Repository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.availability.List"
+		log = log.With(
+			slog.String("operation", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		userId := r.URL.Query().Get("user_id")
+		if userId == "" {
+			api.ResponseError(w, r, "INVALID_REQUEST", "user_id parameter is required")
+			return
+		}
+
+		periods, err := availability.ListPeriods(r.Context(), log, repo, userId)
+		if err != nil {
+			log.Error("failed to list unavailability periods", slog.String("user_id", userId), logUtil.Err(err))
+
+			api.ResponseStorageError(w, r, err)
+			return
+		}
+
+		render.JSON(w, r, ListResponse{
+			Periods: toDtos(periods),
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package availability
+
+import "reviewer-service/internal/domain/availability"
+
+func toDomain(dto *CreateRequest) *availability.Period {
+	return &availability.Period{
+		UserId:   dto.UserId,
+		StartsAt: dto.StartsAt,
+		EndsAt:   dto.EndsAt,
+		Reason:   dto.Reason,
+	}
+}
+
+func toDto(p *availability.Period) *PeriodResponse {
+	return &PeriodResponse{
+		ID:        p.ID,
+		UserId:    p.UserId,
+		StartsAt:  p.StartsAt,
+		EndsAt:    p.EndsAt,
+		Reason:    p.Reason,
+		CreatedAt: p.CreatedAt,
+	}
+}
+
+func toDtos(periods []*availability.Period) []*PeriodResponse {
+	result := make([]*PeriodResponse, 0, len(periods))
+	for _, p := range periods {
+		result = append(result, toDto(p))
+	}
+	return result
+}
@@ -0,0 +1,58 @@
+package identity
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"reviewer-service/internal/domain/webhook"
+	"reviewer-service/internal/http-server/api"
+	logUtil "reviewer-service/internal/lib/logger/slog"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+)
+
+func Delete(log *slog.Logger, repo webhook.IdentityRepository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.identity.Delete"
+		log = log.With(
+			slog.String("operation", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		var req DeleteRequest
+		err := render.DecodeJSON(r.Body, &req)
+		if errors.Is(err, io.EOF) {
+			log.Error("request body is empty")
+			api.ResponseError(w, r, "INVALID_REQUEST", "request body is empty")
+			return
+		}
+		if err != nil {
+			log.Error("failed to decode request body", logUtil.Err(err))
+			api.ResponseError(w, r, "INVALID_REQUEST", "failed to decode request")
+			return
+		}
+
+		if err := api.Validate(req); err != nil {
+			log.Error("invalid request", logUtil.Err(err))
+			api.ResponseInvalidRequest(w, r, err)
+			return
+		}
+
+		err = webhook.DeleteIdentity(r.Context(), log, repo, req.Provider, req.Login)
+		if err != nil {
+			log.Error("failed to delete git identity", slog.String("login", req.Login), logUtil.Err(err))
+
+			api.ResponseStorageError(w, r, err)
+			return
+		}
+
+		render.JSON(w, r, SetResponse{
+			Identity: &IdentityResponse{
+				Provider: req.Provider,
+				Login:    req.Login,
+			},
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package identity
+
+// SetRequest: external_id - числовой id пользователя на хостинге, по нему
+// определяется автор MR GitLab
+type SetRequest struct {
+	Provider   string `json:"provider" validate:"required,oneof=github gitlab"`
+	Login      string `json:"login" validate:"required"`
+	UserId     string `json:"user_id" validate:"required"`
+	ExternalId string `json:"external_id,omitempty" validate:"omitempty,numeric,max=64"`
+}
+
+type DeleteRequest struct {
+	Provider string `json:"provider" validate:"required,oneof=github gitlab"`
+	Login    string `json:"login" validate:"required"`
+}
+
+type IdentityResponse struct {
+	Provider   string `json:"provider"`
+	Login      string `json:"login"`
+	UserId     string `json:"user_id"`
+	ExternalId string `json:"external_id,omitempty"`
+}
+
+type SetResponse struct {
+	Identity *IdentityResponse `json:"identity,omitempty"`
+}
+
+type ListResponse struct {
+	Identities []*IdentityResponse `json:"identities"`
+}
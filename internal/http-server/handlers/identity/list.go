@@ -0,0 +1,33 @@
+package identity
+
+import (
+	"log/slog"
+	"net/http"
+	"reviewer-service/internal/domain/webhook"
+	"reviewer-service/internal/http-server/api"
+	logUtil "reviewer-service/internal/lib/logger/slog"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+)
+
+func List(log *slog.Logger, repo webhook.IdentityRepository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.identity.List"
+		log = log.With(
+			slog.String("operation", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		identities, err := webhook.ListIdentities(r.Context(), log, repo, r.URL.Query().Get("provider"))
+		if err != nil {
+			log.Error("failed to list git identities", logUtil.Err(err))
+			api.ResponseError(w, r, "INTERNAL_ERROR", err.Error())
+			return
+		}
+
+		render.JSON(w, r, ListResponse{
+			Identities: toDtos(identities),
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package identity
+
+import "reviewer-service/internal/domain/webhook"
+
+func toDomain(dto *SetRequest) *webhook.Identity {
+	return &webhook.Identity{
+		Provider:   dto.Provider,
+		Login:      dto.Login,
+		UserId:     dto.UserId,
+		ExternalId: dto.ExternalId,
+	}
+}
+
+func toDto(identity *webhook.Identity) *IdentityResponse {
+	return &IdentityResponse{
+		Provider:   identity.Provider,
+		Login:      identity.Login,
+		UserId:     identity.UserId,
+		ExternalId: identity.ExternalId,
+	}
+}
+
+func toDtos(identities []*webhook.Identity) []*IdentityResponse {
+	result := make([]*IdentityResponse, 0, len(identities))
+	for _, identity := range identities {
+		result = append(result, toDto(identity))
+	}
+	return result
+}
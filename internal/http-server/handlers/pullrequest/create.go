@@ -6,14 +6,15 @@ import (
 	"log/slog"
 	"net/http"
 	"reviewer-service/internal/domain/pullrequest"
-	"reviewer-service/internal/storage"
+	"reviewer-service/internal/http-server/api"
+	"reviewer-service/internal/lib/random"
 
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/render"
-	"github.com/go-playground/validator/v10"
 )
 
-func Create(log *slog.Logger, txManager pullrequest.TransactionManager, repo pullrequest.Repository) http.HandlerFunc {
+// Create выбирает ревьюверов источником rnd, если запрос не задал свой (random.WithSource)
+func Create(log *slog.Logger, txManager pullrequest.TransactionManager, repo pullrequest.Repository, syncer pullrequest.ReviewerSyncer, rnd pullrequest.Random) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		const op = "handlers.pullrequest.Create"
 		log = log.With(
@@ -25,40 +26,41 @@ func Create(log *slog.Logger, txManager pullrequest.TransactionManager, repo pul
 		err := render.DecodeJSON(r.Body, &req)
 		if errors.Is(err, io.EOF) {
 			log.Error("request body is empty")
-			responseError(w, r, http.StatusBadRequest, "INVALID_REQUEST", "request body is empty")
+			api.ResponseError(w, r, "INVALID_REQUEST", "request body is empty")
 			return
 		}
 		if err != nil {
 			log.Error("failed to decode request body", slog.String("error", err.Error()))
-			responseError(w, r, http.StatusBadRequest, "INVALID_REQUEST", "failed to decode request")
+			api.ResponseError(w, r, "INVALID_REQUEST", "failed to decode request")
 			return
 		}
 
-		if err := validator.New().Struct(req); err != nil {
-			var validateErr validator.ValidationErrors
-			errors.As(err, &validateErr)
-
+		if err := api.Validate(req); err != nil {
 			log.Error("invalid request", slog.String("error", err.Error()))
-			responseError(w, r, http.StatusBadRequest, "VALIDATION_ERROR", "invalid request")
+			api.ResponseInvalidRequest(w, r, err)
 			return
 		}
 
-		createdPR, err := pullrequest.CreatePullRequest(r.Context(), log, txManager, repo, toDomain(&req))
+		createdPR, err := pullrequest.CreatePullRequest(r.Context(), log, txManager, repo, syncer, random.FromContext(r.Context(), rnd), toDomain(&req), req.DryRun)
 		if err != nil {
 			log.Error("failed to create pull request", slog.String("error", err.Error()))
 
-			if storageErr, ok := storage.IsError(err); ok {
-				statusCode := getStatusCodeForError(storageErr.Code)
-				responseError(w, r, statusCode, storageErr.Code, storageErr.Message)
-			} else {
-				responseError(w, r, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
-			}
+			api.ResponseStorageError(w, r, err)
 			return
 		}
 
-		w.WriteHeader(http.StatusCreated)
+		prDto := toDto(r.Context(), createdPR)
+		if req.DryRun {
+			render.Status(r, http.StatusOK)
+		} else {
+			setETag(w, prDto)
+			render.Status(r, http.StatusCreated)
+		}
 		render.JSON(w, r, CreateResponse{
-			PR: toDto(createdPR),
+			PR:           prDto,
+			UnderStaffed: createdPR.UnderStaffed,
+			MatchedRules: createdPR.MatchedRules,
+			DryRun:       req.DryRun,
 		})
 	}
 }
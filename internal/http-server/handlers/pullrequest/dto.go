@@ -1,16 +1,25 @@
 package pullrequest
 
 import (
+	"errors"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
-
-	"github.com/go-chi/render"
 )
 
+// CreateRequest: PR задается либо pull_request_id, либо парой repository_id и number.
+// Если указаны оба, pull_request_id сохраняется как есть
 type CreateRequest struct {
-	PullRequestId   string `json:"pull_request_id" validate:"required"`
+	PullRequestId   string `json:"pull_request_id" validate:"required_without=RepositoryId"`
 	PullRequestName string `json:"pull_request_name" validate:"required"`
 	AuthorId        string `json:"author_id" validate:"required"`
+	RepositoryId    int64  `json:"repository_id,omitempty" validate:"gte=0"`
+	Number          int64  `json:"number,omitempty" validate:"required_with=RepositoryId,excluded_without=RepositoryId,gte=0"`
+	// ChangedFiles - пути измененных файлов для выбора владельцев кода
+	ChangedFiles []string `json:"changed_files,omitempty" validate:"max=10000,dive,required"`
+	// DryRun возвращает PR, который был бы создан, ничего не сохраняя
+	DryRun bool `json:"dry_run,omitempty"`
 }
 
 type PullRequestResponse struct {
@@ -19,38 +28,66 @@ type PullRequestResponse struct {
 	AuthorId          string     `json:"author_id"`
 	Status            string     `json:"status"`
 	AssignedReviewers []string   `json:"assigned_reviewers"`
-	MergedAt          *time.Time `json:"mergedAt,omitempty"`
+	MergedAt          *time.Time `json:"merged_at,omitempty"`
+	Version           int64      `json:"version"`
+	RepositoryId      int64      `json:"repository_id,omitempty"`
+	Number            int64      `json:"number,omitempty"`
+	// LegacyMergedAt - прежнее имя merged_at, заполняется вместо него только
+	// на устаревших путях без /api/v1
+	LegacyMergedAt *time.Time `json:"mergedAt,omitempty"`
 }
 
+// CreateResponse.UnderStaffed - свободных ревьюверов оказалось меньше двух,
+// MatchedRules - шаблон правила владельцев кода для каждого выбранного по нему ревьювера
 type CreateResponse struct {
-	PR    *PullRequestResponse `json:"pr,omitempty"`
-	Error *ErrorResponse       `json:"error,omitempty"`
+	PR           *PullRequestResponse `json:"pr,omitempty"`
+	UnderStaffed bool                 `json:"under_staffed"`
+	MatchedRules map[string]string    `json:"matched_rules,omitempty"`
+	DryRun       bool                 `json:"dry_run,omitempty"`
 }
 
-type ErrorResponse struct {
-	Code    string `json:"code"`
-	Message string `json:"message"`
+// SuggestedReviewerDTO: score - оценка rankCandidates, у недоступных участников 0;
+// selected - участник вошел бы в ревьюверы PR, созданного сейчас
+type SuggestedReviewerDTO struct {
+	UserId         string     `json:"user_id"`
+	Seniority      string     `json:"seniority,omitempty"`
+	Score          int        `json:"score"`
+	Selected       bool       `json:"selected"`
+	Available      bool       `json:"available"`
+	OpenReviews    int        `json:"open_reviews"`
+	LastReviewedAt *time.Time `json:"last_reviewed_at,omitempty"`
+	OwnerPattern   string     `json:"owner_pattern,omitempty"`
+	Reasons        []string   `json:"reasons"`
 }
 
-func responseError(w http.ResponseWriter, r *http.Request, statusCode int, code, message string) {
-	w.WriteHeader(statusCode)
-	render.JSON(w, r, CreateResponse{
-		Error: &ErrorResponse{
-			Code:    code,
-			Message: message,
-		},
-	})
+type SuggestReviewersResponse struct {
+	AuthorId     string                  `json:"author_id"`
+	TeamName     string                  `json:"team_name"`
+	Candidates   []*SuggestedReviewerDTO `json:"candidates"`
+	UnderStaffed bool                    `json:"under_staffed"`
 }
 
-func getStatusCodeForError(errorCode string) int {
-	switch errorCode {
-	case "PR_EXISTS":
-		return http.StatusConflict
-	case "NOT_FOUND":
-		return http.StatusNotFound
-	case "VALIDATION_ERROR", "INVALID_REQUEST":
-		return http.StatusBadRequest
-	default:
-		return http.StatusInternalServerError
+var errInvalidIfMatch = errors.New("If-Match must contain a single pull request version, e.g. \"3\"")
+
+// parseIfMatch возвращает версию PR из заголовка If-Match ("3" или W/"3").
+// Отсутствующий заголовок и "*" означают, что версия не проверяется (0)
+func parseIfMatch(r *http.Request) (int64, error) {
+	value := strings.TrimSpace(r.Header.Get("If-Match"))
+	if value == "" || value == "*" {
+		return 0, nil
+	}
+
+	value = strings.TrimPrefix(value, "W/")
+	value = strings.Trim(value, `"`)
+
+	version, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || version <= 0 {
+		return 0, errInvalidIfMatch
 	}
+
+	return version, nil
+}
+
+func setETag(w http.ResponseWriter, pr *PullRequestResponse) {
+	w.Header().Set("ETag", strconv.Quote(strconv.FormatInt(pr.Version, 10)))
 }
@@ -1,7 +1,9 @@
 package pullrequest
 
 import (
+	"context"
 	"reviewer-service/internal/domain/pullrequest"
+	"reviewer-service/internal/http-server/api"
 	"time"
 )
 
@@ -11,6 +13,9 @@ func toDomain(dto *CreateRequest) *pullrequest.Model {
 		PullRequestId:     dto.PullRequestId,
 		PullRequestName:   dto.PullRequestName,
 		AuthorId:          dto.AuthorId,
+		RepositoryId:      dto.RepositoryId,
+		Number:            dto.Number,
+		ChangedFiles:      dto.ChangedFiles,
 		Status:            "OPEN",
 		AssignedReviewers: []string{},
 		CreatedAt:         &now,
@@ -18,19 +23,50 @@ func toDomain(dto *CreateRequest) *pullrequest.Model {
 	}
 }
 
-func toDto(pr *pullrequest.Model) *PullRequestResponse {
+func toDto(ctx context.Context, pr *pullrequest.Model) *PullRequestResponse {
 	var assignedReviewers []string
 	if pr.AssignedReviewers != nil {
 		assignedReviewers = pr.AssignedReviewers
 	} else {
 		assignedReviewers = []string{}
 	}
-	return &PullRequestResponse{
+	dto := &PullRequestResponse{
 		PullRequestId:     pr.PullRequestId,
 		PullRequestName:   pr.PullRequestName,
 		AuthorId:          pr.AuthorId,
 		Status:            pr.Status,
 		AssignedReviewers: assignedReviewers,
 		MergedAt:          pr.MergedAt,
+		Version:           pr.Version,
+		RepositoryId:      pr.RepositoryId,
+		Number:            pr.Number,
+	}
+	if api.IsLegacy(ctx) {
+		dto.MergedAt, dto.LegacyMergedAt = nil, pr.MergedAt
+	}
+	return dto
+}
+
+func toSuggestReviewersResponse(suggestions *pullrequest.Suggestions) SuggestReviewersResponse {
+	candidates := make([]*SuggestedReviewerDTO, 0, len(suggestions.Candidates))
+	for _, suggestion := range suggestions.Candidates {
+		candidates = append(candidates, &SuggestedReviewerDTO{
+			UserId:         suggestion.UserId,
+			Seniority:      suggestion.Seniority,
+			Score:          suggestion.Score,
+			Selected:       suggestion.Selected,
+			Available:      suggestion.Available,
+			OpenReviews:    suggestion.OpenReviews,
+			LastReviewedAt: suggestion.LastReviewedAt,
+			OwnerPattern:   suggestion.OwnerPattern,
+			Reasons:        suggestion.Reasons,
+		})
+	}
+
+	return SuggestReviewersResponse{
+		AuthorId:     suggestions.AuthorId,
+		TeamName:     suggestions.TeamName,
+		Candidates:   candidates,
+		UnderStaffed: suggestions.UnderStaffed,
 	}
 }
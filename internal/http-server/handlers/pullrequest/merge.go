@@ -6,11 +6,10 @@ import (
 	"log/slog"
 	"net/http"
 	"reviewer-service/internal/domain/pullrequest"
-	"reviewer-service/internal/storage"
+	"reviewer-service/internal/http-server/api"
 
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/render"
-	"github.com/go-playground/validator/v10"
 )
 
 type MergeRequest struct {
@@ -18,8 +17,7 @@ type MergeRequest struct {
 }
 
 type MergeResponse struct {
-	PR    *PullRequestResponse `json:"pr,omitempty"`
-	Error *ErrorResponse       `json:"error,omitempty"`
+	PR *PullRequestResponse `json:"pr,omitempty"`
 }
 
 func Merge(log *slog.Logger, txManager pullrequest.TransactionManager, repo pullrequest.Repository) http.HandlerFunc {
@@ -34,49 +32,40 @@ func Merge(log *slog.Logger, txManager pullrequest.TransactionManager, repo pull
 		err := render.DecodeJSON(r.Body, &req)
 		if errors.Is(err, io.EOF) {
 			log.Error("request body is empty")
-			responseErrorMerge(w, r, http.StatusBadRequest, "INVALID_REQUEST", "request body is empty")
+			api.ResponseError(w, r, "INVALID_REQUEST", "request body is empty")
 			return
 		}
 		if err != nil {
 			log.Error("failed to decode request body", slog.String("error", err.Error()))
-			responseErrorMerge(w, r, http.StatusBadRequest, "INVALID_REQUEST", "failed to decode request")
+			api.ResponseError(w, r, "INVALID_REQUEST", "failed to decode request")
 			return
 		}
 
-		if err := validator.New().Struct(req); err != nil {
-			var validateErr validator.ValidationErrors
-			errors.As(err, &validateErr)
-
+		if err := api.Validate(req); err != nil {
 			log.Error("invalid request", slog.String("error", err.Error()))
-			responseErrorMerge(w, r, http.StatusBadRequest, "VALIDATION_ERROR", "invalid request")
+			api.ResponseInvalidRequest(w, r, err)
 			return
 		}
 
-		mergedPR, err := pullrequest.MergePullRequest(r.Context(), log, txManager, repo, req.PullRequestId)
+		expectedVersion, err := parseIfMatch(r)
+		if err != nil {
+			log.Error("invalid If-Match header", slog.String("error", err.Error()))
+			api.ResponseError(w, r, "INVALID_REQUEST", err.Error())
+			return
+		}
+
+		mergedPR, err := pullrequest.MergePullRequest(r.Context(), log, txManager, repo, req.PullRequestId, expectedVersion)
 		if err != nil {
 			log.Error("failed to merge pull request", slog.String("error", err.Error()))
 
-			if storageErr, ok := storage.IsError(err); ok {
-				statusCode := getStatusCodeForError(storageErr.Code)
-				responseErrorMerge(w, r, statusCode, storageErr.Code, storageErr.Message)
-			} else {
-				responseErrorMerge(w, r, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
-			}
+			api.ResponseStorageError(w, r, err)
 			return
 		}
 
+		prDto := toDto(r.Context(), mergedPR)
+		setETag(w, prDto)
 		render.JSON(w, r, MergeResponse{
-			PR: toDto(mergedPR),
+			PR: prDto,
 		})
 	}
 }
-
-func responseErrorMerge(w http.ResponseWriter, r *http.Request, statusCode int, code, message string) {
-	w.WriteHeader(statusCode)
-	render.JSON(w, r, MergeResponse{
-		Error: &ErrorResponse{
-			Code:    code,
-			Message: message,
-		},
-	})
-}
@@ -5,26 +5,30 @@ import (
 	"io"
 	"log/slog"
 	"net/http"
+	"reviewer-service/internal/domain/event"
 	"reviewer-service/internal/domain/pullrequest"
-	"reviewer-service/internal/storage"
+	"reviewer-service/internal/http-server/api"
+	"reviewer-service/internal/lib/random"
 
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/render"
-	"github.com/go-playground/validator/v10"
 )
 
 type ReassignRequest struct {
 	PullRequestId string `json:"pull_request_id" validate:"required"`
 	OldUserId     string `json:"old_reviewer_id" validate:"required"`
+	// DryRun возвращает, кто стал бы заменой, ничего не сохраняя
+	DryRun bool `json:"dry_run,omitempty"`
 }
 
 type ReassignResponse struct {
 	PR         *PullRequestResponse `json:"pr,omitempty"`
 	ReplacedBy string               `json:"replaced_by,omitempty"`
-	Error      *ErrorResponse       `json:"error,omitempty"`
+	DryRun     bool                 `json:"dry_run,omitempty"`
 }
 
-func Reassign(log *slog.Logger, txManager pullrequest.TransactionManager, repo pullrequest.Repository) http.HandlerFunc {
+// Reassign выбирает замену источником rnd, если запрос не задал свой (random.WithSource)
+func Reassign(log *slog.Logger, txManager pullrequest.TransactionManager, repo pullrequest.Repository, syncer pullrequest.ReviewerSyncer, rnd pullrequest.Random) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		const op = "handlers.pullrequest.Reassign"
 		log = log.With(
@@ -36,39 +40,42 @@ func Reassign(log *slog.Logger, txManager pullrequest.TransactionManager, repo p
 		err := render.DecodeJSON(r.Body, &req)
 		if errors.Is(err, io.EOF) {
 			log.Error("request body is empty")
-			responseErrorReassign(w, r, http.StatusBadRequest, "INVALID_REQUEST", "request body is empty")
+			api.ResponseError(w, r, "INVALID_REQUEST", "request body is empty")
 			return
 		}
 		if err != nil {
 			log.Error("failed to decode request body", slog.String("error", err.Error()))
-			responseErrorReassign(w, r, http.StatusBadRequest, "INVALID_REQUEST", "failed to decode request")
+			api.ResponseError(w, r, "INVALID_REQUEST", "failed to decode request")
 			return
 		}
 
-		if err := validator.New().Struct(req); err != nil {
-			var validateErr validator.ValidationErrors
-			errors.As(err, &validateErr)
-
+		if err := api.Validate(req); err != nil {
 			log.Error("invalid request", slog.String("error", err.Error()))
-			responseErrorReassign(w, r, http.StatusBadRequest, "VALIDATION_ERROR", "invalid request")
+			api.ResponseInvalidRequest(w, r, err)
+			return
+		}
+
+		expectedVersion, err := parseIfMatch(r)
+		if err != nil {
+			log.Error("invalid If-Match header", slog.String("error", err.Error()))
+			api.ResponseError(w, r, "INVALID_REQUEST", err.Error())
 			return
 		}
 
-		updatedPR, newReviewerId, err := pullrequest.ReassignReviewer(r.Context(), log, txManager, repo, req.PullRequestId, req.OldUserId)
+		updatedPR, newReviewerId, err := pullrequest.ReassignReviewer(r.Context(), log, txManager, repo, syncer, random.FromContext(r.Context(), rnd), req.PullRequestId, req.OldUserId, expectedVersion, event.AssignReasonReassigned, req.DryRun)
 		if err != nil {
 			log.Error("failed to reassign reviewer", slog.String("error", err.Error()))
 
-			if storageErr, ok := storage.IsError(err); ok {
-				statusCode := getStatusCodeForErrorReassign(storageErr.Code)
-				responseErrorReassign(w, r, statusCode, storageErr.Code, storageErr.Message)
-			} else {
-				responseErrorReassign(w, r, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
-			}
+			api.ResponseStorageError(w, r, err)
 			return
 		}
 
 		response := ReassignResponse{
-			PR: toDto(updatedPR),
+			PR:     toDto(r.Context(), updatedPR),
+			DryRun: req.DryRun,
+		}
+		if !req.DryRun {
+			setETag(w, response.PR)
 		}
 		if newReviewerId != "" {
 			response.ReplacedBy = newReviewerId
@@ -77,28 +84,3 @@ func Reassign(log *slog.Logger, txManager pullrequest.TransactionManager, repo p
 		render.JSON(w, r, response)
 	}
 }
-
-func responseErrorReassign(w http.ResponseWriter, r *http.Request, statusCode int, code, message string) {
-	w.WriteHeader(statusCode)
-	render.JSON(w, r, ReassignResponse{
-		Error: &ErrorResponse{
-			Code:    code,
-			Message: message,
-		},
-	})
-}
-
-func getStatusCodeForErrorReassign(errorCode string) int {
-	switch errorCode {
-	case "PR_EXISTS":
-		return http.StatusConflict
-	case "PR_MERGED", "NOT_ASSIGNED", "NO_CANDIDATE":
-		return http.StatusConflict
-	case "NOT_FOUND":
-		return http.StatusNotFound
-	case "VALIDATION_ERROR", "INVALID_REQUEST":
-		return http.StatusBadRequest
-	default:
-		return http.StatusInternalServerError
-	}
-}
@@ -0,0 +1,63 @@
+package pullrequest
+
+import (
+	"log/slog"
+	"net/http"
+	"reviewer-service/internal/domain/auth"
+	"reviewer-service/internal/domain/pullrequest"
+	"reviewer-service/internal/http-server/api"
+	"reviewer-service/internal/lib/random"
+	"reviewer-service/internal/storage"
+	"strconv"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+)
+
+// maxSuggestedReviewers - наибольший count, как и число ревьюверов репозитория
+const maxSuggestedReviewers = 10
+
+// SuggestReviewers упорядочивает равных кандидатов источником rnd, если запрос
+// не задал свой (random.WithSource)
+func SuggestReviewers(log *slog.Logger, repo pullrequest.Repository, rnd pullrequest.Random) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.pullrequest.SuggestReviewers"
+		log = log.With(
+			slog.String("operation", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		query := r.URL.Query()
+
+		authorId, err := auth.ResolveUserId(r.Context(), query.Get("author_id"))
+		if err != nil {
+			log.Error("author_id does not match token", slog.String("error", err.Error()))
+			api.ResponseError(w, r, storage.ErrForbidden.Code, "author_id must match the authenticated user")
+			return
+		}
+		if authorId == "" {
+			api.ResponseError(w, r, "INVALID_REQUEST", "author_id parameter is required")
+			return
+		}
+
+		count := pullrequest.ReviewersPerPR
+		if rawCount := query.Get("count"); rawCount != "" {
+			count, err = strconv.Atoi(rawCount)
+			if err != nil || count <= 0 || count > maxSuggestedReviewers {
+				log.Error("invalid count", slog.String("count", rawCount))
+				api.ResponseError(w, r, "VALIDATION_ERROR", "count must be a number from 1 to 10")
+				return
+			}
+		}
+
+		suggestions, err := pullrequest.SuggestReviewers(r.Context(), log, repo, random.FromContext(r.Context(), rnd), authorId, query["changed_files"], count)
+		if err != nil {
+			log.Error("failed to suggest reviewers", slog.String("author_id", authorId), slog.String("error", err.Error()))
+
+			api.ResponseStorageError(w, r, err)
+			return
+		}
+
+		render.JSON(w, r, toSuggestReviewersResponse(suggestions))
+	}
+}
@@ -0,0 +1,37 @@
+package repository
+
+import "time"
+
+type CreateRequest struct {
+	Host     string `json:"host" validate:"required,max=255"`
+	Name     string `json:"name" validate:"required,max=255"`
+	TeamName string `json:"team_name" validate:"required"`
+	// ReviewersCount = 0 - число ревьюверов по умолчанию
+	ReviewersCount int `json:"reviewers_count" validate:"gte=0,lte=10"`
+}
+
+type SetReviewersCountRequest struct {
+	ID             int64 `json:"id" validate:"required"`
+	ReviewersCount int   `json:"reviewers_count" validate:"gte=0,lte=10"`
+}
+
+type RepositoryResponse struct {
+	ID             int64     `json:"id"`
+	Host           string    `json:"host"`
+	Name           string    `json:"name"`
+	TeamName       string    `json:"team_name"`
+	ReviewersCount int       `json:"reviewers_count,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+type CreateResponse struct {
+	Repository *RepositoryResponse `json:"repository,omitempty"`
+}
+
+type SetReviewersCountResponse struct {
+	Repository *RepositoryResponse `json:"repository,omitempty"`
+}
+
+type ListResponse struct {
+	Repositories []*RepositoryResponse `json:"repositories"`
+}
@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"log/slog"
+	"net/http"
+	"reviewer-service/internal/domain/repository"
+	"reviewer-service/internal/http-server/api"
+	logUtil "reviewer-service/internal/lib/logger/slog"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+)
+
+// List возвращает репозитории: ?team_name=... - только репозитории команды
+func List(log *slog.Logger, repo repository.Repository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.repository.List"
+		log = log.With(
+			slog.String("operation", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		teamName := r.URL.Query().Get("team_name")
+
+		repositories, err := repository.List(r.Context(), log, repo, teamName)
+		if err != nil {
+			log.Error("failed to list repositories", slog.String("team_name", teamName), logUtil.Err(err))
+
+			api.ResponseStorageError(w, r, err)
+			return
+		}
+
+		render.JSON(w, r, ListResponse{
+			Repositories: toDtos(repositories),
+		})
+	}
+}
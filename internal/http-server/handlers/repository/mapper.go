@@ -0,0 +1,31 @@
+package repository
+
+import "reviewer-service/internal/domain/repository"
+
+func toDomain(dto *CreateRequest) *repository.Model {
+	return &repository.Model{
+		Host:           dto.Host,
+		Name:           dto.Name,
+		TeamName:       dto.TeamName,
+		ReviewersCount: dto.ReviewersCount,
+	}
+}
+
+func toDto(m *repository.Model) *RepositoryResponse {
+	return &RepositoryResponse{
+		ID:             m.ID,
+		Host:           m.Host,
+		Name:           m.Name,
+		TeamName:       m.TeamName,
+		ReviewersCount: m.ReviewersCount,
+		CreatedAt:      m.CreatedAt,
+	}
+}
+
+func toDtos(repositories []*repository.Model) []*RepositoryResponse {
+	result := make([]*RepositoryResponse, 0, len(repositories))
+	for _, m := range repositories {
+		result = append(result, toDto(m))
+	}
+	return result
+}
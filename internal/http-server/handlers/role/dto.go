@@ -0,0 +1,21 @@
+package role
+
+type RoleRequest struct {
+	UserId   string `json:"user_id" validate:"required"`
+	Role     string `json:"role" validate:"required,oneof=admin team_lead"`
+	TeamName string `json:"team_name,omitempty"`
+}
+
+type RoleResponse struct {
+	UserId   string `json:"user_id"`
+	Role     string `json:"role"`
+	TeamName string `json:"team_name,omitempty"`
+}
+
+type GrantResponse struct {
+	Role *RoleResponse `json:"role,omitempty"`
+}
+
+type ListResponse struct {
+	Roles []*RoleResponse `json:"roles"`
+}
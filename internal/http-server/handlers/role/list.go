@@ -0,0 +1,33 @@
+package role
+
+import (
+	"log/slog"
+	"net/http"
+	"reviewer-service/internal/domain/auth"
+	"reviewer-service/internal/http-server/api"
+	logUtil "reviewer-service/internal/lib/logger/slog"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+)
+
+func List(log *slog.Logger, repo auth.RoleAdminRepository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.role.List"
+		log = log.With(
+			slog.String("operation", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		roles, err := auth.ListRoles(r.Context(), log, repo, r.URL.Query().Get("user_id"))
+		if err != nil {
+			log.Error("failed to list roles", logUtil.Err(err))
+			api.ResponseError(w, r, "INTERNAL_ERROR", err.Error())
+			return
+		}
+
+		render.JSON(w, r, ListResponse{
+			Roles: toDtos(roles),
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package role
+
+import "reviewer-service/internal/domain/auth"
+
+func toDomain(dto *RoleRequest) *auth.Role {
+	return &auth.Role{
+		UserId:   dto.UserId,
+		Role:     dto.Role,
+		TeamName: dto.TeamName,
+	}
+}
+
+func toDto(role *auth.Role) *RoleResponse {
+	return &RoleResponse{
+		UserId:   role.UserId,
+		Role:     role.Role,
+		TeamName: role.TeamName,
+	}
+}
+
+func toDtos(roles []*auth.Role) []*RoleResponse {
+	result := make([]*RoleResponse, 0, len(roles))
+	for _, role := range roles {
+		result = append(result, toDto(role))
+	}
+	return result
+}
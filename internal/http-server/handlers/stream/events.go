@@ -0,0 +1,189 @@
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"reviewer-service/internal/domain/event"
+	"reviewer-service/internal/http-server/api"
+	logUtil "reviewer-service/internal/lib/logger/slog"
+	"reviewer-service/internal/stream"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+const replayBatchSize = 500
+
+type Repository interface {
+	GetEventsAfter(ctx context.Context, afterId int64, types []string, teamName string, limit int) ([]*event.Event, error)
+}
+
+// Events отдает поток событий PR в формате Server-Sent Events:
+// ?team_name=...&user_id=...&types=pr.created,pr.merged
+// С заголовком Last-Event-ID (или параметром last_event_id) сначала
+// досылаются события из журнала после указанного ID, затем идут новые
+func Events(log *slog.Logger, hub *stream.Hub, repo Repository, heartbeat time.Duration) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.stream.Events"
+		log = log.With(
+			slog.String("operation", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		query := r.URL.Query()
+
+		filter, err := parseFilter(query)
+		if err != nil {
+			log.Error("invalid stream filter", logUtil.Err(err))
+			api.ResponseError(w, r, "VALIDATION_ERROR", err.Error())
+			return
+		}
+
+		lastEventId, err := parseLastEventId(r.Header.Get("Last-Event-ID"), query.Get("last_event_id"))
+		if err != nil {
+			log.Error("invalid Last-Event-ID", logUtil.Err(err))
+			api.ResponseError(w, r, "VALIDATION_ERROR", err.Error())
+			return
+		}
+
+		// Подписка до чтения журнала, чтобы не потерять события между ними
+		sub := hub.Subscribe(filter)
+		defer hub.Unsubscribe(sub)
+
+		rc := http.NewResponseController(w)
+		// Поток живет дольше WriteTimeout сервера
+		if err := rc.SetWriteDeadline(time.Time{}); err != nil && !errors.Is(err, http.ErrNotSupported) {
+			log.Error("failed to reset write deadline", logUtil.Err(err))
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Connection", "keep-alive")
+		w.Header().Set("X-Accel-Buffering", "no")
+		w.WriteHeader(http.StatusOK)
+
+		if _, err := fmt.Fprint(w, "retry: 3000\n\n"); err != nil {
+			return
+		}
+
+		ctx := r.Context()
+
+		replayed := make(map[int64]struct{})
+		for afterId := lastEventId; lastEventId > 0; {
+			events, err := repo.GetEventsAfter(ctx, afterId, filter.Types, filter.TeamName, replayBatchSize)
+			if err != nil {
+				log.Error("failed to replay events", logUtil.Int64("after_id", afterId), logUtil.Err(err))
+				return
+			}
+
+			for _, e := range events {
+				afterId = e.ID
+				replayed[e.ID] = struct{}{}
+				if !filter.Match(e) {
+					continue
+				}
+				if err := writeEvent(w, e); err != nil {
+					return
+				}
+			}
+
+			if len(events) < replayBatchSize {
+				break
+			}
+		}
+
+		if err := rc.Flush(); err != nil {
+			log.Error("streaming is not supported", logUtil.Err(err))
+			return
+		}
+
+		log.Info("event stream opened", logUtil.Int64("last_event_id", lastEventId), slog.Int("replayed", len(replayed)))
+
+		ticker := time.NewTicker(heartbeat)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+
+			case e, ok := <-sub.Events():
+				if !ok {
+					if sub.Lagged() {
+						log.Warn("event stream client lagged behind, closing")
+					}
+					return
+				}
+				if _, ok := replayed[e.ID]; ok {
+					continue
+				}
+				if err := writeEvent(w, e); err != nil {
+					return
+				}
+
+			case <-ticker.C:
+				if _, err := fmt.Fprint(w, ": ping\n\n"); err != nil {
+					return
+				}
+			}
+
+			if err := rc.Flush(); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func parseFilter(query url.Values) (stream.Filter, error) {
+	filter := stream.Filter{
+		Types:    stream.Types,
+		TeamName: query.Get("team_name"),
+		UserId:   query.Get("user_id"),
+	}
+
+	if rawTypes := query.Get("types"); rawTypes != "" {
+		filter.Types = strings.Split(rawTypes, ",")
+		for _, eventType := range filter.Types {
+			if !slices.Contains(stream.Types, eventType) {
+				return filter, fmt.Errorf("unknown event type %q", eventType)
+			}
+		}
+	}
+
+	return filter, nil
+}
+
+func parseLastEventId(header string, param string) (int64, error) {
+	raw := header
+	if raw == "" {
+		raw = param
+	}
+	if raw == "" {
+		return 0, nil
+	}
+
+	id, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil || id < 0 {
+		return 0, fmt.Errorf("Last-Event-ID must be a non-negative event id")
+	}
+
+	return id, nil
+}
+
+func writeEvent(w http.ResponseWriter, e *event.Event) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", e.ID, e.Type, data)
+	return err
+}
@@ -0,0 +1,68 @@
+package subscription
+
+import (
+	"log/slog"
+	"net/http"
+	"reviewer-service/internal/domain/subscription"
+	"reviewer-service/internal/http-server/api"
+	logUtil "reviewer-service/internal/lib/logger/slog"
+	"strconv"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+)
+
+// Deliveries возвращает журнал доставок подписки:
+// ?subscription_id=...&status=pending|delivered|dead&limit=...
+func Deliveries(log *slog.Logger, repo subscription.Repository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.subscription.Deliveries"
+		log = log.With(
+			slog.String("operation", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		query := r.URL.Query()
+
+		subscriptionId, err := strconv.ParseInt(query.Get("subscription_id"), 10, 64)
+		if err != nil || subscriptionId <= 0 {
+			log.Error("invalid subscription_id", slog.String("subscription_id", query.Get("subscription_id")))
+			api.ResponseError(w, r, "VALIDATION_ERROR", "subscription_id is required")
+			return
+		}
+
+		filter := subscription.DeliveryFilter{
+			SubscriptionId: subscriptionId,
+			Status:         query.Get("status"),
+		}
+
+		switch filter.Status {
+		case "", subscription.DeliveryPending, subscription.DeliveryDelivered, subscription.DeliveryDead:
+		default:
+			log.Error("invalid status", slog.String("status", filter.Status))
+			api.ResponseError(w, r, "VALIDATION_ERROR", "status must be pending, delivered or dead")
+			return
+		}
+
+		if rawLimit := query.Get("limit"); rawLimit != "" {
+			filter.Limit, err = strconv.Atoi(rawLimit)
+			if err != nil || filter.Limit <= 0 {
+				log.Error("invalid limit", slog.String("limit", rawLimit))
+				api.ResponseError(w, r, "VALIDATION_ERROR", "limit must be a positive number")
+				return
+			}
+		}
+
+		deliveries, err := subscription.ListDeliveries(r.Context(), log, repo, filter)
+		if err != nil {
+			log.Error("failed to list subscription deliveries", logUtil.Int64("subscription_id", subscriptionId), logUtil.Err(err))
+
+			api.ResponseStorageError(w, r, err)
+			return
+		}
+
+		render.JSON(w, r, DeliveriesResponse{
+			Deliveries: deliveriesToDtos(deliveries),
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package subscription
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type CreateRequest struct {
+	URL        string   `json:"url" validate:"required,http_url"`
+	EventTypes []string `json:"event_types" validate:"required,min=1,dive,required"`
+	TeamName   string   `json:"team_name"`
+	Secret     string   `json:"secret" validate:"omitempty,min=16"`
+}
+
+type DeleteRequest struct {
+	ID int64 `json:"id" validate:"required"`
+}
+
+type RetryRequest struct {
+	ID int64 `json:"id" validate:"required"`
+}
+
+type SubscriptionResponse struct {
+	ID         int64     `json:"id"`
+	URL        string    `json:"url"`
+	EventTypes []string  `json:"event_types"`
+	TeamName   string    `json:"team_name,omitempty"`
+	Active     bool      `json:"active"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type DeliveryResponse struct {
+	ID             int64           `json:"id"`
+	SubscriptionId int64           `json:"subscription_id"`
+	EventId        int64           `json:"event_id"`
+	EventType      string          `json:"event_type"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	NextAttemptAt  time.Time       `json:"next_attempt_at"`
+	LastStatusCode int             `json:"last_status_code,omitempty"`
+	LastError      string          `json:"last_error,omitempty"`
+	CreatedAt      time.Time       `json:"created_at"`
+	DeliveredAt    *time.Time      `json:"delivered_at,omitempty"`
+	Payload        json.RawMessage `json:"payload"`
+}
+
+type CreateResponse struct {
+	Subscription *SubscriptionResponse `json:"subscription,omitempty"`
+	Secret       string                `json:"secret,omitempty"`
+}
+
+type ListResponse struct {
+	Subscriptions []*SubscriptionResponse `json:"subscriptions"`
+}
+
+type DeleteResponse struct {
+	ID int64 `json:"id,omitempty"`
+}
+
+type DeliveriesResponse struct {
+	Deliveries []*DeliveryResponse `json:"deliveries"`
+}
+
+type RetryResponse struct {
+	Delivery *DeliveryResponse `json:"delivery,omitempty"`
+}
@@ -0,0 +1,34 @@
+package subscription
+
+import (
+	"log/slog"
+	"net/http"
+	"reviewer-service/internal/domain/subscription"
+	"reviewer-service/internal/http-server/api"
+	logUtil "reviewer-service/internal/lib/logger/slog"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+)
+
+func List(log *slog.Logger, repo subscription.Repository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.subscription.List"
+		log = log.With(
+			slog.String("operation", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		subscriptions, err := subscription.ListSubscriptions(r.Context(), log, repo, r.URL.Query().Get("team_name"))
+		if err != nil {
+			log.Error("failed to list subscriptions", logUtil.Err(err))
+
+			api.ResponseStorageError(w, r, err)
+			return
+		}
+
+		render.JSON(w, r, ListResponse{
+			Subscriptions: toDtos(subscriptions),
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package subscription
+
+import "reviewer-service/internal/domain/subscription"
+
+func toDomain(dto *CreateRequest) *subscription.Subscription {
+	return &subscription.Subscription{
+		URL:        dto.URL,
+		Secret:     dto.Secret,
+		EventTypes: dto.EventTypes,
+		TeamName:   dto.TeamName,
+	}
+}
+
+func toDto(s *subscription.Subscription) *SubscriptionResponse {
+	return &SubscriptionResponse{
+		ID:         s.ID,
+		URL:        s.URL,
+		EventTypes: s.EventTypes,
+		TeamName:   s.TeamName,
+		Active:     s.Active,
+		CreatedAt:  s.CreatedAt,
+	}
+}
+
+func toDtos(subscriptions []*subscription.Subscription) []*SubscriptionResponse {
+	result := make([]*SubscriptionResponse, 0, len(subscriptions))
+	for _, s := range subscriptions {
+		result = append(result, toDto(s))
+	}
+	return result
+}
+
+func deliveryToDto(d *subscription.Delivery) *DeliveryResponse {
+	return &DeliveryResponse{
+		ID:             d.ID,
+		SubscriptionId: d.SubscriptionId,
+		EventId:        d.EventId,
+		EventType:      d.EventType,
+		Status:         d.Status,
+		Attempts:       d.Attempts,
+		NextAttemptAt:  d.NextAttemptAt,
+		LastStatusCode: d.LastStatusCode,
+		LastError:      d.LastError,
+		CreatedAt:      d.CreatedAt,
+		DeliveredAt:    d.DeliveredAt,
+		Payload:        d.Payload,
+	}
+}
+
+func deliveriesToDtos(deliveries []*subscription.Delivery) []*DeliveryResponse {
+	result := make([]*DeliveryResponse, 0, len(deliveries))
+	for _, d := range deliveries {
+		result = append(result, deliveryToDto(d))
+	}
+	return result
+}
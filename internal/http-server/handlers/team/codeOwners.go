@@ -0,0 +1,119 @@
+package team
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"reviewer-service/internal/domain/codeowners"
+	"reviewer-service/internal/http-server/api"
+	logUtil "reviewer-service/internal/lib/logger/slog"
+	"strings"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+)
+
+func SetCodeOwners(log *slog.Logger, txManager codeowners.TransactionManager, repo codeowners.Repository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.team.SetCodeOwners"
+		log = log.With(
+			slog.String("operation", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		var req SetCodeOwnersRequest
+		err := render.DecodeJSON(r.Body, &req)
+		if errors.Is(err, io.EOF) {
+			log.Error("request body is empty")
+			api.ResponseError(w, r, "INVALID_REQUEST", "request body is empty")
+			return
+		}
+		if err != nil {
+			log.Error("failed to decode request body", logUtil.Err(err))
+			api.ResponseError(w, r, "INVALID_REQUEST", "failed to decode request")
+			return
+		}
+
+		if err := api.Validate(req); err != nil {
+			log.Error("invalid request", logUtil.Err(err))
+			api.ResponseInvalidRequest(w, r, err)
+			return
+		}
+
+		rules, err := codeowners.SetRules(r.Context(), log, txManager, repo, req.TeamName, toCodeOwnerRules(req.Rules))
+		if err != nil {
+			log.Error("failed to set team code owners", slog.String("team_name", req.TeamName), logUtil.Err(err))
+
+			api.ResponseStorageError(w, r, err)
+			return
+		}
+
+		render.JSON(w, r, toCodeOwnersDto(req.TeamName, rules))
+	}
+}
+
+func ImportCodeOwners(log *slog.Logger, txManager codeowners.TransactionManager, repo codeowners.Repository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.team.ImportCodeOwners"
+		log = log.With(
+			slog.String("operation", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		var req ImportCodeOwnersRequest
+		err := render.DecodeJSON(r.Body, &req)
+		if errors.Is(err, io.EOF) {
+			log.Error("request body is empty")
+			api.ResponseError(w, r, "INVALID_REQUEST", "request body is empty")
+			return
+		}
+		if err != nil {
+			log.Error("failed to decode request body", logUtil.Err(err))
+			api.ResponseError(w, r, "INVALID_REQUEST", "failed to decode request")
+			return
+		}
+
+		if err := api.Validate(req); err != nil {
+			log.Error("invalid request", logUtil.Err(err))
+			api.ResponseInvalidRequest(w, r, err)
+			return
+		}
+
+		result, err := codeowners.Import(r.Context(), log, txManager, repo, req.TeamName, strings.NewReader(req.CodeOwners))
+		if err != nil {
+			log.Error("failed to import team code owners", slog.String("team_name", req.TeamName), logUtil.Err(err))
+
+			api.ResponseStorageError(w, r, err)
+			return
+		}
+
+		render.JSON(w, r, toImportCodeOwnersDto(req.TeamName, result))
+	}
+}
+
+func GetCodeOwners(log *slog.Logger, repo codeowners.Repository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.team.GetCodeOwners"
+		log = log.With(
+			slog.String("operation", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		teamName := r.URL.Query().Get("team_name")
+		if teamName == "" {
+			api.ResponseError(w, r, "INVALID_REQUEST", "team_name parameter is required")
+			return
+		}
+
+		rules, err := codeowners.GetRules(r.Context(), log, repo, teamName)
+		if err != nil {
+			log.Error("failed to get team code owners", slog.String("team_name", teamName), logUtil.Err(err))
+
+			api.ResponseStorageError(w, r, err)
+			return
+		}
+
+		render.JSON(w, r, toCodeOwnersDto(teamName, rules))
+	}
+}
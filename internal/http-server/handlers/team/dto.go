@@ -1,75 +1,137 @@
 package team
 
-import (
-	"fmt"
-	"net/http"
-	"strings"
-
-	"github.com/go-chi/render"
-	"github.com/go-playground/validator/v10"
-)
+import "time"
 
 type DTO struct {
 	Name    string    `json:"team_name" validate:"required"`
 	Members []*Member `json:"members,omitempty" validate:"dive" required:"true"`
+	// DefaultMaxOpenReviews = 0 - без лимита
+	DefaultMaxOpenReviews int `json:"default_max_open_reviews,omitempty" validate:"gte=0"`
 }
 
 type Member struct {
-	UserId   string `json:"user_id" required:"true"`
-	Username string `json:"username" required:"true"`
-	IsActive bool   `json:"is_active" required:"true"`
+	UserId     string `json:"user_id" required:"true"`
+	Username   string `json:"username" required:"true"`
+	IsActive   bool   `json:"is_active" required:"true"`
+	ChatHandle string `json:"chat_handle,omitempty"`
+	// MaxOpenReviews = 0 - действует лимит команды
+	MaxOpenReviews int    `json:"max_open_reviews,omitempty" validate:"gte=0"`
+	Seniority      string `json:"seniority,omitempty" validate:"omitempty,oneof=junior middle senior lead"`
 }
 
 type SaveRequest struct {
 	Team DTO `json:"team"`
 }
 
-type ErrorResponse struct {
-	Code    string `json:"code"`
-	Message string `json:"message"`
+type SetChatWebhookRequest struct {
+	TeamName   string `json:"team_name" validate:"required"`
+	WebhookURL string `json:"webhook_url" validate:"omitempty,http_url"`
+}
+
+// SetChatWebhookResponse не возвращает сам адрес: он дает право писать в чат
+type SetChatWebhookResponse struct {
+	TeamName              string `json:"team_name,omitempty"`
+	ChatWebhookConfigured bool   `json:"chat_webhook_configured"`
+}
+
+type SetDefaultMaxOpenReviewsRequest struct {
+	TeamName string `json:"team_name" validate:"required"`
+	// DefaultMaxOpenReviews = 0 снимает лимит
+	DefaultMaxOpenReviews int `json:"default_max_open_reviews" validate:"gte=0"`
+}
+
+type SetDefaultMaxOpenReviewsResponse struct {
+	TeamName              string `json:"team_name"`
+	DefaultMaxOpenReviews int    `json:"default_max_open_reviews"`
 }
 
 type SaveResponse struct {
-	Team  *DTO           `json:"team,omitempty"`
-	Error *ErrorResponse `json:"error,omitempty"`
-}
-
-func validationErrorResponse(errs validator.ValidationErrors) string {
-	var errMsgs []string
-
-	for _, err := range errs {
-		switch err.ActualTag() {
-		case "required":
-			errMsgs = append(errMsgs, fmt.Sprintf("field %s is a required field", err.Field()))
-		case "url":
-			errMsgs = append(errMsgs, fmt.Sprintf("field %s is not a valid URL", err.Field()))
-		default:
-			errMsgs = append(errMsgs, fmt.Sprintf("field %s is not valid", err.Field()))
-		}
-	}
-
-	return strings.Join(errMsgs, ", ")
-}
-
-func responseError(w http.ResponseWriter, r *http.Request, statusCode int, code, message string) {
-	w.WriteHeader(statusCode)
-	render.JSON(w, r, SaveResponse{
-		Error: &ErrorResponse{
-			Code:    code,
-			Message: message,
-		},
-	})
-}
-
-func getStatusCodeForError(errorCode string) int {
-	switch errorCode {
-	case "TEAM_EXISTS", "USER_EXISTS":
-		return http.StatusBadRequest
-	case "NOT_FOUND":
-		return http.StatusNotFound
-	case "VALIDATION_ERROR", "INVALID_REQUEST":
-		return http.StatusBadRequest
-	default:
-		return http.StatusInternalServerError
-	}
+	Team *DTO `json:"team,omitempty"`
+}
+
+type SetReviewSLARequest struct {
+	TeamName string `json:"team_name" validate:"required"`
+	// ReviewHours = 0 отключает SLA
+	ReviewHours     *int  `json:"review_hours" validate:"required,gte=0,lte=8760"`
+	WorkingDaysOnly *bool `json:"working_days_only,omitempty"`
+	AutoReassign    bool  `json:"auto_reassign"`
+}
+
+type ReviewSLADTO struct {
+	TeamName        string     `json:"team_name"`
+	Enabled         bool       `json:"enabled"`
+	ReviewHours     int        `json:"review_hours,omitempty"`
+	WorkingDaysOnly bool       `json:"working_days_only"`
+	AutoReassign    bool       `json:"auto_reassign"`
+	UpdatedAt       *time.Time `json:"updated_at,omitempty"`
+}
+
+// CodeOwnerRuleDTO.Owners может быть пустым: такое правило снимает владельцев
+// с подходящих файлов, как строка без владельцев в CODEOWNERS
+type CodeOwnerRuleDTO struct {
+	Pattern string   `json:"pattern" validate:"required"`
+	Owners  []string `json:"owners" validate:"required,dive,required"`
+}
+
+// SetCodeOwnersRequest.Rules заменяет все правила команды, пустой список их удаляет
+type SetCodeOwnersRequest struct {
+	TeamName string              `json:"team_name" validate:"required"`
+	Rules    []*CodeOwnerRuleDTO `json:"rules" validate:"required,dive"`
+}
+
+type CodeOwnersDTO struct {
+	TeamName string              `json:"team_name"`
+	Rules    []*CodeOwnerRuleDTO `json:"rules"`
+}
+
+// ImportCodeOwnersRequest.CodeOwners - содержимое файла CODEOWNERS формата GitHub
+type ImportCodeOwnersRequest struct {
+	TeamName   string `json:"team_name" validate:"required"`
+	CodeOwners string `json:"codeowners" validate:"max=3145728"`
+}
+
+type UnknownHandleDTO struct {
+	Line   int    `json:"line"`
+	Handle string `json:"handle"`
+}
+
+type ImportCodeOwnersResponse struct {
+	TeamName       string              `json:"team_name"`
+	Rules          []*CodeOwnerRuleDTO `json:"rules"`
+	UnknownHandles []UnknownHandleDTO  `json:"unknown_handles"`
+	InvalidLines   []int               `json:"invalid_lines"`
+}
+
+// CompositionRuleDTO - не меньше MinCount ревьюверов уровня MinSeniority или выше
+type CompositionRuleDTO struct {
+	MinSeniority string `json:"min_seniority" validate:"required,oneof=junior middle senior lead"`
+	MinCount     int    `json:"min_count" validate:"gte=1,lte=10"`
+}
+
+// ExcludedPairDTO - участники, которые не ревьюят PR друг друга
+type ExcludedPairDTO struct {
+	UserId      string `json:"user_id" validate:"required"`
+	OtherUserId string `json:"other_user_id" validate:"required,nefield=UserId"`
+}
+
+// RepeatLimitDTO - не больше MaxReviews назначений ревьювера автору за WindowDays дней
+type RepeatLimitDTO struct {
+	MaxReviews int `json:"max_reviews" validate:"gte=1,lte=100"`
+	WindowDays int `json:"window_days" validate:"gte=1,lte=365"`
+}
+
+// SetReviewPolicyRequest заменяет правила команды целиком: отсутствующее или пустое
+// поле снимает соответствующие правила
+type SetReviewPolicyRequest struct {
+	TeamName      string                `json:"team_name" validate:"required"`
+	Composition   []*CompositionRuleDTO `json:"composition" validate:"max=4,unique=MinSeniority,dive"`
+	ExcludedPairs []*ExcludedPairDTO    `json:"excluded_pairs" validate:"max=100,dive"`
+	RepeatLimit   *RepeatLimitDTO       `json:"repeat_limit"`
+}
+
+type ReviewPolicyDTO struct {
+	TeamName      string                `json:"team_name"`
+	Composition   []*CompositionRuleDTO `json:"composition"`
+	ExcludedPairs []*ExcludedPairDTO    `json:"excluded_pairs"`
+	RepeatLimit   *RepeatLimitDTO       `json:"repeat_limit,omitempty"`
 }
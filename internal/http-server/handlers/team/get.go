@@ -4,7 +4,7 @@ import (
 	"log/slog"
 	"net/http"
 	"reviewer-service/internal/domain/team"
-	"reviewer-service/internal/storage"
+	"reviewer-service/internal/http-server/api"
 
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/render"
@@ -20,7 +20,7 @@ func Get(log *slog.Logger, teamRepo team.Repository) http.HandlerFunc {
 
 		teamName := r.URL.Query().Get("team_name")
 		if teamName == "" {
-			responseError(w, r, http.StatusBadRequest, "INVALID_REQUEST", "team_name parameter is required")
+			api.ResponseError(w, r, "INVALID_REQUEST", "team_name parameter is required")
 			return
 		}
 
@@ -28,12 +28,7 @@ func Get(log *slog.Logger, teamRepo team.Repository) http.HandlerFunc {
 		if err != nil {
 			log.Error("failed to get team", slog.String("team_name", teamName))
 
-			if storageErr, ok := storage.IsError(err); ok {
-				statusCode := getStatusCodeForError(storageErr.Code)
-				responseError(w, r, statusCode, storageErr.Code, storageErr.Message)
-			} else {
-				responseError(w, r, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
-			}
+			api.ResponseStorageError(w, r, err)
 			return
 		}
 
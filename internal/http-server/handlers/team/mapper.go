@@ -1,21 +1,27 @@
 package team
 
 import (
+	"reviewer-service/internal/domain/codeowners"
+	"reviewer-service/internal/domain/policy"
+	"reviewer-service/internal/domain/sla"
 	"reviewer-service/internal/domain/team"
 	"reviewer-service/internal/domain/user"
+	"time"
 )
 
 func toDomain(dto *DTO) *team.Model {
 	return &team.Model{
-		Name:    dto.Name,
-		Members: toUserDomains(dto.Members, dto.Name),
+		Name:                  dto.Name,
+		Members:               toUserDomains(dto.Members, dto.Name),
+		DefaultMaxOpenReviews: dto.DefaultMaxOpenReviews,
 	}
 }
 
 func toDto(teamModel *team.Model) *DTO {
 	return &DTO{
-		Name:    teamModel.Name,
-		Members: toMemberDTOs(teamModel.Members),
+		Name:                  teamModel.Name,
+		Members:               toMemberDTOs(teamModel.Members),
+		DefaultMaxOpenReviews: teamModel.DefaultMaxOpenReviews,
 	}
 }
 
@@ -23,10 +29,13 @@ func toUserDomains(dtos []*Member, teamName string) []*user.Model {
 	users := make([]*user.Model, len(dtos))
 	for i, dto := range dtos {
 		users[i] = &user.Model{
-			UserId:   dto.UserId,
-			Username: dto.Username,
-			TeamName: teamName,
-			IsActive: dto.IsActive,
+			UserId:         dto.UserId,
+			Username:       dto.Username,
+			TeamName:       teamName,
+			IsActive:       dto.IsActive,
+			ChatHandle:     dto.ChatHandle,
+			MaxOpenReviews: dto.MaxOpenReviews,
+			Seniority:      dto.Seniority,
 		}
 	}
 	return users
@@ -36,10 +45,118 @@ func toMemberDTOs(users []*user.Model) []*Member {
 	members := make([]*Member, len(users))
 	for i, userModel := range users {
 		members[i] = &Member{
-			UserId:   userModel.UserId,
-			Username: userModel.Username,
-			IsActive: userModel.IsActive,
+			UserId:         userModel.UserId,
+			Username:       userModel.Username,
+			IsActive:       userModel.IsActive,
+			ChatHandle:     userModel.ChatHandle,
+			MaxOpenReviews: userModel.MaxOpenReviews,
+			Seniority:      userModel.Seniority,
 		}
 	}
 	return members
 }
+
+func toPolicy(req *SetReviewSLARequest) *sla.Policy {
+	workingDaysOnly := true
+	if req.WorkingDaysOnly != nil {
+		workingDaysOnly = *req.WorkingDaysOnly
+	}
+
+	return &sla.Policy{
+		TeamName:        req.TeamName,
+		ReviewWithin:    time.Duration(*req.ReviewHours) * time.Hour,
+		WorkingDaysOnly: workingDaysOnly,
+		AutoReassign:    req.AutoReassign,
+	}
+}
+
+func toReviewSLADto(teamName string, p *sla.Policy) *ReviewSLADTO {
+	if p == nil {
+		return &ReviewSLADTO{TeamName: teamName}
+	}
+
+	return &ReviewSLADTO{
+		TeamName:        p.TeamName,
+		Enabled:         true,
+		ReviewHours:     int(p.ReviewWithin / time.Hour),
+		WorkingDaysOnly: p.WorkingDaysOnly,
+		AutoReassign:    p.AutoReassign,
+		UpdatedAt:       &p.UpdatedAt,
+	}
+}
+
+func toCodeOwnerRules(dtos []*CodeOwnerRuleDTO) []*codeowners.Rule {
+	rules := make([]*codeowners.Rule, len(dtos))
+	for i, dto := range dtos {
+		rules[i] = &codeowners.Rule{
+			Pattern: dto.Pattern,
+			Owners:  dto.Owners,
+		}
+	}
+	return rules
+}
+
+func toCodeOwnersDto(teamName string, rules []*codeowners.Rule) *CodeOwnersDTO {
+	dtos := make([]*CodeOwnerRuleDTO, len(rules))
+	for i, rule := range rules {
+		dtos[i] = &CodeOwnerRuleDTO{
+			Pattern: rule.Pattern,
+			Owners:  rule.Owners,
+		}
+	}
+	return &CodeOwnersDTO{TeamName: teamName, Rules: dtos}
+}
+
+func toImportCodeOwnersDto(teamName string, result *codeowners.ImportResult) *ImportCodeOwnersResponse {
+	unknownHandles := make([]UnknownHandleDTO, len(result.UnknownHandles))
+	for i, handle := range result.UnknownHandles {
+		unknownHandles[i] = UnknownHandleDTO{Line: handle.Line, Handle: handle.Handle}
+	}
+
+	return &ImportCodeOwnersResponse{
+		TeamName:       teamName,
+		Rules:          toCodeOwnersDto(teamName, result.Rules).Rules,
+		UnknownHandles: unknownHandles,
+		InvalidLines:   result.InvalidLines,
+	}
+}
+
+func toReviewPolicy(req *SetReviewPolicyRequest) *policy.Policy {
+	composition := make(policy.Composition, len(req.Composition))
+	for i, dto := range req.Composition {
+		composition[i] = policy.Constraint{
+			MinSeniority: dto.MinSeniority,
+			MinCount:     dto.MinCount,
+		}
+	}
+	pairs := make([]policy.Pair, len(req.ExcludedPairs))
+	for i, dto := range req.ExcludedPairs {
+		pairs[i] = policy.Pair{UserId: dto.UserId, OtherUserId: dto.OtherUserId}
+	}
+
+	p := &policy.Policy{TeamName: req.TeamName, Composition: composition, ExcludedPairs: pairs}
+	if req.RepeatLimit != nil {
+		p.RepeatLimit = policy.RepeatLimit{MaxReviews: req.RepeatLimit.MaxReviews, WindowDays: req.RepeatLimit.WindowDays}
+	}
+	return p
+}
+
+func toReviewPolicyDto(p *policy.Policy) *ReviewPolicyDTO {
+	composition := make([]*CompositionRuleDTO, len(p.Composition))
+	for i, constraint := range p.Composition {
+		composition[i] = &CompositionRuleDTO{
+			MinSeniority: constraint.MinSeniority,
+			MinCount:     constraint.MinCount,
+		}
+	}
+	pairs := make([]*ExcludedPairDTO, len(p.ExcludedPairs))
+	for i, pair := range p.ExcludedPairs {
+		pairs[i] = &ExcludedPairDTO{UserId: pair.UserId, OtherUserId: pair.OtherUserId}
+	}
+
+	dto := &ReviewPolicyDTO{TeamName: p.TeamName, Composition: composition, ExcludedPairs: pairs}
+	if p.RepeatLimit.MaxReviews > 0 {
+		dto.RepeatLimit = &RepeatLimitDTO{MaxReviews: p.RepeatLimit.MaxReviews, WindowDays: p.RepeatLimit.WindowDays}
+	}
+	return dto
+}
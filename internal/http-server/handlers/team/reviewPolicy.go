@@ -0,0 +1,79 @@
+package team
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"reviewer-service/internal/domain/policy"
+	"reviewer-service/internal/http-server/api"
+	logUtil "reviewer-service/internal/lib/logger/slog"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+)
+
+func SetReviewPolicy(log *slog.Logger, txManager policy.TransactionManager, repo policy.Repository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.team.SetReviewPolicy"
+		log = log.With(
+			slog.String("operation", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		var req SetReviewPolicyRequest
+		err := render.DecodeJSON(r.Body, &req)
+		if errors.Is(err, io.EOF) {
+			log.Error("request body is empty")
+			api.ResponseError(w, r, "INVALID_REQUEST", "request body is empty")
+			return
+		}
+		if err != nil {
+			log.Error("failed to decode request body", logUtil.Err(err))
+			api.ResponseError(w, r, "INVALID_REQUEST", "failed to decode request")
+			return
+		}
+
+		if err := api.Validate(req); err != nil {
+			log.Error("invalid request", logUtil.Err(err))
+			api.ResponseInvalidRequest(w, r, err)
+			return
+		}
+
+		saved, err := policy.SetPolicy(r.Context(), log, txManager, repo, toReviewPolicy(&req))
+		if err != nil {
+			log.Error("failed to set team review policy", slog.String("team_name", req.TeamName), logUtil.Err(err))
+
+			api.ResponseStorageError(w, r, err)
+			return
+		}
+
+		render.JSON(w, r, toReviewPolicyDto(saved))
+	}
+}
+
+func GetReviewPolicy(log *slog.Logger, repo policy.Repository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.team.GetReviewPolicy"
+		log = log.With(
+			slog.String("operation", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		teamName := r.URL.Query().Get("team_name")
+		if teamName == "" {
+			api.ResponseError(w, r, "INVALID_REQUEST", "team_name parameter is required")
+			return
+		}
+
+		p, err := policy.GetPolicy(r.Context(), log, repo, teamName)
+		if err != nil {
+			log.Error("failed to get team review policy", slog.String("team_name", teamName), logUtil.Err(err))
+
+			api.ResponseStorageError(w, r, err)
+			return
+		}
+
+		render.JSON(w, r, toReviewPolicyDto(p))
+	}
+}
@@ -6,12 +6,11 @@ import (
 	"log/slog"
 	"net/http"
 	"reviewer-service/internal/domain/team"
+	"reviewer-service/internal/http-server/api"
 	logUtil "reviewer-service/internal/lib/logger/slog"
-	"reviewer-service/internal/storage"
 
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/render"
-	"github.com/go-playground/validator/v10"
 )
 
 func Save(log *slog.Logger, txManager team.TransactionManager, repo team.Repository) http.HandlerFunc {
@@ -26,21 +25,18 @@ func Save(log *slog.Logger, txManager team.TransactionManager, repo team.Reposit
 		err := render.DecodeJSON(r.Body, &req)
 		if errors.Is(err, io.EOF) {
 			log.Error("request body is empty")
-			responseError(w, r, http.StatusBadRequest, "INVALID_REQUEST", "request body is empty")
+			api.ResponseError(w, r, "INVALID_REQUEST", "request body is empty")
 			return
 		}
 		if err != nil {
 			log.Error("failed to decode request body", logUtil.Err(err))
-			responseError(w, r, http.StatusBadRequest, "INVALID_REQUEST", "failed to decode request")
+			api.ResponseError(w, r, "INVALID_REQUEST", "failed to decode request")
 			return
 		}
 
-		if err := validator.New().Struct(req); err != nil {
-			var validateErr validator.ValidationErrors
-			errors.As(err, &validateErr)
-
+		if err := api.Validate(req); err != nil {
 			log.Error("invalid request", logUtil.Err(err))
-			responseError(w, r, http.StatusBadRequest, "VALIDATION_ERROR", validationErrorResponse(validateErr))
+			api.ResponseInvalidRequest(w, r, err)
 			return
 		}
 
@@ -48,12 +44,7 @@ func Save(log *slog.Logger, txManager team.TransactionManager, repo team.Reposit
 		if err != nil {
 			log.Error("failed to save team", logUtil.Err(err))
 
-			if storageErr, ok := storage.IsError(err); ok {
-				statusCode := getStatusCodeForError(storageErr.Code)
-				responseError(w, r, statusCode, storageErr.Code, storageErr.Message)
-			} else {
-				responseError(w, r, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
-			}
+			api.ResponseStorageError(w, r, err)
 			return
 		}
 
@@ -62,7 +53,7 @@ func Save(log *slog.Logger, txManager team.TransactionManager, repo team.Reposit
 }
 
 func responseOK(w http.ResponseWriter, r *http.Request, statusCode int, team *DTO) {
-	w.WriteHeader(statusCode)
+	render.Status(r, statusCode)
 	render.JSON(w, r, SaveResponse{
 		Team: team,
 	})
@@ -1,37 +1,45 @@
 package user
 
-import (
-	"net/http"
-
-	"github.com/go-chi/render"
-)
-
 type SetIsActiveRequest struct {
 	UserId   string `json:"user_id" validate:"required"`
 	IsActive bool   `json:"is_active"`
 }
 
-type UserResponse struct {
-	UserId   string `json:"user_id"`
-	Username string `json:"username"`
-	TeamName string `json:"team_name"`
-	IsActive bool   `json:"is_active"`
+type SetChatHandleRequest struct {
+	UserId     string `json:"user_id" validate:"required"`
+	ChatHandle string `json:"chat_handle"`
 }
 
-type SetIsActiveResponse struct {
-	User  *UserResponse  `json:"user,omitempty"`
-	Error *ErrorResponse `json:"error,omitempty"`
+type SetMaxOpenReviewsRequest struct {
+	UserId string `json:"user_id" validate:"required"`
+	// MaxOpenReviews = 0 возвращает лимит команды
+	MaxOpenReviews int `json:"max_open_reviews" validate:"gte=0"`
+}
+
+type SetSeniorityRequest struct {
+	UserId string `json:"user_id" validate:"required"`
+	// Seniority = "" снимает уровень
+	Seniority string `json:"seniority" validate:"omitempty,oneof=junior middle senior lead"`
 }
 
-type ErrorResponse struct {
-	Code    string `json:"code"`
-	Message string `json:"message"`
+type UserResponse struct {
+	UserId     string `json:"user_id"`
+	Username   string `json:"username"`
+	TeamName   string `json:"team_name"`
+	IsActive   bool   `json:"is_active"`
+	ChatHandle string `json:"chat_handle,omitempty"`
+	// MaxOpenReviews = 0 - действует лимит команды
+	MaxOpenReviews int    `json:"max_open_reviews,omitempty"`
+	Seniority      string `json:"seniority,omitempty"`
+}
+
+type SetIsActiveResponse struct {
+	User *UserResponse `json:"user,omitempty"`
 }
 
 type GetReviewResponse struct {
 	UserId       string                      `json:"user_id"`
 	PullRequests []*PullRequestShortResponse `json:"pull_requests"`
-	Error        *ErrorResponse              `json:"error,omitempty"`
 }
 
 type PullRequestShortResponse struct {
@@ -39,27 +47,6 @@ type PullRequestShortResponse struct {
 	PullRequestName string `json:"pull_request_name"`
 	AuthorId        string `json:"author_id"`
 	Status          string `json:"status"`
-}
-
-func responseError(w http.ResponseWriter, r *http.Request, statusCode int, code, message string) {
-	w.WriteHeader(statusCode)
-	render.JSON(w, r, SetIsActiveResponse{
-		Error: &ErrorResponse{
-			Code:    code,
-			Message: message,
-		},
-	})
-}
-
-func getStatusCodeForError(errorCode string) int {
-	switch errorCode {
-	case "USER_EXISTS":
-		return http.StatusBadRequest
-	case "NOT_FOUND":
-		return http.StatusNotFound
-	case "VALIDATION_ERROR", "INVALID_REQUEST":
-		return http.StatusBadRequest
-	default:
-		return http.StatusInternalServerError
-	}
+	RepositoryId    int64  `json:"repository_id,omitempty"`
+	Number          int64  `json:"number,omitempty"`
 }
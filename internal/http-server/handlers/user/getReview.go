@@ -3,8 +3,11 @@ package user
 import (
 	"log/slog"
 	"net/http"
+	"reviewer-service/internal/domain/auth"
 	"reviewer-service/internal/domain/pullrequest"
+	"reviewer-service/internal/http-server/api"
 	"reviewer-service/internal/storage"
+	"strconv"
 
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/render"
@@ -18,22 +21,31 @@ func GetReview(log *slog.Logger, repo pullrequest.Repository) http.HandlerFunc {
 			slog.String("request_id", middleware.GetReqID(r.Context())),
 		)
 
-		userId := r.URL.Query().Get("user_id")
+		userId, err := auth.ResolveUserId(r.Context(), r.URL.Query().Get("user_id"))
+		if err != nil {
+			log.Error("user_id does not match token", slog.String("error", err.Error()))
+			api.ResponseError(w, r, storage.ErrForbidden.Code, "user_id must match the authenticated user")
+			return
+		}
 		if userId == "" {
-			responseErrorGetReview(w, r, http.StatusBadRequest, "INVALID_REQUEST", "user_id parameter is required")
+			api.ResponseError(w, r, "INVALID_REQUEST", "user_id parameter is required")
 			return
 		}
 
-		prs, err := pullrequest.GetPullRequests(r.Context(), log, repo, userId)
+		var repositoryId int64
+		if raw := r.URL.Query().Get("repository_id"); raw != "" {
+			repositoryId, err = strconv.ParseInt(raw, 10, 64)
+			if err != nil || repositoryId <= 0 {
+				api.ResponseError(w, r, "VALIDATION_ERROR", "repository_id must be a positive integer")
+				return
+			}
+		}
+
+		prs, err := pullrequest.GetPullRequests(r.Context(), log, repo, userId, repositoryId)
 		if err != nil {
 			log.Error("failed to get pull requests", slog.String("user_id", userId), slog.String("error", err.Error()))
 
-			if storageErr, ok := storage.IsError(err); ok {
-				statusCode := getStatusCodeForError(storageErr.Code)
-				responseErrorGetReview(w, r, statusCode, storageErr.Code, storageErr.Message)
-			} else {
-				responseErrorGetReview(w, r, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
-			}
+			api.ResponseStorageError(w, r, err)
 			return
 		}
 
@@ -43,13 +55,3 @@ func GetReview(log *slog.Logger, repo pullrequest.Repository) http.HandlerFunc {
 		})
 	}
 }
-
-func responseErrorGetReview(w http.ResponseWriter, r *http.Request, statusCode int, code, message string) {
-	w.WriteHeader(statusCode)
-	render.JSON(w, r, GetReviewResponse{
-		Error: &ErrorResponse{
-			Code:    code,
-			Message: message,
-		},
-	})
-}
@@ -7,10 +7,13 @@ import (
 
 func toDto(userModel *user.Model) *UserResponse {
 	return &UserResponse{
-		UserId:   userModel.UserId,
-		Username: userModel.Username,
-		TeamName: userModel.TeamName,
-		IsActive: userModel.IsActive,
+		UserId:         userModel.UserId,
+		Username:       userModel.Username,
+		TeamName:       userModel.TeamName,
+		IsActive:       userModel.IsActive,
+		ChatHandle:     userModel.ChatHandle,
+		MaxOpenReviews: userModel.MaxOpenReviews,
+		Seniority:      userModel.Seniority,
 	}
 }
 
@@ -22,6 +25,8 @@ func toPullRequestShortDtos(prShorts []*pullrequest.Model) []*PullRequestShortRe
 			PullRequestName: pr.PullRequestName,
 			AuthorId:        pr.AuthorId,
 			Status:          pr.Status,
+			RepositoryId:    pr.RepositoryId,
+			Number:          pr.Number,
 		})
 	}
 	return result
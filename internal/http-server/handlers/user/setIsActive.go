@@ -6,11 +6,10 @@ import (
 	"log/slog"
 	"net/http"
 	"reviewer-service/internal/domain/user"
-	"reviewer-service/internal/storage"
+	"reviewer-service/internal/http-server/api"
 
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/render"
-	"github.com/go-playground/validator/v10"
 )
 
 func SetIsActive(log *slog.Logger, txManager user.TransactionManager, repo user.Repository) http.HandlerFunc {
@@ -25,21 +24,18 @@ func SetIsActive(log *slog.Logger, txManager user.TransactionManager, repo user.
 		err := render.DecodeJSON(r.Body, &req)
 		if errors.Is(err, io.EOF) {
 			log.Error("request body is empty")
-			responseError(w, r, http.StatusBadRequest, "INVALID_REQUEST", "request body is empty")
+			api.ResponseError(w, r, "INVALID_REQUEST", "request body is empty")
 			return
 		}
 		if err != nil {
 			log.Error("failed to decode request body", slog.String("error", err.Error()))
-			responseError(w, r, http.StatusBadRequest, "INVALID_REQUEST", "failed to decode request")
+			api.ResponseError(w, r, "INVALID_REQUEST", "failed to decode request")
 			return
 		}
 
-		if err := validator.New().Struct(req); err != nil {
-			var validateErr validator.ValidationErrors
-			errors.As(err, &validateErr)
-
+		if err := api.Validate(req); err != nil {
 			log.Error("invalid request", slog.String("error", err.Error()))
-			responseError(w, r, http.StatusBadRequest, "VALIDATION_ERROR", "invalid request")
+			api.ResponseInvalidRequest(w, r, err)
 			return
 		}
 
@@ -47,12 +43,7 @@ func SetIsActive(log *slog.Logger, txManager user.TransactionManager, repo user.
 		if err != nil {
 			log.Error("failed to update user", slog.String("user_id", req.UserId))
 
-			if storageErr, ok := storage.IsError(err); ok {
-				statusCode := getStatusCodeForError(storageErr.Code)
-				responseError(w, r, statusCode, storageErr.Code, storageErr.Message)
-			} else {
-				responseError(w, r, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
-			}
+			api.ResponseStorageError(w, r, err)
 			return
 		}
 
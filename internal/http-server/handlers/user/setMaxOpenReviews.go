@@ -0,0 +1,54 @@
+package user
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"reviewer-service/internal/domain/user"
+	"reviewer-service/internal/http-server/api"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+)
+
+func SetMaxOpenReviews(log *slog.Logger, repo user.Repository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.user.SetMaxOpenReviews"
+		log = log.With(
+			slog.String("operation", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		var req SetMaxOpenReviewsRequest
+		err := render.DecodeJSON(r.Body, &req)
+		if errors.Is(err, io.EOF) {
+			log.Error("request body is empty")
+			api.ResponseError(w, r, "INVALID_REQUEST", "request body is empty")
+			return
+		}
+		if err != nil {
+			log.Error("failed to decode request body", slog.String("error", err.Error()))
+			api.ResponseError(w, r, "INVALID_REQUEST", "failed to decode request")
+			return
+		}
+
+		if err := api.Validate(req); err != nil {
+			log.Error("invalid request", slog.String("error", err.Error()))
+			api.ResponseInvalidRequest(w, r, err)
+			return
+		}
+
+		updatedUser, err := user.SetUserMaxOpenReviews(r.Context(), log, repo, req.UserId, req.MaxOpenReviews)
+		if err != nil {
+			log.Error("failed to update user max open reviews", slog.String("user_id", req.UserId))
+
+			api.ResponseStorageError(w, r, err)
+			return
+		}
+
+		render.JSON(w, r, SetIsActiveResponse{
+			User: toDto(updatedUser),
+		})
+	}
+}
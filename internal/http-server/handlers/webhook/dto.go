@@ -0,0 +1,72 @@
+package webhook
+
+// maxPayloadSize ограничивает размер тела вебхука
+const maxPayloadSize = 5 << 20
+
+type GitHubPullRequestEvent struct {
+	Action      string             `json:"action"`
+	Number      int64              `json:"number"`
+	PullRequest *GitHubPullRequest `json:"pull_request"`
+	Repository  *GitHubRepository  `json:"repository"`
+}
+
+type GitHubPullRequest struct {
+	Number int64       `json:"number"`
+	Title  string      `json:"title"`
+	Draft  bool        `json:"draft"`
+	Merged bool        `json:"merged"`
+	User   *GitHubUser `json:"user"`
+}
+
+type GitHubRepository struct {
+	FullName string `json:"full_name"`
+	HtmlUrl  string `json:"html_url"`
+}
+
+type GitHubUser struct {
+	Login string `json:"login"`
+}
+
+type GitLabMergeRequestEvent struct {
+	ObjectKind       string              `json:"object_kind"`
+	User             *GitLabUser         `json:"user"`
+	Project          *GitLabProject      `json:"project"`
+	ObjectAttributes *GitLabMergeRequest `json:"object_attributes"`
+	Changes          *GitLabChanges      `json:"changes"`
+}
+
+type GitLabUser struct {
+	ID       int64  `json:"id"`
+	Username string `json:"username"`
+}
+
+type GitLabProject struct {
+	PathWithNamespace string `json:"path_with_namespace"`
+	WebUrl            string `json:"web_url"`
+}
+
+type GitLabMergeRequest struct {
+	IID            int64  `json:"iid"`
+	Title          string `json:"title"`
+	AuthorId       int64  `json:"author_id"`
+	State          string `json:"state"`
+	Action         string `json:"action"`
+	Draft          bool   `json:"draft"`
+	WorkInProgress bool   `json:"work_in_progress"`
+}
+
+type GitLabChanges struct {
+	Draft *GitLabBoolChange `json:"draft"`
+}
+
+type GitLabBoolChange struct {
+	Previous bool `json:"previous"`
+	Current  bool `json:"current"`
+}
+
+type WebhookResponse struct {
+	Status        string `json:"status,omitempty"`
+	Action        string `json:"action,omitempty"`
+	PullRequestId string `json:"pull_request_id,omitempty"`
+	Reason        string `json:"reason,omitempty"`
+}
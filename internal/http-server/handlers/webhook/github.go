@@ -0,0 +1,106 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"reviewer-service/internal/domain/pullrequest"
+	"reviewer-service/internal/domain/webhook"
+	"reviewer-service/internal/http-server/api"
+	logUtil "reviewer-service/internal/lib/logger/slog"
+	"reviewer-service/internal/lib/random"
+	"reviewer-service/internal/storage"
+	"strings"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+)
+
+const (
+	GitHubSignatureHeader = "X-Hub-Signature-256"
+	GitHubEventHeader     = "X-GitHub-Event"
+	GitHubDeliveryHeader  = "X-GitHub-Delivery"
+)
+
+// GitHub принимает вебхуки GitHub. Подпись X-Hub-Signature-256 проверяется
+// секретом secret, обрабатываются только события pull_request
+func GitHub(log *slog.Logger, txManager webhook.TransactionManager, repo webhook.Repository, syncer pullrequest.ReviewerSyncer, rnd pullrequest.Random, secret string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.webhook.GitHub"
+		log = log.With(
+			slog.String("operation", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxPayloadSize))
+		if err != nil {
+			log.Error("failed to read request body", logUtil.Err(err))
+			api.ResponseError(w, r, "INVALID_REQUEST", "failed to read request")
+			return
+		}
+
+		if !validGitHubSignature(secret, body, r.Header.Get(GitHubSignatureHeader)) {
+			log.Warn("invalid webhook signature", slog.String("remote_addr", r.RemoteAddr))
+			api.ResponseStorageError(w, r, storage.ErrInvalidSignature)
+			return
+		}
+
+		eventName := r.Header.Get(GitHubEventHeader)
+		if eventName != "pull_request" {
+			render.JSON(w, r, WebhookResponse{
+				Status: webhook.StatusIgnored,
+				Reason: "event " + eventName + " is not handled",
+			})
+			return
+		}
+
+		var payload GitHubPullRequestEvent
+		if err := json.Unmarshal(body, &payload); err != nil {
+			log.Error("failed to decode request body", logUtil.Err(err))
+			api.ResponseError(w, r, "INVALID_REQUEST", "failed to decode request")
+			return
+		}
+
+		if payload.PullRequest == nil || payload.Repository == nil {
+			log.Error("invalid request", slog.String("action", payload.Action))
+			api.ResponseError(w, r, "VALIDATION_ERROR", "pull_request and repository are required")
+			return
+		}
+
+		result, err := webhook.Process(r.Context(), log, txManager, repo, syncer, random.FromContext(r.Context(), rnd), fromGitHub(r.Header.Get(GitHubDeliveryHeader), &payload))
+		if err != nil {
+			log.Error("failed to process webhook", logUtil.Err(err))
+
+			api.ResponseStorageError(w, r, err)
+			return
+		}
+
+		render.JSON(w, r, toDto(result))
+	}
+}
+
+// validGitHubSignature сравнивает заголовок вида sha256=<hex> с HMAC-SHA256 тела
+func validGitHubSignature(secret string, body []byte, header string) bool {
+	if secret == "" {
+		return false
+	}
+
+	signature, ok := strings.CutPrefix(header, "sha256=")
+	if !ok {
+		return false
+	}
+
+	expected, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+
+	return hmac.Equal(mac.Sum(nil), expected)
+}
@@ -0,0 +1,82 @@
+package webhook
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"reviewer-service/internal/domain/pullrequest"
+	"reviewer-service/internal/domain/webhook"
+	"reviewer-service/internal/http-server/api"
+	logUtil "reviewer-service/internal/lib/logger/slog"
+	"reviewer-service/internal/lib/random"
+	"reviewer-service/internal/storage"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+)
+
+const (
+	GitLabTokenHeader    = "X-Gitlab-Token"
+	GitLabEventHeader    = "X-Gitlab-Event"
+	GitLabDeliveryHeader = "X-Gitlab-Event-UUID"
+)
+
+// GitLab принимает вебхуки GitLab. X-Gitlab-Token должен совпадать с token,
+// обрабатываются только события Merge Request Hook
+func GitLab(log *slog.Logger, txManager webhook.TransactionManager, repo webhook.Repository, syncer pullrequest.ReviewerSyncer, rnd pullrequest.Random, token string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.webhook.GitLab"
+		log = log.With(
+			slog.String("operation", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		received := r.Header.Get(GitLabTokenHeader)
+		if token == "" || subtle.ConstantTimeCompare([]byte(received), []byte(token)) != 1 {
+			log.Warn("invalid webhook token", slog.String("remote_addr", r.RemoteAddr))
+			api.ResponseStorageError(w, r, storage.ErrInvalidSignature)
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxPayloadSize))
+		if err != nil {
+			log.Error("failed to read request body", logUtil.Err(err))
+			api.ResponseError(w, r, "INVALID_REQUEST", "failed to read request")
+			return
+		}
+
+		eventName := r.Header.Get(GitLabEventHeader)
+		if eventName != "Merge Request Hook" {
+			render.JSON(w, r, WebhookResponse{
+				Status: webhook.StatusIgnored,
+				Reason: "event " + eventName + " is not handled",
+			})
+			return
+		}
+
+		var payload GitLabMergeRequestEvent
+		if err := json.Unmarshal(body, &payload); err != nil {
+			log.Error("failed to decode request body", logUtil.Err(err))
+			api.ResponseError(w, r, "INVALID_REQUEST", "failed to decode request")
+			return
+		}
+
+		if payload.ObjectAttributes == nil || payload.Project == nil {
+			log.Error("invalid request", slog.String("object_kind", payload.ObjectKind))
+			api.ResponseError(w, r, "VALIDATION_ERROR", "object_attributes and project are required")
+			return
+		}
+
+		result, err := webhook.Process(r.Context(), log, txManager, repo, syncer, random.FromContext(r.Context(), rnd), fromGitLab(r.Header.Get(GitLabDeliveryHeader), &payload))
+		if err != nil {
+			log.Error("failed to process webhook", logUtil.Err(err))
+
+			api.ResponseStorageError(w, r, err)
+			return
+		}
+
+		render.JSON(w, r, toDto(result))
+	}
+}
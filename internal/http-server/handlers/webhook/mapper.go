@@ -0,0 +1,135 @@
+package webhook
+
+import (
+	"net/url"
+	"reviewer-service/internal/domain/webhook"
+	"strconv"
+)
+
+// Хосты по умолчанию, если в событии нет ссылки на репозиторий
+const (
+	defaultGitHubHost = "github.com"
+	defaultGitLabHost = "gitlab.com"
+)
+
+// fromGitHub приводит событие pull_request к webhook.Event.
+// Черновики не регистрируются до ready_for_review, converted_to_draft
+// приостанавливает ревью
+func fromGitHub(deliveryId string, payload *GitHubPullRequestEvent) *webhook.Event {
+	event := &webhook.Event{
+		Provider:      webhook.ProviderGitHub,
+		DeliveryId:    deliveryId,
+		Name:          "pull_request." + payload.Action,
+		PullRequestId: pullRequestId(payload.Repository.FullName, payload.PullRequest.Number),
+		Title:         payload.PullRequest.Title,
+
+		RepositoryHost: hostOf(payload.Repository.HtmlUrl, defaultGitHubHost),
+		RepositoryName: payload.Repository.FullName,
+		Number:         payload.PullRequest.Number,
+	}
+	if payload.PullRequest.User != nil {
+		event.AuthorLogin = payload.PullRequest.User.Login
+	}
+
+	switch payload.Action {
+	case "opened":
+		if !payload.PullRequest.Draft {
+			event.Action = webhook.ActionOpen
+		}
+	case "ready_for_review":
+		event.Action = webhook.ActionReady
+	case "converted_to_draft":
+		event.Action = webhook.ActionDraft
+	case "reopened":
+		event.Action = webhook.ActionReopen
+	case "closed":
+		if payload.PullRequest.Merged {
+			event.Action = webhook.ActionMerge
+		} else {
+			event.Action = webhook.ActionClose
+		}
+	}
+
+	return event
+}
+
+// fromGitLab приводит Merge Request Hook к webhook.Event. Снятие статуса
+// черновика с открытого MR регистрирует его или возвращает ревью, установка -
+// приостанавливает ревью
+func fromGitLab(deliveryId string, payload *GitLabMergeRequestEvent) *webhook.Event {
+	mr := payload.ObjectAttributes
+	event := &webhook.Event{
+		Provider:      webhook.ProviderGitLab,
+		DeliveryId:    deliveryId,
+		Name:          "merge_request." + mr.Action,
+		PullRequestId: mergeRequestId(payload.Project.PathWithNamespace, mr.IID),
+		Title:         mr.Title,
+
+		RepositoryHost: hostOf(payload.Project.WebUrl, defaultGitLabHost),
+		RepositoryName: payload.Project.PathWithNamespace,
+		Number:         mr.IID,
+	}
+	if mr.AuthorId != 0 {
+		event.AuthorExternalId = strconv.FormatInt(mr.AuthorId, 10)
+	}
+	// В событии есть только id автора, логин известен, если событие вызвал сам автор
+	if payload.User != nil && payload.User.ID == mr.AuthorId {
+		event.AuthorLogin = payload.User.Username
+	}
+
+	draft := mr.Draft || mr.WorkInProgress
+
+	switch mr.Action {
+	case "open":
+		if !draft {
+			event.Action = webhook.ActionOpen
+		}
+	case "reopen":
+		event.Action = webhook.ActionReopen
+	case "merge":
+		event.Action = webhook.ActionMerge
+	case "close":
+		event.Action = webhook.ActionClose
+	case "update":
+		if mr.State != "opened" || payload.Changes == nil || payload.Changes.Draft == nil {
+			break
+		}
+		switch change := payload.Changes.Draft; {
+		case change.Previous && !change.Current:
+			event.Action = webhook.ActionReady
+		case !change.Previous && change.Current:
+			event.Action = webhook.ActionDraft
+		}
+	}
+
+	return event
+}
+
+// pullRequestId строит идентификатор PR вида owner/repo#42
+func pullRequestId(repository string, number int64) string {
+	return repository + "#" + strconv.FormatInt(number, 10)
+}
+
+// mergeRequestId строит идентификатор MR вида group/project!42
+func mergeRequestId(project string, iid int64) string {
+	return project + "!" + strconv.FormatInt(iid, 10)
+}
+
+// hostOf возвращает хост из ссылки на репозиторий, для self-hosted инсталляций он
+// отличается от хоста по умолчанию
+func hostOf(rawUrl string, defaultHost string) string {
+	parsed, err := url.Parse(rawUrl)
+	if err != nil || parsed.Host == "" {
+		return defaultHost
+	}
+	return parsed.Host
+}
+
+func toDto(result *webhook.Result) WebhookResponse {
+	return WebhookResponse{
+		Status:        result.Status,
+		Action:        result.Action,
+		PullRequestId: result.PullRequestId,
+		Reason:        result.Reason,
+	}
+}
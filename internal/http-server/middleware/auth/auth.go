@@ -0,0 +1,177 @@
+package auth
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"reviewer-service/internal/domain/apikey"
+	domainAuth "reviewer-service/internal/domain/auth"
+	"reviewer-service/internal/domain/user"
+	"reviewer-service/internal/http-server/api"
+	"reviewer-service/internal/http-server/middleware/logger"
+	"reviewer-service/internal/lib/jwt"
+	logUtil "reviewer-service/internal/lib/logger/slog"
+	"reviewer-service/internal/storage"
+	"slices"
+	"strings"
+
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+const APIKeyHeader = "X-API-Key"
+
+type Options struct {
+	APIKeys apikey.Repository
+	// BootstrapKey - статический ключ с scope admin для выпуска первых ключей
+	BootstrapKey string
+
+	// Tokens проверяет Authorization: Bearer JWT; nil отключает этот способ
+	Tokens *jwt.Verifier
+	Users  user.Repository
+	// Roles дает scope admin пользователям с ролью admin
+	Roles domainAuth.RoleRepository
+	// UserClaim - claim токена, содержащий users.user_id
+	UserClaim string
+	// UserScopes выдаются всем пользователям с валидным токеном
+	UserScopes []string
+}
+
+// Authenticate кладет Principal в контекст запроса, если переданы учетные данные.
+// Запросы без учетных данных пропускаются дальше, их отклоняет RequireScope
+func Authenticate(log *slog.Logger, opts Options) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		log := log.With(
+			slog.String("component", "middleware/auth"),
+		)
+
+		log.Info("auth middleware enabled")
+
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			principal, err := ResolvePrincipal(r.Context(), opts, r.Header.Get(APIKeyHeader), r.Header.Get("Authorization"))
+			if principal == nil && err == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if err != nil {
+				log.Warn("authentication failed",
+					slog.String("request_id", middleware.GetReqID(r.Context())),
+					slog.String("remote_addr", r.RemoteAddr),
+					logUtil.Err(err),
+				)
+				api.ResponseStorageError(w, r, err)
+				return
+			}
+
+			logger.SetActor(r.Context(), principal.Actor())
+			next.ServeHTTP(w, r.WithContext(domainAuth.WithPrincipal(r.Context(), principal)))
+		}
+
+		return http.HandlerFunc(fn)
+	}
+}
+
+// RequireScope пропускает только аутентифицированные запросы с нужным scope
+func RequireScope(scope string) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			principal, ok := domainAuth.FromContext(r.Context())
+			if !ok {
+				api.ResponseStorageError(w, r, storage.ErrUnauthorized)
+				return
+			}
+
+			if !principal.HasScope(scope) {
+				api.ResponseError(w, r, storage.ErrForbidden.Code, "scope "+scope+" is required")
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		}
+
+		return http.HandlerFunc(fn)
+	}
+}
+
+// ResolvePrincipal проверяет API ключ или значение заголовка Authorization
+// (Bearer JWT). Без учетных данных возвращает nil без ошибки. Используется
+// также gRPC сервером, чтобы оба транспорта аутентифицировали одинаково
+func ResolvePrincipal(ctx context.Context, opts Options, rawKey string, authorization string) (*domainAuth.Principal, error) {
+	token, hasToken := bearerToken(authorization)
+	switch {
+	case rawKey != "":
+		return authenticateAPIKey(ctx, opts, rawKey)
+	case hasToken && opts.Tokens != nil:
+		return authenticateToken(ctx, opts, token)
+	default:
+		return nil, nil
+	}
+}
+
+func authenticateAPIKey(ctx context.Context, opts Options, rawKey string) (*domainAuth.Principal, error) {
+	if opts.BootstrapKey != "" && subtle.ConstantTimeCompare([]byte(rawKey), []byte(opts.BootstrapKey)) == 1 {
+		return &domainAuth.Principal{
+			Kind:   domainAuth.KindAPIKey,
+			Id:     "bootstrap",
+			Name:   "bootstrap",
+			Scopes: []string{domainAuth.ScopeAdmin},
+		}, nil
+	}
+
+	return apikey.Authenticate(ctx, opts.APIKeys, rawKey)
+}
+
+// authenticateToken проверяет JWT и сопоставляет его с пользователем из таблицы users.
+// Scope admin из claims токена не принимается: его получают только пользователи
+// с ролью admin в user_roles
+func authenticateToken(ctx context.Context, opts Options, token string) (*domainAuth.Principal, error) {
+	claims, err := opts.Tokens.Verify(ctx, token)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", storage.ErrUnauthorized, err)
+	}
+
+	userId := claims.String(opts.UserClaim)
+	if userId == "" {
+		return nil, fmt.Errorf("%w: claim %s is missing", storage.ErrUnauthorized, opts.UserClaim)
+	}
+
+	userModel, err := opts.Users.GetUserByUserId(ctx, userId)
+	if err != nil {
+		if storageErr, ok := storage.IsError(err); ok && storageErr == storage.ErrUserNotFound {
+			return nil, fmt.Errorf("%w: unknown user %s", storage.ErrUnauthorized, userId)
+		}
+		return nil, err
+	}
+
+	scopes := slices.Clone(opts.UserScopes)
+	for _, scope := range claims.Scopes() {
+		if domainAuth.IsKnownScope(scope) && scope != domainAuth.ScopeAdmin && !slices.Contains(scopes, scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+
+	roles, err := opts.Roles.GetUserRoles(ctx, userModel.UserId)
+	if err != nil {
+		return nil, err
+	}
+	if domainAuth.Roles(roles).IsAdmin() {
+		scopes = append(scopes, domainAuth.ScopeAdmin)
+	}
+
+	return &domainAuth.Principal{
+		Kind:   domainAuth.KindUser,
+		Id:     userModel.UserId,
+		Name:   userModel.UserId,
+		Scopes: scopes,
+	}, nil
+}
+
+func bearerToken(header string) (string, bool) {
+	scheme, token, ok := strings.Cut(header, " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") || token == "" {
+		return "", false
+	}
+	return strings.TrimSpace(token), true
+}
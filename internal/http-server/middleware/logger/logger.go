@@ -1,13 +1,26 @@
 package logger
 
 import (
+	"context"
 	"log/slog"
 	"net/http"
+	"reviewer-service/internal/domain/auth"
 	"time"
 
 	"github.com/go-chi/chi/v5/middleware"
 )
 
+type actorKey struct{}
+
+// SetActor сообщает логгеру субъект запроса. Логгер стоит до middleware/auth,
+// чтобы в лог попадали и отклоненные запросы, поэтому субъект, определенный
+// позже, передается через контекст
+func SetActor(ctx context.Context, actor string) {
+	if holder, ok := ctx.Value(actorKey{}).(*string); ok {
+		*holder = actor
+	}
+}
+
 func New(log *slog.Logger) func(next http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		log := log.With(
@@ -26,13 +39,26 @@ func New(log *slog.Logger) func(next http.Handler) http.Handler {
 			)
 			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
 
+			actor := auth.Actor(r.Context())
+			r = r.WithContext(context.WithValue(r.Context(), actorKey{}, &actor))
+
 			t1 := time.Now()
 			defer func() {
-				entry.Info("request completed",
+				attrs := []any{
+					slog.String("actor", actor),
 					slog.Int("status", ww.Status()),
 					slog.Int("bytes", ww.BytesWritten()),
 					slog.String("duration", time.Since(t1).String()),
-				)
+				}
+
+				switch ww.Status() {
+				case http.StatusTooManyRequests:
+					entry.Warn("request rate limited", attrs...)
+				case http.StatusUnauthorized:
+					entry.Warn("request unauthorized", attrs...)
+				default:
+					entry.Info("request completed", attrs...)
+				}
 			}()
 
 			next.ServeHTTP(ww, r)
@@ -0,0 +1,106 @@
+package ratelimit
+
+import (
+	"log/slog"
+	"math"
+	"net"
+	"net/http"
+	"reviewer-service/internal/domain/auth"
+	"reviewer-service/internal/http-server/api"
+	"reviewer-service/internal/lib/metrics"
+	"reviewer-service/internal/lib/ratelimit"
+	"strconv"
+	"time"
+)
+
+const defaultRoute = "default"
+
+// KeyFunc возвращает ключ клиента; false - запрос этим лимитом не ограничивается
+type KeyFunc func(r *http.Request) (string, bool)
+
+type Options struct {
+	// Default применяется к путям, для которых нет записи в Routes
+	Default ratelimit.Limit
+	// Routes - лимиты по пути запроса без префикса версии, например "/pullRequest/create"
+	Routes map[string]ratelimit.Limit
+	// Key определяет клиента; nil - ByAddress
+	Key KeyFunc
+}
+
+// ByAddress определяет клиента по адресу. Лимит по адресу ставится до
+// middleware/auth, чтобы ограничивать и запросы с неверными учетными данными
+func ByAddress(r *http.Request) (string, bool) {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	return "addr:" + host, true
+}
+
+// ByPrincipal определяет клиента по Principal (API ключ или пользователь) и
+// пропускает анонимные запросы. Ставится после middleware/auth
+func ByPrincipal(r *http.Request) (string, bool) {
+	principal, ok := auth.FromContext(r.Context())
+	if !ok {
+		return "", false
+	}
+	return principal.Kind + ":" + principal.Id, true
+}
+
+// New ограничивает частоту запросов каждого клиента token bucket'ом
+func New(log *slog.Logger, opts Options) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		log := log.With(
+			slog.String("component", "middleware/ratelimit"),
+		)
+
+		log.Info("rate limit middleware enabled",
+			slog.Float64("default_rps", opts.Default.Rate),
+			slog.Int("default_burst", opts.Default.Burst),
+			slog.Int("routes", len(opts.Routes)),
+		)
+
+		limiter := ratelimit.New()
+		key := opts.Key
+		if key == nil {
+			key = ByAddress
+		}
+
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			route := api.TrimBasePath(r.URL.Path)
+			limit, ok := opts.Routes[route]
+			if !ok {
+				route = defaultRoute
+				limit = opts.Default
+			}
+
+			client, ok := key(r)
+			if !ok || limit.Rate <= 0 || limit.Burst <= 0 {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			result := limiter.Allow(client+" "+route, limit)
+
+			w.Header().Set("RateLimit-Limit", strconv.Itoa(result.Limit))
+			w.Header().Set("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+			w.Header().Set("RateLimit-Reset", strconv.Itoa(ceilSeconds(result.Reset)))
+
+			if !result.Allowed {
+				metrics.RateLimitedRequests.WithLabelValues(route).Inc()
+
+				w.Header().Set("Retry-After", strconv.Itoa(max(ceilSeconds(result.RetryAfter), 1)))
+				api.ResponseError(w, r, api.CodeRateLimited, "too many requests")
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		}
+
+		return http.HandlerFunc(fn)
+	}
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
@@ -0,0 +1,43 @@
+package seed
+
+import (
+	"log/slog"
+	"net/http"
+	"reviewer-service/internal/http-server/api"
+	"reviewer-service/internal/lib/random"
+	"strconv"
+)
+
+// Header - зерно случайного выбора ревьюверов для одного запроса
+const Header = "X-Selection-Seed"
+
+// New подменяет источник случайности запроса генератором с зерном из заголовка
+// X-Selection-Seed, чтобы тесты получали воспроизводимый выбор. Подключается
+// только в окружении test; запросы без заголовка используют общий источник
+func New(log *slog.Logger) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		log := log.With(
+			slog.String("component", "middleware/seed"),
+		)
+
+		log.Info("per-request selection seed enabled")
+
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			value := r.Header.Get(Header)
+			if value == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			seed, err := strconv.ParseInt(value, 10, 64)
+			if err != nil || seed == 0 {
+				api.ResponseError(w, r, api.CodeInvalidRequest, Header+" must be a non-zero integer")
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(random.WithSource(r.Context(), random.New(seed))))
+		}
+
+		return http.HandlerFunc(fn)
+	}
+}
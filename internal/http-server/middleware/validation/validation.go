@@ -0,0 +1,187 @@
+package validation
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"reviewer-service/internal/http-server/api"
+	logUtil "reviewer-service/internal/lib/logger/slog"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/legacy"
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// Options проверки запросов: аутентификацию выполняет auth middleware,
+// значения по умолчанию из схемы в тело не подставляются
+var Options = &openapi3filter.Options{
+	AuthenticationFunc:  openapi3filter.NoopAuthenticationFunc,
+	SkipSettingDefaults: true,
+	MultiError:          true,
+}
+
+// versionRouter ищет операцию по пути без префикса версии: в спецификации
+// он задан в servers, а устаревшие пути его не содержат
+type versionRouter struct {
+	routers.Router
+}
+
+func (vr versionRouter) FindRoute(r *http.Request) (*routers.Route, map[string]string, error) {
+	url := *r.URL
+	url.Path = api.TrimBasePath(url.Path)
+
+	trimmed := *r
+	trimmed.URL = &url
+
+	return vr.Router.FindRoute(&trimmed)
+}
+
+// NewRouter сопоставляет запросы /api/v1 и устаревшие пути с операциями спецификации
+func NewRouter(doc *openapi3.T) (routers.Router, error) {
+	withoutServers := *doc
+	withoutServers.Servers = nil
+
+	router, err := legacy.NewRouter(&withoutServers)
+	if err != nil {
+		return nil, err
+	}
+
+	return versionRouter{Router: router}, nil
+}
+
+// New отклоняет запросы, не соответствующие спецификации, с 400 VALIDATION_ERROR.
+// Пути и методы, которых нет в спецификации, пропускаются дальше
+func New(log *slog.Logger, doc *openapi3.T) (func(next http.Handler) http.Handler, error) {
+	router, err := NewRouter(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(next http.Handler) http.Handler {
+		log := log.With(
+			slog.String("component", "middleware/validation"),
+		)
+
+		log.Info("openapi request validation enabled")
+
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			route, pathParams, err := router.FindRoute(r)
+			if err != nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			err = openapi3filter.ValidateRequest(r.Context(), &openapi3filter.RequestValidationInput{
+				Request:    r,
+				PathParams: pathParams,
+				Route:      route,
+				Options:    Options,
+			})
+			if err != nil {
+				log.Warn("request does not match openapi spec",
+					slog.String("request_id", middleware.GetReqID(r.Context())),
+					slog.String("path", r.URL.Path),
+					logUtil.Err(err),
+				)
+
+				api.ResponseValidationError(w, r, Describe(err), Details(err))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		}
+
+		return http.HandlerFunc(fn)
+	}, nil
+}
+
+// Describe сокращает ошибку проверки до списка полей и причин без дампа схемы
+func Describe(err error) string {
+	details := Details(err)
+
+	messages := make([]string, 0, len(details))
+	for _, detail := range details {
+		if detail.Field == "" {
+			messages = append(messages, detail.Message)
+			continue
+		}
+		messages = append(messages, detail.Field+": "+detail.Message)
+	}
+
+	return strings.Join(messages, ", ")
+}
+
+// Details раскладывает ошибку проверки по полям запроса. Поля тела называются
+// как в ошибках обработчиков: team.members[0].username
+func Details(err error) []*api.ErrorDetail {
+	var multi openapi3.MultiError
+	if errors.As(err, &multi) {
+		var details []*api.ErrorDetail
+		for _, e := range multi {
+			details = append(details, Details(e)...)
+		}
+		return details
+	}
+
+	var requestErr *openapi3filter.RequestError
+	if errors.As(err, &requestErr) {
+		details := []*api.ErrorDetail{{Message: requestErr.Reason}}
+		if requestErr.Err != nil {
+			details = Details(requestErr.Err)
+		}
+
+		if requestErr.Parameter != nil {
+			for _, detail := range details {
+				detail.Field = joinField(requestErr.Parameter.Name, detail.Field)
+			}
+		}
+		for _, detail := range details {
+			if detail.Field == "" {
+				detail.Field = "body"
+			}
+		}
+		return details
+	}
+
+	var schemaErr *openapi3.SchemaError
+	if errors.As(err, &schemaErr) {
+		return []*api.ErrorDetail{{
+			Field:   fieldPath(schemaErr.JSONPointer()),
+			Message: schemaErr.Reason,
+		}}
+	}
+
+	var securityErr *openapi3filter.SecurityRequirementsError
+	if errors.As(err, &securityErr) {
+		return []*api.ErrorDetail{{Message: "credentials are required"}}
+	}
+
+	return []*api.ErrorDetail{{Message: err.Error()}}
+}
+
+func fieldPath(pointer []string) string {
+	var path string
+	for _, segment := range pointer {
+		if _, err := strconv.Atoi(segment); err == nil {
+			path += "[" + segment + "]"
+			continue
+		}
+		path = joinField(path, segment)
+	}
+	return path
+}
+
+func joinField(prefix, field string) string {
+	switch {
+	case prefix == "":
+		return field
+	case field == "":
+		return prefix
+	default:
+		return prefix + "." + field
+	}
+}
@@ -0,0 +1,323 @@
+package router
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"reviewer-service/internal/domain/auth"
+	domainPR "reviewer-service/internal/domain/pullrequest"
+	domainSubscription "reviewer-service/internal/domain/subscription"
+	"reviewer-service/internal/http-server/api"
+	"reviewer-service/internal/http-server/handlers/apikey"
+	availabilityHandlers "reviewer-service/internal/http-server/handlers/availability"
+	"reviewer-service/internal/http-server/handlers/identity"
+	"reviewer-service/internal/http-server/handlers/pullrequest"
+	repositoryHandlers "reviewer-service/internal/http-server/handlers/repository"
+	"reviewer-service/internal/http-server/handlers/role"
+	streamHandlers "reviewer-service/internal/http-server/handlers/stream"
+	"reviewer-service/internal/http-server/handlers/subscription"
+	"reviewer-service/internal/http-server/handlers/team"
+	"reviewer-service/internal/http-server/handlers/user"
+	"reviewer-service/internal/http-server/handlers/webhook"
+	authMiddleware "reviewer-service/internal/http-server/middleware/auth"
+	"reviewer-service/internal/http-server/middleware/logger"
+	rateLimitMiddleware "reviewer-service/internal/http-server/middleware/ratelimit"
+	seedMiddleware "reviewer-service/internal/http-server/middleware/seed"
+	validationMiddleware "reviewer-service/internal/http-server/middleware/validation"
+	"reviewer-service/internal/http-server/openapi"
+	"reviewer-service/internal/lib/metrics"
+	"reviewer-service/internal/lib/netguard"
+	"reviewer-service/internal/storage/postgresql"
+	"reviewer-service/internal/stream"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// Options описывает, какие middleware и маршруты включены. Сервис и
+// интеграционные тесты собирают HTTP API одной функцией New
+type Options struct {
+	// Auth включает аутентификацию и проверку scope; nil - без аутентификации
+	Auth *authMiddleware.Options
+	// RateLimit включает ограничение частоты запросов клиента: с Auth - по API
+	// ключу или пользователю, без Auth - по адресу. Key задает New
+	RateLimit *rateLimitMiddleware.Options
+	// AddressRateLimit - лимит по адресу до аутентификации, когда Auth задан.
+	// Он общий для всех клиентов за одним NAT или прокси, поэтому настраивается
+	// отдельно и шире лимита клиента; nil - без лимита по адресу
+	AddressRateLimit *rateLimitMiddleware.Options
+	// SeedHeader разрешает задавать зерно выбора заголовком X-Selection-Seed
+	SeedHeader       bool
+	ValidateRequests bool
+	LegacyRoutes     bool
+
+	// Пустой секрет или токен отключает вебхук
+	GitHubSecret string
+	GitLabToken  string
+
+	// EventHub включает /events/stream; nil - поток выключен
+	EventHub        *stream.Hub
+	StreamHeartbeat time.Duration
+
+	// SubscriptionTargets проверяет адреса новых подписок; nil - запрещены
+	// loopback, link-local и частные адреса
+	SubscriptionTargets domainSubscription.TargetPolicy
+
+	Syncer domainPR.ReviewerSyncer
+	Random domainPR.Random
+}
+
+// New собирает роутер HTTP API. Логгер и лимит по адресу стоят до
+// аутентификации, чтобы отклоненные с 401 запросы (подбор ключей и токенов)
+// тоже логировались и ограничивались
+func New(log *slog.Logger, storage *postgresql.Storage, opts Options) (http.Handler, error) {
+	apiSpec, err := openapi.Load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load openapi spec: %w", err)
+	}
+
+	specHandler, err := openapi.Handler(apiSpec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render openapi spec: %w", err)
+	}
+
+	if opts.SubscriptionTargets == nil {
+		opts.SubscriptionTargets, _ = netguard.New(nil)
+	}
+
+	router := chi.NewRouter()
+	router.NotFound(api.NotFound)
+	router.MethodNotAllowed(api.MethodNotAllowed)
+	router.Use(middleware.Recoverer)
+	router.Use(middleware.RequestID)
+	router.Use(logger.New(log))
+	addressLimit := opts.AddressRateLimit
+	if opts.Auth == nil {
+		addressLimit = opts.RateLimit
+	}
+	if addressLimit != nil {
+		byAddress := *addressLimit
+		byAddress.Key = rateLimitMiddleware.ByAddress
+		router.Use(rateLimitMiddleware.New(log, byAddress))
+	}
+	if opts.Auth != nil {
+		router.Use(authMiddleware.Authenticate(log, *opts.Auth))
+		if opts.RateLimit != nil {
+			byPrincipal := *opts.RateLimit
+			byPrincipal.Key = rateLimitMiddleware.ByPrincipal
+			router.Use(rateLimitMiddleware.New(log, byPrincipal))
+		}
+	}
+	if opts.SeedHeader {
+		router.Use(seedMiddleware.New(log))
+	}
+	if opts.ValidateRequests {
+		validator, err := validationMiddleware.New(log, apiSpec)
+		if err != nil {
+			return nil, fmt.Errorf("failed to configure request validation: %w", err)
+		}
+		router.Use(validator)
+	}
+
+	requireScope := func(scope string) func(http.Handler) http.Handler {
+		if opts.Auth == nil {
+			return func(next http.Handler) http.Handler { return next }
+		}
+		return authMiddleware.RequireScope(scope)
+	}
+
+	// Маршруты API живут под /api/v1, а при legacy_routes - еще и по старым путям
+	apiRoutes := func(router chi.Router) {
+		router.With(requireScope(auth.ScopeTeamsWrite)).Post(
+			"/team/add", team.Save(log, storage, storage),
+		)
+
+		router.With(requireScope(auth.ScopeRead)).Get(
+			"/team/get", team.Get(log, storage),
+		)
+
+		router.With(requireScope(auth.ScopeTeamsWrite)).Post(
+			"/team/setChatWebhook", team.SetChatWebhook(log, storage),
+		)
+
+		router.With(requireScope(auth.ScopeTeamsWrite)).Post(
+			"/team/setDefaultMaxOpenReviews", team.SetDefaultMaxOpenReviews(log, storage),
+		)
+
+		router.With(requireScope(auth.ScopeTeamsWrite)).Post(
+			"/team/setReviewSla", team.SetReviewSLA(log, storage),
+		)
+
+		router.With(requireScope(auth.ScopeRead)).Get(
+			"/team/getReviewSla", team.GetReviewSLA(log, storage),
+		)
+
+		router.With(requireScope(auth.ScopeTeamsWrite)).Post(
+			"/team/setCodeOwners", team.SetCodeOwners(log, storage, storage),
+		)
+
+		router.With(requireScope(auth.ScopeRead)).Get(
+			"/team/getCodeOwners", team.GetCodeOwners(log, storage),
+		)
+
+		router.With(requireScope(auth.ScopeTeamsWrite)).Post(
+			"/team/importCodeOwners", team.ImportCodeOwners(log, storage, storage),
+		)
+
+		router.With(requireScope(auth.ScopeTeamsWrite)).Post(
+			"/team/setReviewPolicy", team.SetReviewPolicy(log, storage, storage),
+		)
+
+		router.With(requireScope(auth.ScopeRead)).Get(
+			"/team/getReviewPolicy", team.GetReviewPolicy(log, storage),
+		)
+
+		router.With(requireScope(auth.ScopeTeamsWrite)).Post(
+			"/repositories/create", repositoryHandlers.Create(log, storage),
+		)
+
+		router.With(requireScope(auth.ScopeRead)).Get(
+			"/repositories/list", repositoryHandlers.List(log, storage),
+		)
+
+		router.With(requireScope(auth.ScopeTeamsWrite)).Post(
+			"/repositories/setReviewersCount", repositoryHandlers.SetReviewersCount(log, storage),
+		)
+
+		router.With(requireScope(auth.ScopeUsersWrite)).Post(
+			"/users/setIsActive", user.SetIsActive(log, storage, storage),
+		)
+
+		router.With(requireScope(auth.ScopeRead)).Get(
+			"/users/getReview", user.GetReview(log, storage),
+		)
+
+		router.With(requireScope(auth.ScopeUsersWrite)).Post(
+			"/users/setChatHandle", user.SetChatHandle(log, storage),
+		)
+
+		router.With(requireScope(auth.ScopeUsersWrite)).Post(
+			"/users/setMaxOpenReviews", user.SetMaxOpenReviews(log, storage),
+		)
+
+		router.With(requireScope(auth.ScopeUsersWrite)).Post(
+			"/users/setSeniority", user.SetSeniority(log, storage),
+		)
+
+		router.With(requireScope(auth.ScopeUsersWrite)).Post(
+			"/users/unavailability/create", availabilityHandlers.Create(log, storage),
+		)
+
+		router.With(requireScope(auth.ScopeRead)).Get(
+			"/users/unavailability/list", availabilityHandlers.List(log, storage),
+		)
+
+		router.With(requireScope(auth.ScopeUsersWrite)).Post(
+			"/users/unavailability/delete", availabilityHandlers.Delete(log, storage),
+		)
+
+		router.With(requireScope(auth.ScopePRsWrite)).Post(
+			"/pullRequest/create", pullrequest.Create(log, storage, storage, opts.Syncer, opts.Random),
+		)
+
+		router.With(requireScope(auth.ScopePRsWrite)).Post(
+			"/pullRequest/merge", pullrequest.Merge(log, storage, storage),
+		)
+
+		router.With(requireScope(auth.ScopePRsWrite)).Post(
+			"/pullRequest/reassign", pullrequest.Reassign(log, storage, storage, opts.Syncer, opts.Random),
+		)
+
+		router.With(requireScope(auth.ScopeRead)).Get(
+			"/pullRequest/suggestReviewers", pullrequest.SuggestReviewers(log, storage, opts.Random),
+		)
+
+		router.With(requireScope(auth.ScopeAdmin)).Post(
+			"/admin/apiKeys/create", apikey.Create(log, storage),
+		)
+
+		router.With(requireScope(auth.ScopeAdmin)).Get(
+			"/admin/apiKeys/list", apikey.List(log, storage),
+		)
+
+		router.With(requireScope(auth.ScopeAdmin)).Post(
+			"/admin/apiKeys/revoke", apikey.Revoke(log, storage),
+		)
+
+		router.With(requireScope(auth.ScopeAdmin)).Post(
+			"/admin/roles/grant", role.Grant(log, storage),
+		)
+
+		router.With(requireScope(auth.ScopeAdmin)).Post(
+			"/admin/roles/revoke", role.Revoke(log, storage),
+		)
+
+		router.With(requireScope(auth.ScopeAdmin)).Get(
+			"/admin/roles/list", role.List(log, storage),
+		)
+
+		router.With(requireScope(auth.ScopeAdmin)).Post(
+			"/admin/identities/set", identity.Set(log, storage),
+		)
+
+		router.With(requireScope(auth.ScopeAdmin)).Post(
+			"/admin/identities/delete", identity.Delete(log, storage),
+		)
+
+		router.With(requireScope(auth.ScopeAdmin)).Get(
+			"/admin/identities/list", identity.List(log, storage),
+		)
+
+		router.With(requireScope(auth.ScopeTeamsWrite)).Post(
+			"/subscriptions/create", subscription.Create(log, storage, opts.SubscriptionTargets),
+		)
+
+		router.With(requireScope(auth.ScopeRead)).Get(
+			"/subscriptions/list", subscription.List(log, storage),
+		)
+
+		router.With(requireScope(auth.ScopeTeamsWrite)).Post(
+			"/subscriptions/delete", subscription.Delete(log, storage),
+		)
+
+		router.With(requireScope(auth.ScopeRead)).Get(
+			"/subscriptions/deliveries", subscription.Deliveries(log, storage),
+		)
+
+		router.With(requireScope(auth.ScopeTeamsWrite)).Post(
+			"/subscriptions/deliveries/retry", subscription.Retry(log, storage),
+		)
+
+		if opts.EventHub != nil {
+			router.With(requireScope(auth.ScopeRead)).Get(
+				"/events/stream", streamHandlers.Events(log, opts.EventHub, storage, opts.StreamHeartbeat),
+			)
+		}
+
+		// Вебхуки аутентифицируются подписью, а не API ключом
+		if opts.GitHubSecret != "" {
+			router.Post(
+				"/webhooks/github", webhook.GitHub(log, storage, storage, opts.Syncer, opts.Random, opts.GitHubSecret),
+			)
+		}
+
+		if opts.GitLabToken != "" {
+			router.Post(
+				"/webhooks/gitlab", webhook.GitLab(log, storage, storage, opts.Syncer, opts.Random, opts.GitLabToken),
+			)
+		}
+	}
+
+	router.Route(api.BasePath, apiRoutes)
+	if opts.LegacyRoutes {
+		router.With(api.Deprecated).Group(apiRoutes)
+	}
+
+	router.Handle("/metrics", metrics.Handler())
+
+	router.Get("/openapi.json", specHandler)
+	router.Get("/docs", openapi.SwaggerUI("/openapi.json"))
+
+	return router, nil
+}
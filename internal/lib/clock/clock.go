@@ -0,0 +1,46 @@
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock - источник текущего времени для фоновых задач; в тестах подменяется на Fake
+type Clock interface {
+	Now() time.Time
+}
+
+// Real - системные часы
+type Real struct{}
+
+func (Real) Now() time.Time {
+	return time.Now()
+}
+
+// Fake - часы, которые идут только при вызове Set или Advance
+type Fake struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func NewFake(now time.Time) *Fake {
+	return &Fake{now: now}
+}
+
+func (f *Fake) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+func (f *Fake) Set(now time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = now
+}
+
+func (f *Fake) Advance(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = f.now.Add(d)
+}
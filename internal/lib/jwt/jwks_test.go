@@ -0,0 +1,46 @@
+package jwt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseJWKS(t *testing.T) {
+	const ecKey = `{"kty": "EC", "kid": "ec", "use": "sig", "crv": "P-256", "x": "AQ", "y": "Ag"}`
+	const rsaKey = `{"kty": "RSA", "kid": "rsa", "n": "AQAB", "e": "AQAB"}`
+
+	tests := []struct {
+		name     string
+		jwks     string
+		wantKids []string
+		wantErr  bool
+	}{
+		{name: "signing keys", jwks: `{"keys": [` + ecKey + `, ` + rsaKey + `]}`, wantKids: []string{"ec", "rsa"}},
+		{name: "ed25519 key is skipped", jwks: `{"keys": [{"kty": "OKP", "kid": "ed", "crv": "Ed25519", "x": "AQ"}, ` + ecKey + `]}`, wantKids: []string{"ec"}},
+		{name: "other curve is skipped", jwks: `{"keys": [{"kty": "EC", "kid": "p384", "crv": "P-384", "x": "AQ", "y": "Ag"}, ` + rsaKey + `]}`, wantKids: []string{"rsa"}},
+		{name: "broken key is skipped", jwks: `{"keys": [{"kty": "RSA", "kid": "bad", "n": "!!", "e": "AQAB"}, ` + rsaKey + `]}`, wantKids: []string{"rsa"}},
+		{name: "encryption key is skipped", jwks: `{"keys": [{"kty": "RSA", "kid": "enc", "use": "enc", "n": "AQAB", "e": "AQAB"}, ` + ecKey + `]}`, wantKids: []string{"ec"}},
+		{name: "no usable keys", jwks: `{"keys": [{"kty": "OKP", "kid": "ed", "crv": "Ed25519", "x": "AQ"}]}`, wantErr: true},
+		{name: "empty", jwks: `{"keys": []}`, wantErr: true},
+		{name: "not json", jwks: `keys`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keys, err := parseJWKS(discardLogger, []byte(tt.jwks))
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			kids := make([]string, 0, len(keys))
+			for kid := range keys {
+				kids = append(kids, kid)
+			}
+			assert.ElementsMatch(t, tt.wantKids, kids)
+		})
+	}
+}
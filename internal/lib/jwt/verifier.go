@@ -0,0 +1,172 @@
+package jwt
+
+import (
+	"context"
+	"crypto"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	jwtLib "github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	leeway         = 30 * time.Second
+	refreshBackoff = time.Minute
+)
+
+var (
+	ErrMalformedToken = errors.New("malformed token")
+	ErrUnknownKey     = errors.New("token signed with unknown key")
+	ErrBadSignature   = errors.New("invalid token signature")
+	ErrExpired        = errors.New("token is expired or not yet valid")
+	ErrClaims         = errors.New("token issuer or audience mismatch or required claim is missing")
+)
+
+// Config: Issuer и Audience обязательны, чтобы не принимать токены, выпущенные
+// другим провайдером или для другого сервиса
+type Config struct {
+	// JWKS - путь к файлу или http(s) URL с набором ключей
+	JWKS     string
+	Issuer   string
+	Audience string
+}
+
+type Claims map[string]any
+
+// Verifier проверяет подпись (RS256, ES256) и стандартные claims JWT
+// по ключам из JWKS
+type Verifier struct {
+	cfg    Config
+	log    *slog.Logger
+	client *http.Client
+	now    func() time.Time
+	parser *jwtLib.Parser
+
+	mu          sync.RWMutex
+	keys        map[string]crypto.PublicKey
+	lastRefresh time.Time
+}
+
+func NewVerifier(ctx context.Context, log *slog.Logger, cfg Config) (*Verifier, error) {
+	if cfg.Issuer == "" || cfg.Audience == "" {
+		return nil, errors.New("jwt issuer and audience are required")
+	}
+
+	v := &Verifier{
+		cfg:    cfg,
+		log:    log.With(slog.String("component", "jwt")),
+		client: &http.Client{Timeout: 5 * time.Second},
+		now:    time.Now,
+	}
+	v.parser = jwtLib.NewParser(
+		jwtLib.WithValidMethods([]string{"RS256", "ES256"}),
+		jwtLib.WithIssuer(cfg.Issuer),
+		jwtLib.WithAudience(cfg.Audience),
+		jwtLib.WithExpirationRequired(),
+		jwtLib.WithLeeway(leeway),
+		jwtLib.WithTimeFunc(func() time.Time { return v.now() }),
+	)
+
+	keys, err := loadJWKS(ctx, v.log, v.client, cfg.JWKS)
+	if err != nil {
+		return nil, err
+	}
+	v.keys = keys
+	v.lastRefresh = v.now()
+
+	return v, nil
+}
+
+func (v *Verifier) Verify(ctx context.Context, token string) (Claims, error) {
+	claims := jwtLib.MapClaims{}
+	_, err := v.parser.ParseWithClaims(token, claims, func(t *jwtLib.Token) (any, error) {
+		kid, _ := t.Header["kid"].(string)
+		return v.key(ctx, kid)
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	return Claims(claims), nil
+}
+
+// mapError сводит ошибки golang-jwt к ошибкам пакета. Отсутствие обязательного
+// claim (exp, iss или aud) golang-jwt не уточняет, поэтому это ErrClaims
+func mapError(err error) error {
+	switch {
+	case errors.Is(err, ErrUnknownKey):
+		return err
+	case errors.Is(err, jwtLib.ErrTokenMalformed):
+		return ErrMalformedToken
+	case errors.Is(err, jwtLib.ErrTokenExpired),
+		errors.Is(err, jwtLib.ErrTokenNotValidYet),
+		errors.Is(err, jwtLib.ErrTokenUsedBeforeIssued):
+		return ErrExpired
+	case errors.Is(err, jwtLib.ErrTokenInvalidIssuer),
+		errors.Is(err, jwtLib.ErrTokenInvalidAudience),
+		errors.Is(err, jwtLib.ErrTokenRequiredClaimMissing):
+		return ErrClaims
+	default:
+		return fmt.Errorf("%w: %v", ErrBadSignature, err)
+	}
+}
+
+func (v *Verifier) key(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	v.mu.RLock()
+	key, ok := v.keys[kid]
+	canRefresh := v.now().Sub(v.lastRefresh) > refreshBackoff
+	v.mu.RUnlock()
+
+	if ok {
+		return key, nil
+	}
+	if !canRefresh {
+		return nil, ErrUnknownKey
+	}
+
+	// Ключ мог смениться у провайдера - перечитываем JWKS не чаще refreshBackoff
+	keys, err := loadJWKS(ctx, v.log, v.client, v.cfg.JWKS)
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.lastRefresh = v.now()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnknownKey, err)
+	}
+	v.keys = keys
+
+	key, ok = v.keys[kid]
+	if !ok {
+		return nil, ErrUnknownKey
+	}
+	return key, nil
+}
+
+func (c Claims) String(name string) string {
+	value, _ := c[name].(string)
+	return value
+}
+
+// Scopes возвращает значения claim scope (строка через пробел) или scp (массив)
+func (c Claims) Scopes() []string {
+	if scope := c.String("scope"); scope != "" {
+		return strings.Fields(scope)
+	}
+	return c.strings("scp")
+}
+
+func (c Claims) strings(name string) []string {
+	values, _ := c[name].([]any)
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		if s, ok := value.(string); ok {
+			result = append(result, s)
+		}
+	}
+	return result
+}
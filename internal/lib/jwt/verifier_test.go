@@ -0,0 +1,163 @@
+package jwt
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	jwtLib "github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testIssuer   = "https://idp.example.com"
+	testAudience = "reviewer-service"
+	testKid      = "key-1"
+)
+
+var discardLogger = slog.New(slog.NewTextHandler(io.Discard, nil))
+
+// writeJWKS сохраняет открытый ключ в файл JWKS и возвращает путь к нему
+func writeJWKS(t *testing.T, key *ecdsa.PrivateKey) string {
+	t.Helper()
+
+	encode := func(b []byte) string { return base64.RawURLEncoding.EncodeToString(b) }
+	set := jsonWebKeySet{Keys: []jsonWebKey{{
+		Kty: "EC",
+		Kid: testKid,
+		Use: "sig",
+		Alg: "ES256",
+		Crv: "P-256",
+		X:   encode(key.X.FillBytes(make([]byte, 32))),
+		Y:   encode(key.Y.FillBytes(make([]byte, 32))),
+	}}}
+
+	data, err := json.Marshal(set)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+	return path
+}
+
+func sign(t *testing.T, method jwtLib.SigningMethod, key any, kid string, claims jwtLib.MapClaims) string {
+	t.Helper()
+
+	token := jwtLib.NewWithClaims(method, claims)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	signed, err := token.SignedString(key)
+	require.NoError(t, err)
+	return signed
+}
+
+func TestVerifier_Verify(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	verifier, err := NewVerifier(context.Background(), discardLogger, Config{JWKS: writeJWKS(t, key), Issuer: testIssuer, Audience: testAudience})
+	require.NoError(t, err)
+
+	now := time.Now()
+	claims := func(override jwtLib.MapClaims) jwtLib.MapClaims {
+		result := jwtLib.MapClaims{
+			"sub": "u1",
+			"iss": testIssuer,
+			"aud": testAudience,
+			"exp": now.Add(time.Hour).Unix(),
+		}
+		for name, value := range override {
+			if value == nil {
+				delete(result, name)
+			} else {
+				result[name] = value
+			}
+		}
+		return result
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr error
+	}{
+		{name: "valid", token: sign(t, jwtLib.SigningMethodES256, key, testKid, claims(nil))},
+		{name: "audience list", token: sign(t, jwtLib.SigningMethodES256, key, testKid, claims(jwtLib.MapClaims{"aud": []string{"other", testAudience}}))},
+		{name: "expired within leeway", token: sign(t, jwtLib.SigningMethodES256, key, testKid, claims(jwtLib.MapClaims{"exp": now.Add(-10 * time.Second).Unix()}))},
+		{name: "expired", token: sign(t, jwtLib.SigningMethodES256, key, testKid, claims(jwtLib.MapClaims{"exp": now.Add(-time.Hour).Unix()})), wantErr: ErrExpired},
+		{name: "not yet valid", token: sign(t, jwtLib.SigningMethodES256, key, testKid, claims(jwtLib.MapClaims{"nbf": now.Add(time.Hour).Unix()})), wantErr: ErrExpired},
+		{name: "no exp", token: sign(t, jwtLib.SigningMethodES256, key, testKid, claims(jwtLib.MapClaims{"exp": nil})), wantErr: ErrClaims},
+		{name: "other issuer", token: sign(t, jwtLib.SigningMethodES256, key, testKid, claims(jwtLib.MapClaims{"iss": "https://evil.example.com"})), wantErr: ErrClaims},
+		{name: "other audience", token: sign(t, jwtLib.SigningMethodES256, key, testKid, claims(jwtLib.MapClaims{"aud": "other"})), wantErr: ErrClaims},
+		{name: "no audience", token: sign(t, jwtLib.SigningMethodES256, key, testKid, claims(jwtLib.MapClaims{"aud": nil})), wantErr: ErrClaims},
+		{name: "unknown kid", token: sign(t, jwtLib.SigningMethodES256, key, "key-2", claims(nil)), wantErr: ErrUnknownKey},
+		{name: "other key", token: sign(t, jwtLib.SigningMethodES256, otherKey, testKid, claims(nil)), wantErr: ErrBadSignature},
+		{name: "hmac", token: sign(t, jwtLib.SigningMethodHS256, []byte("secret"), testKid, claims(nil)), wantErr: ErrBadSignature},
+		{name: "malformed", token: "not.a.token", wantErr: ErrMalformedToken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := verifier.Verify(context.Background(), tt.token)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "u1", got.String("sub"))
+		})
+	}
+}
+
+func TestNewVerifier_RequiresIssuerAndAudience(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	path := writeJWKS(t, key)
+
+	tests := []struct {
+		name string
+		cfg  Config
+	}{
+		{name: "no issuer", cfg: Config{JWKS: path, Audience: testAudience}},
+		{name: "no audience", cfg: Config{JWKS: path, Issuer: testIssuer}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewVerifier(context.Background(), discardLogger, tt.cfg)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestClaims_Scopes(t *testing.T) {
+	tests := []struct {
+		name   string
+		claims Claims
+		want   []string
+	}{
+		{name: "scope string", claims: Claims{"scope": "pr:read  pr:write"}, want: []string{"pr:read", "pr:write"}},
+		{name: "scp array", claims: Claims{"scp": []any{"pr:read", 42, "admin"}}, want: []string{"pr:read", "admin"}},
+		{name: "scope wins", claims: Claims{"scope": "admin", "scp": []any{"pr:read"}}, want: []string{"admin"}},
+		{name: "none", claims: Claims{}, want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.claims.Scopes())
+		})
+	}
+}
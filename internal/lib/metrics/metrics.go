@@ -0,0 +1,49 @@
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "reviewer_service"
+
+var RateLimitedRequests = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "rate_limited_requests_total",
+		Help:      "Requests rejected by the rate limiter.",
+	},
+	[]string{"route"},
+)
+
+var StreamClients = promauto.NewGauge(
+	prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "stream_clients",
+		Help:      "Clients connected to the event stream.",
+	},
+)
+
+var StreamLaggedClients = promauto.NewCounter(
+	prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "stream_lagged_clients_total",
+		Help:      "Event stream clients disconnected because they could not keep up.",
+	},
+)
+
+var SLABreaches = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "sla_breaches_total",
+		Help:      "Reviewer assignments that exceeded the team review SLA.",
+	},
+	[]string{"team"},
+)
+
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
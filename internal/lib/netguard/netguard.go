@@ -0,0 +1,108 @@
+package netguard
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"net/url"
+	"strings"
+	"syscall"
+)
+
+var (
+	ErrScheme = errors.New("only http and https urls are allowed")
+	ErrHost   = errors.New("url host is empty")
+	ErrTarget = errors.New("target address is not allowed")
+)
+
+// Guard запрещает запросы на loopback, link-local и частные адреса, кроме
+// подсетей из списка разрешенных. Защищает от SSRF при отправке на адреса,
+// которые задают клиенты API
+type Guard struct {
+	allowed []netip.Prefix
+}
+
+// New принимает список разрешенных подсетей в виде CIDR или отдельных IP
+func New(allowed []string) (*Guard, error) {
+	g := &Guard{allowed: make([]netip.Prefix, 0, len(allowed))}
+	for _, value := range allowed {
+		prefix, err := netip.ParsePrefix(value)
+		if err != nil {
+			addr, addrErr := netip.ParseAddr(value)
+			if addrErr != nil {
+				return nil, fmt.Errorf("invalid allowed target %q: %w", value, err)
+			}
+			prefix = netip.PrefixFrom(addr, addr.BitLen())
+		}
+		g.allowed = append(g.allowed, prefix.Masked())
+	}
+
+	return g, nil
+}
+
+// CheckURL проверяет схему и, если хост задан IP адресом или localhost, сам адрес.
+// Имена хостов проверяются при соединении через Control, так как DNS может
+// вернуть другой адрес ко времени запроса
+func (g *Guard) CheckURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return ErrScheme
+	}
+
+	host := u.Hostname()
+	if host == "" {
+		return ErrHost
+	}
+
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		host = "127.0.0.1"
+	}
+
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return nil
+	}
+
+	return g.CheckAddr(addr)
+}
+
+// CheckAddr возвращает ErrTarget для запрещенного адреса
+func (g *Guard) CheckAddr(addr netip.Addr) error {
+	addr = addr.Unmap()
+	for _, prefix := range g.allowed {
+		if prefix.Contains(addr) {
+			return nil
+		}
+	}
+
+	if addr.IsLoopback() || addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() ||
+		addr.IsPrivate() || addr.IsUnspecified() {
+		return ErrTarget
+	}
+
+	return nil
+}
+
+// Control подходит для net.Dialer.Control: проверяет адрес, с которым
+// устанавливается соединение, уже после разрешения имени
+func (g *Guard) Control(network, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return err
+	}
+
+	if err := g.CheckAddr(addrPort.Addr()); err != nil {
+		return fmt.Errorf("%w: %s", err, address)
+	}
+
+	return nil
+}
+
+// Dialer возвращает net.Dialer, который не соединяется с запрещенными адресами
+func (g *Guard) Dialer() *net.Dialer {
+	return &net.Dialer{Control: g.Control}
+}
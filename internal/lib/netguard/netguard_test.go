@@ -0,0 +1,64 @@
+package netguard
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGuard_CheckURL(t *testing.T) {
+	guard, err := New([]string{"10.20.0.0/16", "192.168.1.5"})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		url     string
+		wantErr error
+	}{
+		{name: "public host", url: "https://bot.example.com/hook"},
+		{name: "public ip", url: "http://93.184.216.34/hook"},
+		{name: "allowed subnet", url: "http://10.20.3.4:8080/hook"},
+		{name: "allowed ip", url: "http://192.168.1.5/hook"},
+		{name: "ftp", url: "ftp://example.com/hook", wantErr: ErrScheme},
+		{name: "no host", url: "http:///hook", wantErr: ErrHost},
+		{name: "localhost", url: "http://localhost:8080/hook", wantErr: ErrTarget},
+		{name: "loopback", url: "http://127.0.0.1/hook", wantErr: ErrTarget},
+		{name: "loopback ipv6", url: "http://[::1]/hook", wantErr: ErrTarget},
+		{name: "mapped loopback", url: "http://[::ffff:127.0.0.1]/hook", wantErr: ErrTarget},
+		{name: "metadata", url: "http://169.254.169.254/latest", wantErr: ErrTarget},
+		{name: "private", url: "http://10.1.2.3/hook", wantErr: ErrTarget},
+		{name: "other private ip", url: "http://192.168.1.6/hook", wantErr: ErrTarget},
+		{name: "unspecified", url: "http://0.0.0.0/hook", wantErr: ErrTarget},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := guard.CheckURL(tt.url)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGuard_Control(t *testing.T) {
+	guard, err := New(nil)
+	require.NoError(t, err)
+
+	assert.NoError(t, guard.Control("tcp4", "93.184.216.34:443", nil))
+	assert.ErrorIs(t, guard.Control("tcp4", "127.0.0.1:80", nil), ErrTarget)
+	assert.ErrorIs(t, guard.Control("tcp6", "[fe80::1]:80", nil), ErrTarget)
+}
+
+func TestNew_InvalidTarget(t *testing.T) {
+	_, err := New([]string{"not-a-subnet"})
+	assert.Error(t, err)
+
+	guard, err := New([]string{"fd00::/8"})
+	require.NoError(t, err)
+	assert.NoError(t, guard.CheckAddr(netip.MustParseAddr("fd12::1")))
+}
@@ -0,0 +1,49 @@
+package random
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// Source - источник случайности для выбора среди равных кандидатов в ревьюверы
+type Source interface {
+	Shuffle(n int, swap func(i, j int))
+}
+
+// Seeded - потокобезопасный генератор math/rand: с одним зерном выдает одну и ту же
+// последовательность
+type Seeded struct {
+	mu  sync.Mutex
+	rnd *rand.Rand
+}
+
+// New возвращает генератор с зерном seed; 0 - зерно от текущего времени
+func New(seed int64) *Seeded {
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	return &Seeded{rnd: rand.New(rand.NewSource(seed))}
+}
+
+func (s *Seeded) Shuffle(n int, swap func(i, j int)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rnd.Shuffle(n, swap)
+}
+
+type sourceKey struct{}
+
+// WithSource задает источник случайности для одного запроса
+func WithSource(ctx context.Context, source Source) context.Context {
+	return context.WithValue(ctx, sourceKey{}, source)
+}
+
+// FromContext возвращает источник запроса, а если он не задан - fallback
+func FromContext(ctx context.Context, fallback Source) Source {
+	if source, ok := ctx.Value(sourceKey{}).(Source); ok {
+		return source
+	}
+	return fallback
+}
@@ -0,0 +1,99 @@
+package ratelimit
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// Limit - параметры token bucket: Rate токенов в секунду, не больше Burst за раз
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+// Result описывает решение для одного запроса
+type Result struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// RetryAfter - через сколько появится следующий токен (только при отказе)
+	RetryAfter time.Duration
+	// Reset - через сколько bucket полностью восстановится
+	Reset time.Duration
+}
+
+type bucket struct {
+	tokens   float64
+	lastSeen time.Time
+}
+
+// Limiter хранит token bucket для каждого ключа в памяти процесса
+type Limiter struct {
+	mu      sync.Mutex
+	buckets map[string]*bucket
+	idleTTL time.Duration
+	lastGC  time.Time
+	now     func() time.Time
+}
+
+func New() *Limiter {
+	return &Limiter{
+		buckets: make(map[string]*bucket),
+		idleTTL: 10 * time.Minute,
+		now:     time.Now,
+	}
+}
+
+// Allow списывает токен из bucket ключа key, если он есть
+func (l *Limiter) Allow(key string, limit Limit) Result {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.collectIdle(now)
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(limit.Burst), lastSeen: now}
+		l.buckets[key] = b
+	}
+
+	elapsed := now.Sub(b.lastSeen).Seconds()
+	b.tokens = math.Min(float64(limit.Burst), b.tokens+elapsed*limit.Rate)
+	b.lastSeen = now
+
+	result := Result{Limit: limit.Burst}
+	if b.tokens >= 1 {
+		b.tokens--
+		result.Allowed = true
+	} else {
+		result.RetryAfter = secondsToDuration((1 - b.tokens) / limit.Rate)
+	}
+
+	result.Remaining = int(math.Floor(b.tokens))
+	result.Reset = secondsToDuration((float64(limit.Burst) - b.tokens) / limit.Rate)
+
+	return result
+}
+
+// collectIdle удаляет давно не использованные bucket, чтобы map не росла бесконечно
+func (l *Limiter) collectIdle(now time.Time) {
+	if now.Sub(l.lastGC) < l.idleTTL {
+		return
+	}
+	l.lastGC = now
+
+	for key, b := range l.buckets {
+		if now.Sub(b.lastSeen) > l.idleTTL {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+func secondsToDuration(seconds float64) time.Duration {
+	if seconds <= 0 || math.IsInf(seconds, 0) || math.IsNaN(seconds) {
+		return 0
+	}
+	return time.Duration(seconds * float64(time.Second))
+}
@@ -0,0 +1,86 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLimiter_Allow(t *testing.T) {
+	limit := Limit{Rate: 2, Burst: 3}
+
+	// step - запрос через after после предыдущего
+	type step struct {
+		after time.Duration
+		want  Result
+	}
+
+	tests := []struct {
+		name  string
+		steps []step
+	}{
+		{
+			name: "burst then reject",
+			steps: []step{
+				{want: Result{Allowed: true, Limit: 3, Remaining: 2, Reset: 500 * time.Millisecond}},
+				{want: Result{Allowed: true, Limit: 3, Remaining: 1, Reset: time.Second}},
+				{want: Result{Allowed: true, Limit: 3, Remaining: 0, Reset: 1500 * time.Millisecond}},
+				{want: Result{Limit: 3, Remaining: 0, RetryAfter: 500 * time.Millisecond, Reset: 1500 * time.Millisecond}},
+			},
+		},
+		{
+			name: "refill at rate",
+			steps: []step{
+				{want: Result{Allowed: true, Limit: 3, Remaining: 2, Reset: 500 * time.Millisecond}},
+				{want: Result{Allowed: true, Limit: 3, Remaining: 1, Reset: time.Second}},
+				{want: Result{Allowed: true, Limit: 3, Remaining: 0, Reset: 1500 * time.Millisecond}},
+				{after: 250 * time.Millisecond, want: Result{Limit: 3, Remaining: 0, RetryAfter: 250 * time.Millisecond, Reset: 1250 * time.Millisecond}},
+				{after: 250 * time.Millisecond, want: Result{Allowed: true, Limit: 3, Remaining: 0, Reset: 1500 * time.Millisecond}},
+			},
+		},
+		{
+			name: "refill is capped by burst",
+			steps: []step{
+				{want: Result{Allowed: true, Limit: 3, Remaining: 2, Reset: 500 * time.Millisecond}},
+				{after: time.Hour, want: Result{Allowed: true, Limit: 3, Remaining: 2, Reset: 500 * time.Millisecond}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+			limiter := New()
+			limiter.now = func() time.Time { return now }
+
+			for i, s := range tt.steps {
+				now = now.Add(s.after)
+				assert.Equal(t, s.want, limiter.Allow("client", limit), "step %d", i)
+			}
+		})
+	}
+}
+
+func TestLimiter_KeysAreIndependent(t *testing.T) {
+	limiter := New()
+	limit := Limit{Rate: 1, Burst: 1}
+
+	assert.True(t, limiter.Allow("a", limit).Allowed)
+	assert.False(t, limiter.Allow("a", limit).Allowed)
+	assert.True(t, limiter.Allow("b", limit).Allowed)
+}
+
+func TestLimiter_CollectsIdleBuckets(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	limiter := New()
+	limiter.now = func() time.Time { return now }
+	limit := Limit{Rate: 1, Burst: 1}
+
+	limiter.Allow("idle", limit)
+	now = now.Add(limiter.idleTTL + time.Second)
+	limiter.Allow("active", limit)
+
+	assert.NotContains(t, limiter.buckets, "idle")
+	assert.Contains(t, limiter.buckets, "active")
+}
@@ -0,0 +1,222 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"reviewer-service/internal/domain/event"
+	"reviewer-service/internal/domain/user"
+	logUtil "reviewer-service/internal/lib/logger/slog"
+	"reviewer-service/internal/storage"
+	"strings"
+	"text/template"
+	"time"
+)
+
+const (
+	DefaultAssignedTemplate   = `{{.Reviewer.Mention}}, you were assigned to review *{{.PullRequestName}}* ({{.PullRequestId}}) by {{.Author.Mention}}`
+	DefaultReassignedTemplate = `{{.Reviewer.Mention}}, you were assigned to review *{{.PullRequestName}}* ({{.PullRequestId}}) by {{.Author.Mention}} instead of {{.ReplacedReviewer.Username}}`
+	// DefaultMentionFormat - упоминание пользователя Slack по member ID
+	DefaultMentionFormat = "<@%s>"
+)
+
+type Repository interface {
+	GetTeamChatWebhook(ctx context.Context, teamName string) (string, error)
+	GetUserByUserId(ctx context.Context, userId string) (*user.Model, error)
+}
+
+type Options struct {
+	// AssignedTemplate и ReassignedTemplate - шаблоны text/template для
+	// назначения при создании PR и при переназначении, данные - Message
+	AssignedTemplate   string
+	ReassignedTemplate string
+	// MentionFormat - формат fmt для упоминания по chat handle
+	MentionFormat string
+	Timeout       time.Duration
+}
+
+// Person - участник уведомления. Mention - упоминание по chat handle,
+// а если он не задан - имя пользователя
+type Person struct {
+	UserId   string
+	Username string
+	Handle   string
+	Mention  string
+}
+
+// Message - данные шаблона уведомления
+type Message struct {
+	PullRequestId    string
+	PullRequestName  string
+	TeamName         string
+	Author           Person
+	Reviewer         Person
+	ReplacedReviewer Person
+}
+
+// Notifier отправляет в чат команды сообщение о назначении ревьювера. Работает
+// как Sink outbox по событиям reviewer.assigned: сбой чата или ответ 5xx
+// возвращается ошибкой и событие повторяется, а 4xx (неверный адрес вебхука)
+// только логируется, чтобы не задерживать остальные события PR
+type Notifier struct {
+	log           *slog.Logger
+	repo          Repository
+	http          *http.Client
+	assigned      *template.Template
+	reassigned    *template.Template
+	mentionFormat string
+}
+
+func New(log *slog.Logger, repo Repository, opts Options) (*Notifier, error) {
+	if opts.AssignedTemplate == "" {
+		opts.AssignedTemplate = DefaultAssignedTemplate
+	}
+	if opts.ReassignedTemplate == "" {
+		opts.ReassignedTemplate = DefaultReassignedTemplate
+	}
+	if opts.MentionFormat == "" {
+		opts.MentionFormat = DefaultMentionFormat
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = 5 * time.Second
+	}
+
+	assigned, err := template.New("assigned").Parse(opts.AssignedTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("assigned template: %w", err)
+	}
+
+	reassigned, err := template.New("reassigned").Parse(opts.ReassignedTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("reassigned template: %w", err)
+	}
+
+	return &Notifier{
+		log:           log.With(slog.String("component", "notifier")),
+		repo:          repo,
+		http:          &http.Client{Timeout: opts.Timeout},
+		assigned:      assigned,
+		reassigned:    reassigned,
+		mentionFormat: opts.MentionFormat,
+	}, nil
+}
+
+func (n *Notifier) Send(ctx context.Context, e *event.Event) error {
+	if e.Type != event.TypeReviewerAssigned || e.TeamName == "" {
+		return nil
+	}
+
+	webhookURL, err := n.repo.GetTeamChatWebhook(ctx, e.TeamName)
+	if err != nil {
+		if storageErr, ok := storage.IsError(err); ok && storageErr == storage.ErrTeamNotFound {
+			return nil
+		}
+		return err
+	}
+	if webhookURL == "" {
+		return nil
+	}
+
+	var payload event.ReviewerAssignedPayload
+	if err := json.Unmarshal(e.Payload, &payload); err != nil {
+		return err
+	}
+
+	text, err := n.render(ctx, e.TeamName, &payload)
+	if err != nil {
+		return err
+	}
+
+	return n.post(ctx, webhookURL, text, e)
+}
+
+func (n *Notifier) render(ctx context.Context, teamName string, payload *event.ReviewerAssignedPayload) (string, error) {
+	msg := Message{
+		PullRequestId:   payload.PullRequestId,
+		PullRequestName: payload.PullRequestName,
+		TeamName:        teamName,
+	}
+
+	var err error
+	if msg.Reviewer, err = n.person(ctx, payload.ReviewerId); err != nil {
+		return "", err
+	}
+	if msg.Author, err = n.person(ctx, payload.AuthorId); err != nil {
+		return "", err
+	}
+
+	tmpl := n.assigned
+	if payload.ReplacedReviewerId != "" {
+		tmpl = n.reassigned
+		if msg.ReplacedReviewer, err = n.person(ctx, payload.ReplacedReviewerId); err != nil {
+			return "", err
+		}
+	}
+
+	var buf strings.Builder
+	if err := tmpl.Execute(&buf, msg); err != nil {
+		return "", fmt.Errorf("render %s template: %w", tmpl.Name(), err)
+	}
+
+	return buf.String(), nil
+}
+
+// person загружает участника; удаленный пользователь упоминается по user_id
+func (n *Notifier) person(ctx context.Context, userId string) (Person, error) {
+	u, err := n.repo.GetUserByUserId(ctx, userId)
+	if err != nil {
+		if storageErr, ok := storage.IsError(err); ok && storageErr == storage.ErrUserNotFound {
+			return Person{UserId: userId, Username: userId, Mention: userId}, nil
+		}
+		return Person{}, err
+	}
+
+	p := Person{
+		UserId:   u.UserId,
+		Username: u.Username,
+		Handle:   u.ChatHandle,
+		Mention:  u.Username,
+	}
+	if u.ChatHandle != "" {
+		p.Mention = fmt.Sprintf(n.mentionFormat, u.ChatHandle)
+	}
+
+	return p, nil
+}
+
+func (n *Notifier) post(ctx context.Context, webhookURL string, text string, e *event.Event) error {
+	body, err := json.Marshal(map[string]string{"text": text})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 1024))
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return nil
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		return fmt.Errorf("chat webhook responded with status %d", resp.StatusCode)
+	default:
+		n.log.Warn("chat webhook rejected notification",
+			logUtil.Int64("event_id", e.ID),
+			slog.String("team_name", e.TeamName),
+			slog.Int("status", resp.StatusCode))
+		return nil
+	}
+}
@@ -0,0 +1,200 @@
+package outbox
+
+import (
+	"context"
+	"log/slog"
+	"reviewer-service/internal/domain/event"
+	logUtil "reviewer-service/internal/lib/logger/slog"
+	"sync"
+	"time"
+)
+
+type Repository interface {
+	TryLockOutbox(ctx context.Context) (bool, error)
+	ClaimEvents(ctx context.Context, limit int, lease time.Duration) ([]*event.Claimed, error)
+	MarkEventSent(ctx context.Context, id int64) error
+	MarkEventFailed(ctx context.Context, id int64, reason string, retryIn time.Duration, dead bool) error
+	ReleaseEvent(ctx context.Context, id int64) error
+}
+
+type TransactionManager interface {
+	WithTransaction(ctx context.Context, fn func(context.Context) error) error
+}
+
+// Sink доставляет событие получателю. Ошибка означает, что событие будет
+// отправлено повторно, поэтому получатели должны быть идемпотентны по Event.ID
+type Sink interface {
+	Send(ctx context.Context, e *event.Event) error
+}
+
+type Options struct {
+	PollInterval   time.Duration
+	BatchSize      int
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	// Lease - время, на которое пачка событий забирается на отправку. Если
+	// экземпляр сервиса упал, не отправив пачку, ее заберет другой
+	Lease time.Duration
+}
+
+// Dispatcher периодически пересылает неотправленные события из outbox в Sink.
+// Доставка at-least-once: событие помечается отправленным только после успеха Sink.
+// Если событие агрегата не доставлено, следующие события этого агрегата ждут
+// повторной попытки с экспоненциальной задержкой. После MaxAttempts событие
+// помечается failed_at и больше не задерживает свой агрегат
+type Dispatcher struct {
+	log       *slog.Logger
+	txManager TransactionManager
+	repo      Repository
+	sink      Sink
+	opts      Options
+
+	wg sync.WaitGroup
+}
+
+func NewDispatcher(log *slog.Logger, txManager TransactionManager, repo Repository, sink Sink, opts Options) *Dispatcher {
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = time.Second
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = 100
+	}
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = 10
+	}
+	if opts.InitialBackoff <= 0 {
+		opts.InitialBackoff = time.Second
+	}
+	if opts.MaxBackoff <= 0 {
+		opts.MaxBackoff = 5 * time.Minute
+	}
+	if opts.Lease <= 0 {
+		opts.Lease = time.Minute
+	}
+
+	return &Dispatcher{
+		log:       log.With(slog.String("component", "outbox/dispatcher")),
+		txManager: txManager,
+		repo:      repo,
+		sink:      sink,
+		opts:      opts,
+	}
+}
+
+// Start запускает рассылку в отдельной горутине до отмены ctx
+func (d *Dispatcher) Start(ctx context.Context) {
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
+		d.run(ctx)
+	}()
+
+	d.log.Info("outbox dispatcher started", slog.String("poll_interval", d.opts.PollInterval.String()))
+}
+
+// Wait ждет остановки после отмены контекста Start
+func (d *Dispatcher) Wait() {
+	d.wg.Wait()
+}
+
+func (d *Dispatcher) run(ctx context.Context) {
+	ticker := time.NewTicker(d.opts.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := d.DispatchOnce(ctx); err != nil && ctx.Err() == nil {
+				d.log.Error("failed to dispatch outbox events", logUtil.Err(err))
+			}
+		}
+	}
+}
+
+// DispatchOnce отправляет одну пачку событий и возвращает число доставленных.
+// Пачка забирается в короткой транзакции, а Sink вызывается уже вне ее
+func (d *Dispatcher) DispatchOnce(ctx context.Context) (int, error) {
+	claims, err := d.claim(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	blocked := make(map[string]bool)
+	for _, claim := range claims {
+		e := claim.Event
+		aggregate := e.AggregateType + ":" + e.AggregateId
+
+		// Более раннее событие агрегата не доставлено, это ждет следующей пачки
+		if blocked[aggregate] {
+			if err := d.repo.ReleaseEvent(ctx, e.ID); err != nil {
+				return sent, err
+			}
+			continue
+		}
+
+		if err := d.sink.Send(ctx, e); err != nil {
+			blocked[aggregate] = true
+
+			attempt := claim.Attempts + 1
+			dead := attempt >= d.opts.MaxAttempts
+			retryIn := backoff(d.opts.InitialBackoff, d.opts.MaxBackoff, attempt)
+
+			log := d.log.With(
+				logUtil.Int64("event_id", e.ID),
+				slog.String("type", e.Type),
+				slog.String("aggregate", aggregate),
+				slog.Int("attempt", attempt),
+				logUtil.Err(err),
+			)
+			if dead {
+				log.Error("outbox event marked as failed")
+			} else {
+				log.Warn("failed to deliver outbox event, will retry", slog.String("retry_in", retryIn.String()))
+			}
+
+			if err := d.repo.MarkEventFailed(ctx, e.ID, err.Error(), retryIn, dead); err != nil {
+				return sent, err
+			}
+			continue
+		}
+
+		if err := d.repo.MarkEventSent(ctx, e.ID); err != nil {
+			return sent, err
+		}
+		sent++
+	}
+
+	return sent, nil
+}
+
+// claim забирает пачку событий под advisory lock. Если lock у другого
+// экземпляра сервиса, пачка пустая
+func (d *Dispatcher) claim(ctx context.Context) ([]*event.Claimed, error) {
+	var claims []*event.Claimed
+
+	err := d.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		locked, err := d.repo.TryLockOutbox(txCtx)
+		if err != nil || !locked {
+			return err
+		}
+
+		claims, err = d.repo.ClaimEvents(txCtx, d.opts.BatchSize, d.opts.Lease)
+		return err
+	})
+
+	return claims, err
+}
+
+// backoff возвращает задержку перед попыткой attempt+1: initial, удваиваемая
+// с каждой попыткой, но не больше maxDelay
+func backoff(initial, maxDelay time.Duration, attempt int) time.Duration {
+	delay := initial
+	for i := 1; i < attempt && delay < maxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, maxDelay)
+}
@@ -0,0 +1,132 @@
+package outbox
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"reviewer-service/internal/domain/event"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// WriterSink пишет события построчно в JSON, например в stdout
+type WriterSink struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func NewWriterSink(w io.Writer) *WriterSink {
+	return &WriterSink{w: w}
+}
+
+func (s *WriterSink) Send(ctx context.Context, e *event.Event) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, err = s.w.Write(append(data, '\n'))
+	return err
+}
+
+// WebhookSink отправляет каждое событие POST запросом с JSON телом.
+// Успехом считается любой ответ 2xx
+type WebhookSink struct {
+	url  string
+	http *http.Client
+}
+
+func NewWebhookSink(url string, timeout time.Duration) *WebhookSink {
+	return &WebhookSink{
+		url:  url,
+		http: &http.Client{Timeout: timeout},
+	}
+}
+
+func (s *WebhookSink) Send(ctx context.Context, e *event.Event) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Event-Id", strconv.FormatInt(e.ID, 10))
+	req.Header.Set("X-Event-Type", e.Type)
+
+	resp, err := s.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 1024))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook sink: unexpected status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// MemorySink хранит события в памяти, используется в тестах
+type MemorySink struct {
+	mu     sync.Mutex
+	events []*event.Event
+	fail   func(e *event.Event) error
+}
+
+func NewMemorySink() *MemorySink {
+	return &MemorySink{}
+}
+
+// FailWith задает функцию, ошибка которой возвращается вместо доставки
+func (s *MemorySink) FailWith(fn func(e *event.Event) error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.fail = fn
+}
+
+func (s *MemorySink) Send(ctx context.Context, e *event.Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.fail != nil {
+		if err := s.fail(e); err != nil {
+			return err
+		}
+	}
+
+	s.events = append(s.events, e)
+	return nil
+}
+
+func (s *MemorySink) Events() []*event.Event {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.events)
+}
+
+// MultiSink передает событие всем получателям по очереди. Ошибка любого из них
+// приводит к повторной отправке события всем, поэтому получатели должны быть
+// идемпотентны
+type MultiSink []Sink
+
+func (s MultiSink) Send(ctx context.Context, e *event.Event) error {
+	for _, sink := range s {
+		if err := sink.Send(ctx, e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,262 @@
+package outbox
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"reviewer-service/internal/domain/event"
+	"reviewer-service/internal/domain/subscription"
+	logUtil "reviewer-service/internal/lib/logger/slog"
+	"reviewer-service/internal/lib/netguard"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Заголовки запросов к подписчикам
+const (
+	HeaderSignature = "X-Reviewer-Signature-256"
+	HeaderEvent     = "X-Reviewer-Event"
+	HeaderDelivery  = "X-Reviewer-Delivery"
+)
+
+type SubscriptionRepository interface {
+	GetMatchingSubscriptions(ctx context.Context, eventType string, teamName string) ([]*subscription.Subscription, error)
+	AddDelivery(ctx context.Context, d *subscription.Delivery) error
+	ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]*subscription.Target, error)
+	MarkDeliverySucceeded(ctx context.Context, id int64, statusCode int) error
+	MarkDeliveryFailed(ctx context.Context, id int64, statusCode int, reason string, retryIn time.Duration, dead bool) error
+}
+
+// SubscriptionSink раскладывает событие по подходящим подпискам, создавая
+// по доставке на каждую. Саму отправку выполняет Deliverer
+type SubscriptionSink struct {
+	repo SubscriptionRepository
+}
+
+func NewSubscriptionSink(repo SubscriptionRepository) *SubscriptionSink {
+	return &SubscriptionSink{repo: repo}
+}
+
+func (s *SubscriptionSink) Send(ctx context.Context, e *event.Event) error {
+	subscriptions, err := s.repo.GetMatchingSubscriptions(ctx, e.Type, e.TeamName)
+	if err != nil {
+		return err
+	}
+	if len(subscriptions) == 0 {
+		return nil
+	}
+
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	for _, sub := range subscriptions {
+		err := s.repo.AddDelivery(ctx, &subscription.Delivery{
+			SubscriptionId: sub.ID,
+			EventId:        e.ID,
+			EventType:      e.Type,
+			Payload:        payload,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type DeliveryOptions struct {
+	PollInterval   time.Duration
+	BatchSize      int
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Timeout        time.Duration
+	// Lease - на сколько взятая доставка скрыта от других экземпляров сервиса;
+	// по умолчанию BatchSize * Timeout, дольше пачка отправляться не может
+	Lease time.Duration
+	// Targets запрещает соединения с внутренними адресами; nil - запрещены
+	// все loopback, link-local и частные адреса
+	Targets *netguard.Guard
+}
+
+// Deliverer отправляет доставки подписчикам POST запросом с подписью HMAC-SHA256
+// тела в заголовке HeaderSignature. После неудачи попытка повторяется с
+// экспоненциальной задержкой, после MaxAttempts доставка переходит в статус dead
+type Deliverer struct {
+	log       *slog.Logger
+	txManager TransactionManager
+	repo      SubscriptionRepository
+	http      *http.Client
+	opts      DeliveryOptions
+
+	wg sync.WaitGroup
+}
+
+func NewDeliverer(log *slog.Logger, txManager TransactionManager, repo SubscriptionRepository, opts DeliveryOptions) *Deliverer {
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = time.Second
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = 50
+	}
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = 8
+	}
+	if opts.InitialBackoff <= 0 {
+		opts.InitialBackoff = 5 * time.Second
+	}
+	if opts.MaxBackoff <= 0 {
+		opts.MaxBackoff = time.Hour
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = 5 * time.Second
+	}
+	if opts.Lease <= 0 {
+		opts.Lease = time.Duration(opts.BatchSize) * opts.Timeout
+	}
+	if opts.Targets == nil {
+		opts.Targets, _ = netguard.New(nil)
+	}
+
+	client := &http.Client{
+		Timeout:   opts.Timeout,
+		Transport: &http.Transport{DialContext: opts.Targets.Dialer().DialContext},
+	}
+
+	return &Deliverer{
+		log:       log.With(slog.String("component", "outbox/deliverer")),
+		txManager: txManager,
+		repo:      repo,
+		http:      client,
+		opts:      opts,
+	}
+}
+
+// Start запускает отправку в отдельной горутине до отмены ctx
+func (d *Deliverer) Start(ctx context.Context) {
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
+		d.run(ctx)
+	}()
+
+	d.log.Info("subscription deliverer started", slog.String("poll_interval", d.opts.PollInterval.String()))
+}
+
+// Wait ждет остановки после отмены контекста Start
+func (d *Deliverer) Wait() {
+	d.wg.Wait()
+}
+
+func (d *Deliverer) run(ctx context.Context) {
+	ticker := time.NewTicker(d.opts.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := d.DeliverOnce(ctx); err != nil && ctx.Err() == nil {
+				d.log.Error("failed to deliver subscription events", logUtil.Err(err))
+			}
+		}
+	}
+}
+
+// DeliverOnce отправляет одну пачку наступивших доставок и возвращает число успешных.
+// Пачка забирается в короткой транзакции на время Lease, поэтому несколько
+// экземпляров сервиса не отправляют одну доставку одновременно, а запросы
+// к подписчикам выполняются вне транзакции
+func (d *Deliverer) DeliverOnce(ctx context.Context) (int, error) {
+	var targets []*subscription.Target
+	err := d.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		var err error
+		targets, err = d.repo.ClaimDueDeliveries(txCtx, d.opts.BatchSize, d.opts.Lease)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	delivered := 0
+	for _, target := range targets {
+		delivery := target.Delivery
+
+		statusCode, err := d.send(ctx, target)
+		if err == nil {
+			if err := d.repo.MarkDeliverySucceeded(ctx, delivery.ID, statusCode); err != nil {
+				return delivered, err
+			}
+			delivered++
+			continue
+		}
+
+		attempt := delivery.Attempts + 1
+		dead := attempt >= d.opts.MaxAttempts
+		retryIn := backoff(d.opts.InitialBackoff, d.opts.MaxBackoff, attempt)
+
+		log := d.log.With(
+			logUtil.Int64("delivery_id", delivery.ID),
+			logUtil.Int64("subscription_id", delivery.SubscriptionId),
+			slog.String("type", delivery.EventType),
+			slog.Int("attempt", attempt),
+			logUtil.Err(err),
+		)
+		if dead {
+			log.Error("subscription delivery moved to dead letter")
+		} else {
+			log.Warn("subscription delivery failed, will retry", slog.String("retry_in", retryIn.String()))
+		}
+
+		if err := d.repo.MarkDeliveryFailed(ctx, delivery.ID, statusCode, err.Error(), retryIn, dead); err != nil {
+			return delivered, err
+		}
+	}
+
+	return delivered, nil
+}
+
+// send возвращает код ответа подписчика или 0, если ответа не было
+func (d *Deliverer) send(ctx context.Context, target *subscription.Target) (int, error) {
+	delivery := target.Delivery
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderEvent, delivery.EventType)
+	req.Header.Set(HeaderDelivery, strconv.FormatInt(delivery.ID, 10))
+	req.Header.Set(HeaderSignature, Sign(target.Secret, delivery.Payload))
+
+	resp, err := d.http.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 1024))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("subscriber responded with status %d", resp.StatusCode)
+	}
+
+	return resp.StatusCode, nil
+}
+
+// Sign возвращает значение заголовка HeaderSignature для тела запроса
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
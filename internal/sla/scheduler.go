@@ -0,0 +1,199 @@
+package sla
+
+import (
+	"context"
+	"log/slog"
+	"reviewer-service/internal/domain/event"
+	"reviewer-service/internal/domain/pullrequest"
+	"reviewer-service/internal/domain/sla"
+	"reviewer-service/internal/lib/clock"
+	logUtil "reviewer-service/internal/lib/logger/slog"
+	"reviewer-service/internal/lib/metrics"
+	"reviewer-service/internal/lib/random"
+	"reviewer-service/internal/storage"
+	"sync"
+	"time"
+)
+
+type Repository interface {
+	GetOverdueAssignments(ctx context.Context, now time.Time, limit int) ([]*sla.Assignment, error)
+	MarkReviewSLABreached(ctx context.Context, pullRequestId string, reviewerId string, at time.Time) (bool, error)
+	AddOutboxEvent(ctx context.Context, e *event.Event) error
+}
+
+type TransactionManager interface {
+	WithTransaction(ctx context.Context, fn func(context.Context) error) error
+}
+
+type Options struct {
+	PollInterval time.Duration
+	BatchSize    int
+	// Clock по умолчанию - системные часы
+	Clock clock.Clock
+	// Random выбирает замену среди равных кандидатов, по умолчанию - случайное зерно
+	Random pullrequest.Random
+}
+
+// Scheduler периодически ищет назначения ревьюверов на открытые PR, нарушившие
+// SLA команды автора. Каждое нарушение отмечается один раз событием sla.breached,
+// а при auto_reassign ревьювер заменяется через pullrequest.ReassignReviewer
+type Scheduler struct {
+	log       *slog.Logger
+	txManager TransactionManager
+	repo      Repository
+	prRepo    pullrequest.Repository
+	syncer    pullrequest.ReviewerSyncer
+	opts      Options
+
+	wg sync.WaitGroup
+}
+
+func NewScheduler(log *slog.Logger, txManager TransactionManager, repo Repository, prRepo pullrequest.Repository, syncer pullrequest.ReviewerSyncer, opts Options) *Scheduler {
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = time.Minute
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = 100
+	}
+	if opts.Clock == nil {
+		opts.Clock = clock.Real{}
+	}
+	if opts.Random == nil {
+		opts.Random = random.New(0)
+	}
+
+	return &Scheduler{
+		log:       log.With(slog.String("component", "sla/scheduler")),
+		txManager: txManager,
+		repo:      repo,
+		prRepo:    prRepo,
+		syncer:    syncer,
+		opts:      opts,
+	}
+}
+
+// Start запускает проверку SLA в отдельной горутине до отмены ctx
+func (s *Scheduler) Start(ctx context.Context) {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.run(ctx)
+	}()
+
+	s.log.Info("sla scheduler started", slog.String("poll_interval", s.opts.PollInterval.String()))
+}
+
+// Wait ждет остановки после отмены контекста Start
+func (s *Scheduler) Wait() {
+	s.wg.Wait()
+}
+
+func (s *Scheduler) run(ctx context.Context) {
+	ticker := time.NewTicker(s.opts.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := s.CheckOnce(ctx); err != nil && ctx.Err() == nil {
+				s.log.Error("failed to check review sla", logUtil.Err(err))
+			}
+		}
+	}
+}
+
+// CheckOnce обрабатывает одну пачку просроченных назначений и возвращает число
+// отмеченных нарушений
+func (s *Scheduler) CheckOnce(ctx context.Context) (int, error) {
+	now := s.opts.Clock.Now()
+
+	assignments, err := s.repo.GetOverdueAssignments(ctx, now, s.opts.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	breached := 0
+	for _, a := range assignments {
+		// Запрос отбирает по календарному времени, выходные учитывает политика
+		if !a.Policy.Breached(a.AssignedAt, now) {
+			continue
+		}
+
+		marked, err := s.markBreached(ctx, a, now)
+		if err != nil {
+			return breached, err
+		}
+		if !marked {
+			continue
+		}
+		breached++
+
+		metrics.SLABreaches.WithLabelValues(a.Policy.TeamName).Inc()
+		s.log.Warn("review sla breached",
+			slog.String("pull_request_id", a.PullRequestId),
+			slog.String("reviewer_id", a.ReviewerId),
+			slog.String("team_name", a.Policy.TeamName),
+			slog.Time("assigned_at", a.AssignedAt))
+
+		if a.Policy.AutoReassign {
+			s.reassign(ctx, a)
+		}
+	}
+
+	return breached, nil
+}
+
+func (s *Scheduler) markBreached(ctx context.Context, a *sla.Assignment, now time.Time) (bool, error) {
+	marked := false
+
+	err := s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		ok, err := s.repo.MarkReviewSLABreached(txCtx, a.PullRequestId, a.ReviewerId, now)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return nil
+		}
+		marked = true
+
+		return event.Record(txCtx, s.repo, event.AggregatePullRequest, a.PullRequestId, a.Policy.TeamName, event.TypeSLABreached, event.SLABreachedPayload{
+			PullRequestId:   a.PullRequestId,
+			PullRequestName: a.PullRequestName,
+			AuthorId:        a.AuthorId,
+			ReviewerId:      a.ReviewerId,
+			AssignedAt:      a.AssignedAt,
+			ReviewHours:     int(a.Policy.ReviewWithin / time.Hour),
+			AutoReassign:    a.Policy.AutoReassign,
+		})
+	})
+
+	return marked, err
+}
+
+// reassign заменяет ревьювера. Нарушение уже отмечено, поэтому неудачная замена
+// не повторяется: ревьювер остается, а событие sla.breached уже разослано
+func (s *Scheduler) reassign(ctx context.Context, a *sla.Assignment) {
+	_, newReviewerId, err := pullrequest.ReassignReviewer(ctx, s.log, s.txManager, s.prRepo, s.syncer, s.opts.Random,
+		a.PullRequestId, a.ReviewerId, 0, event.AssignReasonSLABreached, false)
+	if err != nil {
+		if storageErr, ok := storage.IsError(err); ok && storageErr.Code == storage.ErrNoReplacementCandidate.Code {
+			s.log.Warn("no replacement for reviewer after sla breach",
+				slog.String("pull_request_id", a.PullRequestId),
+				slog.String("reviewer_id", a.ReviewerId))
+			return
+		}
+
+		s.log.Error("failed to reassign reviewer after sla breach",
+			slog.String("pull_request_id", a.PullRequestId),
+			slog.String("reviewer_id", a.ReviewerId),
+			logUtil.Err(err))
+		return
+	}
+
+	s.log.Info("reviewer reassigned after sla breach",
+		slog.String("pull_request_id", a.PullRequestId),
+		slog.String("old_reviewer_id", a.ReviewerId),
+		slog.String("new_reviewer_id", newReviewerId))
+}
@@ -0,0 +1,129 @@
+package postgresql
+
+import (
+	"context"
+	"reviewer-service/internal/domain/apikey"
+	storageAPIKey "reviewer-service/internal/storage/postgresql/apikey"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const apiKeyColumns = "id, name, prefix, key_hash, scopes, created_at, revoked_at"
+
+func (s *Storage) CreateAPIKey(ctx context.Context, key *apikey.Model) (int64, error) {
+	entity := storageAPIKey.ToEntity(key)
+
+	var id int64
+	var err error
+
+	tx, pool, hasTx := s.getTx(ctx)
+
+	sql := `
+		INSERT INTO api_keys 
+			(name, prefix, key_hash, scopes, created_at) 
+		VALUES 
+			($1, $2, $3, $4, NOW())
+		RETURNING id
+	`
+
+	if hasTx {
+		err = tx.QueryRow(ctx, sql, entity.Name, entity.Prefix, entity.KeyHash, entity.Scopes).Scan(&id)
+	} else {
+		err = pool.QueryRow(ctx, sql, entity.Name, entity.Prefix, entity.KeyHash, entity.Scopes).Scan(&id)
+	}
+
+	if err != nil {
+		return 0, storageAPIKey.MapPGError(err)
+	}
+
+	return id, nil
+}
+
+func (s *Storage) GetAPIKey(ctx context.Context, id int64) (*apikey.Model, error) {
+	return s.getAPIKey(ctx, "SELECT "+apiKeyColumns+" FROM api_keys WHERE id = $1", id)
+}
+
+func (s *Storage) GetAPIKeyByHash(ctx context.Context, keyHash string) (*apikey.Model, error) {
+	return s.getAPIKey(ctx, "SELECT "+apiKeyColumns+" FROM api_keys WHERE key_hash = $1", keyHash)
+}
+
+func (s *Storage) RevokeAPIKey(ctx context.Context, id int64) (*apikey.Model, error) {
+	query := `
+		UPDATE api_keys 
+		SET revoked_at = COALESCE(revoked_at, NOW())
+		WHERE id = $1
+		RETURNING ` + apiKeyColumns
+
+	return s.getAPIKey(ctx, query, id)
+}
+
+func (s *Storage) getAPIKey(ctx context.Context, query string, arg any) (*apikey.Model, error) {
+	tx, pool, hasTx := s.getTx(ctx)
+
+	var row pgx.Row
+	if hasTx {
+		row = tx.QueryRow(ctx, query, arg)
+	} else {
+		row = pool.QueryRow(ctx, query, arg)
+	}
+
+	var entity storageAPIKey.Entity
+	err := row.Scan(
+		&entity.ID,
+		&entity.Name,
+		&entity.Prefix,
+		&entity.KeyHash,
+		&entity.Scopes,
+		&entity.CreatedAt,
+		&entity.RevokedAt,
+	)
+	if err != nil {
+		return nil, storageAPIKey.MapPGError(err)
+	}
+
+	return storageAPIKey.ToDomain(&entity), nil
+}
+
+func (s *Storage) ListAPIKeys(ctx context.Context) ([]*apikey.Model, error) {
+	tx, pool, hasTx := s.getTx(ctx)
+
+	query := "SELECT " + apiKeyColumns + " FROM api_keys ORDER BY id"
+
+	var rows pgx.Rows
+	var err error
+
+	if hasTx {
+		rows, err = tx.Query(ctx, query)
+	} else {
+		rows, err = pool.Query(ctx, query)
+	}
+
+	if err != nil {
+		return nil, storageAPIKey.MapPGError(err)
+	}
+	defer rows.Close()
+
+	keys := make([]*apikey.Model, 0)
+	for rows.Next() {
+		var entity storageAPIKey.Entity
+		err := rows.Scan(
+			&entity.ID,
+			&entity.Name,
+			&entity.Prefix,
+			&entity.KeyHash,
+			&entity.Scopes,
+			&entity.CreatedAt,
+			&entity.RevokedAt,
+		)
+		if err != nil {
+			return nil, storageAPIKey.MapPGError(err)
+		}
+		keys = append(keys, storageAPIKey.ToDomain(&entity))
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, storageAPIKey.MapPGError(err)
+	}
+
+	return keys, nil
+}
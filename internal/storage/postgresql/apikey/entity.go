@@ -0,0 +1,13 @@
+package apikey
+
+import "time"
+
+type Entity struct {
+	ID        int64      `db:"id"`
+	Name      string     `db:"name"`
+	Prefix    string     `db:"prefix"`
+	KeyHash   string     `db:"key_hash"`
+	Scopes    []string   `db:"scopes"`
+	CreatedAt time.Time  `db:"created_at"`
+	RevokedAt *time.Time `db:"revoked_at"`
+}
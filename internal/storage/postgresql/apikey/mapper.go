@@ -0,0 +1,43 @@
+package apikey
+
+import (
+	"errors"
+	"reviewer-service/internal/domain/apikey"
+	"reviewer-service/internal/storage"
+
+	"github.com/jackc/pgx/v5"
+)
+
+func ToEntity(key *apikey.Model) *Entity {
+	scopes := key.Scopes
+	if scopes == nil {
+		scopes = []string{}
+	}
+
+	return &Entity{
+		ID:      key.ID,
+		Name:    key.Name,
+		Prefix:  key.Prefix,
+		KeyHash: key.KeyHash,
+		Scopes:  scopes,
+	}
+}
+
+func ToDomain(entity *Entity) *apikey.Model {
+	return &apikey.Model{
+		ID:        entity.ID,
+		Name:      entity.Name,
+		Prefix:    entity.Prefix,
+		KeyHash:   entity.KeyHash,
+		Scopes:    entity.Scopes,
+		CreatedAt: &entity.CreatedAt,
+		RevokedAt: entity.RevokedAt,
+	}
+}
+
+func MapPGError(err error) error {
+	if errors.Is(err, pgx.ErrNoRows) {
+		return storage.ErrAPIKeyNotFound
+	}
+	return err
+}
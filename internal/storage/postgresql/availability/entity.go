@@ -0,0 +1,18 @@
+package availability
+
+import "time"
+
+type Entity struct {
+	ID        int64     `db:"id"`
+	UserId    string    `db:"user_id"`
+	StartsAt  time.Time `db:"starts_at"`
+	EndsAt    time.Time `db:"ends_at"`
+	Reason    string    `db:"reason"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+type AssignmentEntity struct {
+	PullRequestId string `db:"pull_request_id"`
+	ReviewerId    string `db:"user_id"`
+	PeriodId      int64  `db:"id"`
+}
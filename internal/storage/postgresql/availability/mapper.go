@@ -0,0 +1,51 @@
+package availability
+
+import (
+	"errors"
+	"reviewer-service/internal/domain/availability"
+	"reviewer-service/internal/storage"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+func ToEntity(p *availability.Period) *Entity {
+	return &Entity{
+		ID:        p.ID,
+		UserId:    p.UserId,
+		StartsAt:  p.StartsAt.UTC(),
+		EndsAt:    p.EndsAt.UTC(),
+		Reason:    p.Reason,
+		CreatedAt: p.CreatedAt,
+	}
+}
+
+func ToDomain(entity *Entity) *availability.Period {
+	return &availability.Period{
+		ID:        entity.ID,
+		UserId:    entity.UserId,
+		StartsAt:  entity.StartsAt,
+		EndsAt:    entity.EndsAt,
+		Reason:    entity.Reason,
+		CreatedAt: entity.CreatedAt,
+	}
+}
+
+func AssignmentToDomain(entity *AssignmentEntity) *availability.Assignment {
+	return &availability.Assignment{
+		PullRequestId: entity.PullRequestId,
+		ReviewerId:    entity.ReviewerId,
+		PeriodId:      entity.PeriodId,
+	}
+}
+
+func MapPGError(err error) error {
+	if errors.Is(err, pgx.ErrNoRows) {
+		return storage.ErrUnavailabilityNotFound
+	}
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+		return storage.ErrUserNotFound
+	}
+	return err
+}
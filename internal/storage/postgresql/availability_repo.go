@@ -0,0 +1,177 @@
+package postgresql
+
+import (
+	"context"
+	"reviewer-service/internal/domain/availability"
+	storageAvailability "reviewer-service/internal/storage/postgresql/availability"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const unavailabilityColumns = "id, user_id, starts_at, ends_at, reason, created_at"
+
+func (s *Storage) CreateUnavailability(ctx context.Context, p *availability.Period) (int64, error) {
+	entity := storageAvailability.ToEntity(p)
+
+	var id int64
+	var err error
+
+	tx, pool, hasTx := s.getTx(ctx)
+
+	sql := `
+		INSERT INTO user_unavailability 
+			(user_id, starts_at, ends_at, reason) 
+		VALUES 
+			($1, $2, $3, $4)
+		RETURNING id
+	`
+
+	if hasTx {
+		err = tx.QueryRow(ctx, sql, entity.UserId, entity.StartsAt, entity.EndsAt, entity.Reason).Scan(&id)
+	} else {
+		err = pool.QueryRow(ctx, sql, entity.UserId, entity.StartsAt, entity.EndsAt, entity.Reason).Scan(&id)
+	}
+
+	if err != nil {
+		return 0, storageAvailability.MapPGError(err)
+	}
+
+	return id, nil
+}
+
+func (s *Storage) GetUnavailability(ctx context.Context, id int64) (*availability.Period, error) {
+	periods, err := s.queryUnavailability(ctx, "SELECT "+unavailabilityColumns+" FROM user_unavailability WHERE id = $1", id)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(periods) == 0 {
+		return nil, storageAvailability.MapPGError(pgx.ErrNoRows)
+	}
+
+	return periods[0], nil
+}
+
+func (s *Storage) ListUnavailability(ctx context.Context, userId string) ([]*availability.Period, error) {
+	query := "SELECT " + unavailabilityColumns + " FROM user_unavailability WHERE user_id = $1 ORDER BY starts_at, id"
+	return s.queryUnavailability(ctx, query, userId)
+}
+
+func (s *Storage) DeleteUnavailability(ctx context.Context, id int64) error {
+	tx, pool, hasTx := s.getTx(ctx)
+
+	sql := "DELETE FROM user_unavailability WHERE id = $1"
+
+	var rowsAffected int64
+	if hasTx {
+		result, err := tx.Exec(ctx, sql, id)
+		if err != nil {
+			return storageAvailability.MapPGError(err)
+		}
+		rowsAffected = result.RowsAffected()
+	} else {
+		result, err := pool.Exec(ctx, sql, id)
+		if err != nil {
+			return storageAvailability.MapPGError(err)
+		}
+		rowsAffected = result.RowsAffected()
+	}
+
+	if rowsAffected == 0 {
+		return storageAvailability.MapPGError(pgx.ErrNoRows)
+	}
+
+	return nil
+}
+
+// GetUnavailableAssignments возвращает ревью открытых PR, назначенные пользователям,
+// у которых в момент now идет период отсутствия
+func (s *Storage) GetUnavailableAssignments(ctx context.Context, now time.Time, limit int) ([]*availability.Assignment, error) {
+	tx, pool, hasTx := s.getTx(ctx)
+
+	query := `
+		SELECT DISTINCT ON (r.pull_request_id, r.user_id) 
+			r.pull_request_id, 
+			r.user_id, 
+			u.id 
+		FROM pr_reviewers r 
+		JOIN pull_requests pr ON pr.pull_request_id = r.pull_request_id 
+		JOIN user_unavailability u ON u.user_id = r.user_id 
+		WHERE pr.status = 'OPEN' 
+			AND u.starts_at <= $1 
+			AND u.ends_at > $1 
+		ORDER BY r.pull_request_id, r.user_id, u.starts_at 
+		LIMIT $2
+	`
+
+	var rows pgx.Rows
+	var err error
+
+	if hasTx {
+		rows, err = tx.Query(ctx, query, now.UTC(), limit)
+	} else {
+		rows, err = pool.Query(ctx, query, now.UTC(), limit)
+	}
+
+	if err != nil {
+		return nil, storageAvailability.MapPGError(err)
+	}
+	defer rows.Close()
+
+	assignments := make([]*availability.Assignment, 0)
+	for rows.Next() {
+		var entity storageAvailability.AssignmentEntity
+		if err := rows.Scan(&entity.PullRequestId, &entity.ReviewerId, &entity.PeriodId); err != nil {
+			return nil, storageAvailability.MapPGError(err)
+		}
+		assignments = append(assignments, storageAvailability.AssignmentToDomain(&entity))
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, storageAvailability.MapPGError(err)
+	}
+
+	return assignments, nil
+}
+
+func (s *Storage) queryUnavailability(ctx context.Context, query string, args ...any) ([]*availability.Period, error) {
+	tx, pool, hasTx := s.getTx(ctx)
+
+	var rows pgx.Rows
+	var err error
+
+	if hasTx {
+		rows, err = tx.Query(ctx, query, args...)
+	} else {
+		rows, err = pool.Query(ctx, query, args...)
+	}
+
+	if err != nil {
+		return nil, storageAvailability.MapPGError(err)
+	}
+	defer rows.Close()
+
+	periods := make([]*availability.Period, 0)
+	for rows.Next() {
+		var entity storageAvailability.Entity
+		err := rows.Scan(
+			&entity.ID,
+			&entity.UserId,
+			&entity.StartsAt,
+			&entity.EndsAt,
+			&entity.Reason,
+			&entity.CreatedAt,
+		)
+		if err != nil {
+			return nil, storageAvailability.MapPGError(err)
+		}
+		periods = append(periods, storageAvailability.ToDomain(&entity))
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, storageAvailability.MapPGError(err)
+	}
+
+	return periods, nil
+}
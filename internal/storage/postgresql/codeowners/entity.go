@@ -0,0 +1,9 @@
+package codeowners
+
+type Entity struct {
+	ID       int64    `db:"id"`
+	TeamName string   `db:"team_name"`
+	Position int      `db:"position"`
+	Pattern  string   `db:"pattern"`
+	Owners   []string `db:"owners"`
+}
@@ -0,0 +1,41 @@
+package codeowners
+
+import (
+	"errors"
+	"reviewer-service/internal/domain/codeowners"
+	"reviewer-service/internal/storage"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+func ToEntity(rule *codeowners.Rule) *Entity {
+	owners := rule.Owners
+	if owners == nil {
+		owners = []string{}
+	}
+	return &Entity{
+		ID:       rule.ID,
+		TeamName: rule.TeamName,
+		Position: rule.Position,
+		Pattern:  rule.Pattern,
+		Owners:   owners,
+	}
+}
+
+func ToDomain(entity *Entity) *codeowners.Rule {
+	return &codeowners.Rule{
+		ID:       entity.ID,
+		TeamName: entity.TeamName,
+		Position: entity.Position,
+		Pattern:  entity.Pattern,
+		Owners:   entity.Owners,
+	}
+}
+
+func MapPGError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+		return storage.ErrTeamNotFound
+	}
+	return err
+}
@@ -0,0 +1,95 @@
+package postgresql
+
+import (
+	"context"
+	"reviewer-service/internal/domain/codeowners"
+	storageCodeOwners "reviewer-service/internal/storage/postgresql/codeowners"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// ReplaceCodeOwnerRules удаляет правила команды и сохраняет новые. Атомарность
+// обеспечивает вызывающий через WithTransaction
+func (s *Storage) ReplaceCodeOwnerRules(ctx context.Context, teamName string, rules []*codeowners.Rule) error {
+	tx, pool, hasTx := s.getTx(ctx)
+
+	deleteSql := "DELETE FROM code_owner_rules WHERE team_name = $1"
+	insertSql := `
+		INSERT INTO code_owner_rules
+			(team_name, position, pattern, owners)
+		VALUES
+			($1, $2, $3, $4)
+	`
+
+	var err error
+	if hasTx {
+		_, err = tx.Exec(ctx, deleteSql, teamName)
+	} else {
+		_, err = pool.Exec(ctx, deleteSql, teamName)
+	}
+	if err != nil {
+		return storageCodeOwners.MapPGError(err)
+	}
+
+	for _, rule := range rules {
+		entity := storageCodeOwners.ToEntity(rule)
+
+		if hasTx {
+			_, err = tx.Exec(ctx, insertSql, teamName, entity.Position, entity.Pattern, entity.Owners)
+		} else {
+			_, err = pool.Exec(ctx, insertSql, teamName, entity.Position, entity.Pattern, entity.Owners)
+		}
+		if err != nil {
+			return storageCodeOwners.MapPGError(err)
+		}
+	}
+
+	return nil
+}
+
+func (s *Storage) GetCodeOwnerRules(ctx context.Context, teamName string) ([]*codeowners.Rule, error) {
+	tx, pool, hasTx := s.getTx(ctx)
+
+	query := `
+		SELECT id, team_name, position, pattern, owners
+		FROM code_owner_rules
+		WHERE team_name = $1
+		ORDER BY position
+	`
+
+	var rows pgx.Rows
+	var err error
+
+	if hasTx {
+		rows, err = tx.Query(ctx, query, teamName)
+	} else {
+		rows, err = pool.Query(ctx, query, teamName)
+	}
+
+	if err != nil {
+		return nil, storageCodeOwners.MapPGError(err)
+	}
+	defer rows.Close()
+
+	rules := make([]*codeowners.Rule, 0)
+	for rows.Next() {
+		var entity storageCodeOwners.Entity
+		err := rows.Scan(
+			&entity.ID,
+			&entity.TeamName,
+			&entity.Position,
+			&entity.Pattern,
+			&entity.Owners,
+		)
+		if err != nil {
+			return nil, storageCodeOwners.MapPGError(err)
+		}
+		rules = append(rules, storageCodeOwners.ToDomain(&entity))
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, storageCodeOwners.MapPGError(err)
+	}
+
+	return rules, nil
+}
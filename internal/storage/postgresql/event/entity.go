@@ -0,0 +1,13 @@
+package event
+
+import "time"
+
+type Entity struct {
+	ID            int64     `db:"id"`
+	AggregateType string    `db:"aggregate_type"`
+	AggregateId   string    `db:"aggregate_id"`
+	TeamName      string    `db:"team_name"`
+	EventType     string    `db:"event_type"`
+	Payload       []byte    `db:"payload"`
+	CreatedAt     time.Time `db:"created_at"`
+}
@@ -0,0 +1,27 @@
+package event
+
+import "reviewer-service/internal/domain/event"
+
+func ToEntity(e *event.Event) *Entity {
+	return &Entity{
+		ID:            e.ID,
+		AggregateType: e.AggregateType,
+		AggregateId:   e.AggregateId,
+		TeamName:      e.TeamName,
+		EventType:     e.Type,
+		Payload:       e.Payload,
+		CreatedAt:     e.CreatedAt,
+	}
+}
+
+func ToDomain(entity *Entity) *event.Event {
+	return &event.Event{
+		ID:            entity.ID,
+		AggregateType: entity.AggregateType,
+		AggregateId:   entity.AggregateId,
+		TeamName:      entity.TeamName,
+		Type:          entity.EventType,
+		Payload:       entity.Payload,
+		CreatedAt:     entity.CreatedAt,
+	}
+}
@@ -0,0 +1,9 @@
+package identity
+
+type Entity struct {
+	ID         int64   `db:"id"`
+	Provider   string  `db:"provider"`
+	Login      string  `db:"login"`
+	UserId     string  `db:"user_id"`
+	ExternalId *string `db:"external_id"`
+}
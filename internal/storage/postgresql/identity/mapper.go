@@ -0,0 +1,54 @@
+package identity
+
+import (
+	"errors"
+	"reviewer-service/internal/domain/webhook"
+	"reviewer-service/internal/storage"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+func ToEntity(identity *webhook.Identity) *Entity {
+	var externalId *string
+	if identity.ExternalId != "" {
+		externalId = &identity.ExternalId
+	}
+
+	return &Entity{
+		ID:         identity.ID,
+		Provider:   identity.Provider,
+		Login:      identity.Login,
+		UserId:     identity.UserId,
+		ExternalId: externalId,
+	}
+}
+
+func ToDomain(entity *Entity) *webhook.Identity {
+	identity := &webhook.Identity{
+		ID:       entity.ID,
+		Provider: entity.Provider,
+		Login:    entity.Login,
+		UserId:   entity.UserId,
+	}
+	if entity.ExternalId != nil {
+		identity.ExternalId = *entity.ExternalId
+	}
+	return identity
+}
+
+func MapPGError(err error) error {
+	if errors.Is(err, pgx.ErrNoRows) {
+		return storage.ErrIdentityNotFound
+	}
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case "23503":
+			return storage.ErrUserNotFound
+		case "23505":
+			return storage.ErrExternalIdAlreadyUsed
+		}
+	}
+	return err
+}
@@ -0,0 +1,294 @@
+package postgresql
+
+import (
+	"cmp"
+	"context"
+	"reviewer-service/internal/domain/event"
+	storageEvent "reviewer-service/internal/storage/postgresql/event"
+	"slices"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// outboxLockKey - ключ advisory lock, под которым работает один диспетчер outbox
+const outboxLockKey = 7_340_101
+
+func (s *Storage) AddOutboxEvent(ctx context.Context, e *event.Event) error {
+	entity := storageEvent.ToEntity(e)
+
+	tx, pool, hasTx := s.getTx(ctx)
+
+	sql := `
+		INSERT INTO outbox 
+			(aggregate_type, aggregate_id, team_name, event_type, payload) 
+		VALUES 
+			($1, $2, $3, $4, $5)
+		RETURNING id, created_at
+	`
+
+	var err error
+	if hasTx {
+		err = tx.QueryRow(ctx, sql, entity.AggregateType, entity.AggregateId, entity.TeamName, entity.EventType, entity.Payload).Scan(&e.ID, &e.CreatedAt)
+	} else {
+		err = pool.QueryRow(ctx, sql, entity.AggregateType, entity.AggregateId, entity.TeamName, entity.EventType, entity.Payload).Scan(&e.ID, &e.CreatedAt)
+	}
+
+	return err
+}
+
+// TryLockOutbox берет advisory lock до конца транзакции. Пока он удерживается,
+// другие экземпляры сервиса не забирают события на отправку, что сохраняет их порядок
+func (s *Storage) TryLockOutbox(ctx context.Context) (bool, error) {
+	tx, pool, hasTx := s.getTx(ctx)
+
+	query := "SELECT pg_try_advisory_xact_lock($1)"
+
+	var locked bool
+	var err error
+	if hasTx {
+		err = tx.QueryRow(ctx, query, outboxLockKey).Scan(&locked)
+	} else {
+		err = pool.QueryRow(ctx, query, outboxLockKey).Scan(&locked)
+	}
+
+	return locked, err
+}
+
+func (s *Storage) GetUnsentEvents(ctx context.Context, limit int) ([]*event.Event, error) {
+	tx, pool, hasTx := s.getTx(ctx)
+
+	query := `
+		SELECT id, aggregate_type, aggregate_id, team_name, event_type, payload, created_at 
+		FROM outbox 
+		WHERE sent_at IS NULL
+		ORDER BY id
+		LIMIT $1
+	`
+
+	var rows pgx.Rows
+	var err error
+
+	if hasTx {
+		rows, err = tx.Query(ctx, query, limit)
+	} else {
+		rows, err = pool.Query(ctx, query, limit)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := make([]*event.Event, 0)
+	for rows.Next() {
+		var entity storageEvent.Entity
+		err := rows.Scan(
+			&entity.ID,
+			&entity.AggregateType,
+			&entity.AggregateId,
+			&entity.TeamName,
+			&entity.EventType,
+			&entity.Payload,
+			&entity.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, storageEvent.ToDomain(&entity))
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// ClaimEvents забирает до limit событий на отправку на время lease и возвращает
+// их в порядке ID. Событие не выдается, пока более раннее событие его агрегата
+// ждет повторной попытки или отправляется другим диспетчером. Вызывается под
+// TryLockOutbox, поэтому пачки разных экземпляров сервиса не пересекаются
+func (s *Storage) ClaimEvents(ctx context.Context, limit int, lease time.Duration) ([]*event.Claimed, error) {
+	tx, pool, hasTx := s.getTx(ctx)
+
+	query := `
+		WITH claimable AS (
+			SELECT o.id 
+			FROM outbox o
+			WHERE o.sent_at IS NULL AND o.failed_at IS NULL
+				AND o.next_attempt_at <= NOW()
+				AND (o.claimed_until IS NULL OR o.claimed_until < NOW())
+				AND NOT EXISTS (
+					SELECT 1 
+					FROM outbox p
+					WHERE p.aggregate_type = o.aggregate_type AND p.aggregate_id = o.aggregate_id
+						AND p.id < o.id AND p.sent_at IS NULL AND p.failed_at IS NULL
+						AND (p.next_attempt_at > NOW() OR p.claimed_until >= NOW())
+				)
+			ORDER BY o.id
+			LIMIT $1
+		)
+		UPDATE outbox 
+		SET claimed_until = NOW() + make_interval(secs => $2)
+		FROM claimable
+		WHERE outbox.id = claimable.id
+		RETURNING outbox.id, outbox.aggregate_type, outbox.aggregate_id, outbox.team_name, 
+			outbox.event_type, outbox.payload, outbox.created_at, outbox.attempts
+	`
+
+	var rows pgx.Rows
+	var err error
+
+	if hasTx {
+		rows, err = tx.Query(ctx, query, limit, lease.Seconds())
+	} else {
+		rows, err = pool.Query(ctx, query, limit, lease.Seconds())
+	}
+
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	claims := make([]*event.Claimed, 0)
+	for rows.Next() {
+		var entity storageEvent.Entity
+		var attempts int
+		err := rows.Scan(
+			&entity.ID,
+			&entity.AggregateType,
+			&entity.AggregateId,
+			&entity.TeamName,
+			&entity.EventType,
+			&entity.Payload,
+			&entity.CreatedAt,
+			&attempts,
+		)
+		if err != nil {
+			return nil, err
+		}
+		claims = append(claims, &event.Claimed{Event: storageEvent.ToDomain(&entity), Attempts: attempts})
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	slices.SortFunc(claims, func(a, b *event.Claimed) int {
+		return cmp.Compare(a.Event.ID, b.Event.ID)
+	})
+
+	return claims, nil
+}
+
+func (s *Storage) MarkEventSent(ctx context.Context, id int64) error {
+	tx, pool, hasTx := s.getTx(ctx)
+
+	sql := `
+		UPDATE outbox 
+		SET sent_at = NOW(), attempts = attempts + 1, last_error = NULL, claimed_until = NULL
+		WHERE id = $1
+	`
+
+	var err error
+	if hasTx {
+		_, err = tx.Exec(ctx, sql, id)
+	} else {
+		_, err = pool.Exec(ctx, sql, id)
+	}
+
+	return err
+}
+
+// MarkEventFailed записывает неудачную попытку и откладывает следующую на retryIn.
+// При dead событие помечается failed_at и больше не отправляется
+func (s *Storage) MarkEventFailed(ctx context.Context, id int64, reason string, retryIn time.Duration, dead bool) error {
+	tx, pool, hasTx := s.getTx(ctx)
+
+	sql := `
+		UPDATE outbox 
+		SET attempts = attempts + 1, last_error = $2, claimed_until = NULL,
+			next_attempt_at = NOW() + make_interval(secs => $3),
+			failed_at = CASE WHEN $4 THEN NOW() END
+		WHERE id = $1
+	`
+
+	var err error
+	if hasTx {
+		_, err = tx.Exec(ctx, sql, id, reason, retryIn.Seconds(), dead)
+	} else {
+		_, err = pool.Exec(ctx, sql, id, reason, retryIn.Seconds(), dead)
+	}
+
+	return err
+}
+
+// ReleaseEvent возвращает взятое событие без попытки отправки
+func (s *Storage) ReleaseEvent(ctx context.Context, id int64) error {
+	tx, pool, hasTx := s.getTx(ctx)
+
+	sql := "UPDATE outbox SET claimed_until = NULL WHERE id = $1"
+
+	var err error
+	if hasTx {
+		_, err = tx.Exec(ctx, sql, id)
+	} else {
+		_, err = pool.Exec(ctx, sql, id)
+	}
+
+	return err
+}
+
+// GetEventsAfter возвращает события журнала с ID больше afterId указанных типов,
+// для непустого teamName - только этой команды. Используется для возобновления
+// потока событий по Last-Event-ID
+func (s *Storage) GetEventsAfter(ctx context.Context, afterId int64, types []string, teamName string, limit int) ([]*event.Event, error) {
+	tx, pool, hasTx := s.getTx(ctx)
+
+	query := `
+		SELECT id, aggregate_type, aggregate_id, team_name, event_type, payload, created_at 
+		FROM outbox 
+		WHERE id > $1 AND event_type = ANY($2) AND ($3 = '' OR team_name = $3)
+		ORDER BY id
+		LIMIT $4
+	`
+
+	var rows pgx.Rows
+	var err error
+
+	if hasTx {
+		rows, err = tx.Query(ctx, query, afterId, types, teamName, limit)
+	} else {
+		rows, err = pool.Query(ctx, query, afterId, types, teamName, limit)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := make([]*event.Event, 0)
+	for rows.Next() {
+		var entity storageEvent.Entity
+		err := rows.Scan(
+			&entity.ID,
+			&entity.AggregateType,
+			&entity.AggregateId,
+			&entity.TeamName,
+			&entity.EventType,
+			&entity.Payload,
+			&entity.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, storageEvent.ToDomain(&entity))
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
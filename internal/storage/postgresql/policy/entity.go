@@ -0,0 +1,19 @@
+package policy
+
+type CompositionEntity struct {
+	TeamName     string `db:"team_name"`
+	MinSeniority string `db:"min_seniority"`
+	MinCount     int    `db:"min_count"`
+}
+
+type ExcludedPairEntity struct {
+	TeamName    string `db:"team_name"`
+	UserId      string `db:"user_id"`
+	OtherUserId string `db:"other_user_id"`
+}
+
+type RepeatLimitEntity struct {
+	TeamName   string `db:"team_name"`
+	MaxReviews int    `db:"max_reviews"`
+	WindowDays int    `db:"window_days"`
+}
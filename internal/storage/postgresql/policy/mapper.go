@@ -0,0 +1,54 @@
+package policy
+
+import (
+	"errors"
+	"reviewer-service/internal/domain/policy"
+	"reviewer-service/internal/storage"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+func ToCompositionEntity(teamName string, constraint policy.Constraint) *CompositionEntity {
+	return &CompositionEntity{
+		TeamName:     teamName,
+		MinSeniority: constraint.MinSeniority,
+		MinCount:     constraint.MinCount,
+	}
+}
+
+func ToConstraint(entity *CompositionEntity) policy.Constraint {
+	return policy.Constraint{
+		MinSeniority: entity.MinSeniority,
+		MinCount:     entity.MinCount,
+	}
+}
+
+func ToExcludedPairEntity(teamName string, pair policy.Pair) *ExcludedPairEntity {
+	return &ExcludedPairEntity{
+		TeamName:    teamName,
+		UserId:      pair.UserId,
+		OtherUserId: pair.OtherUserId,
+	}
+}
+
+func ToPair(entity *ExcludedPairEntity) policy.Pair {
+	return policy.Pair{
+		UserId:      entity.UserId,
+		OtherUserId: entity.OtherUserId,
+	}
+}
+
+func ToRepeatLimit(entity *RepeatLimitEntity) policy.RepeatLimit {
+	return policy.RepeatLimit{
+		MaxReviews: entity.MaxReviews,
+		WindowDays: entity.WindowDays,
+	}
+}
+
+func MapPGError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+		return storage.ErrTeamNotFound
+	}
+	return err
+}
@@ -0,0 +1,241 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"reviewer-service/internal/domain/policy"
+	storagePolicy "reviewer-service/internal/storage/postgresql/policy"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// ReplaceCompositionRules удаляет требования к составу ревьюверов команды и
+// сохраняет новые. Атомарность обеспечивает вызывающий через WithTransaction
+func (s *Storage) ReplaceCompositionRules(ctx context.Context, teamName string, composition policy.Composition) error {
+	tx, pool, hasTx := s.getTx(ctx)
+
+	deleteSql := "DELETE FROM team_composition_rules WHERE team_name = $1"
+	insertSql := `
+		INSERT INTO team_composition_rules
+			(team_name, min_seniority, min_count)
+		VALUES
+			($1, $2, $3)
+	`
+
+	var err error
+	if hasTx {
+		_, err = tx.Exec(ctx, deleteSql, teamName)
+	} else {
+		_, err = pool.Exec(ctx, deleteSql, teamName)
+	}
+	if err != nil {
+		return storagePolicy.MapPGError(err)
+	}
+
+	for _, constraint := range composition {
+		entity := storagePolicy.ToCompositionEntity(teamName, constraint)
+
+		if hasTx {
+			_, err = tx.Exec(ctx, insertSql, entity.TeamName, entity.MinSeniority, entity.MinCount)
+		} else {
+			_, err = pool.Exec(ctx, insertSql, entity.TeamName, entity.MinSeniority, entity.MinCount)
+		}
+		if err != nil {
+			return storagePolicy.MapPGError(err)
+		}
+	}
+
+	return nil
+}
+
+// GetCompositionRules возвращает требования команды от старшего уровня к младшему
+func (s *Storage) GetCompositionRules(ctx context.Context, teamName string) (policy.Composition, error) {
+	tx, pool, hasTx := s.getTx(ctx)
+
+	query := `
+		SELECT team_name, min_seniority, min_count
+		FROM team_composition_rules
+		WHERE team_name = $1
+	`
+
+	var rows pgx.Rows
+	var err error
+
+	if hasTx {
+		rows, err = tx.Query(ctx, query, teamName)
+	} else {
+		rows, err = pool.Query(ctx, query, teamName)
+	}
+
+	if err != nil {
+		return nil, storagePolicy.MapPGError(err)
+	}
+	defer rows.Close()
+
+	composition := make(policy.Composition, 0)
+	for rows.Next() {
+		var entity storagePolicy.CompositionEntity
+		if err := rows.Scan(&entity.TeamName, &entity.MinSeniority, &entity.MinCount); err != nil {
+			return nil, storagePolicy.MapPGError(err)
+		}
+		composition = append(composition, storagePolicy.ToConstraint(&entity))
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, storagePolicy.MapPGError(err)
+	}
+
+	return composition.Sorted(), nil
+}
+
+// ReplaceExcludedPairs удаляет исключенные пары команды и сохраняет новые.
+// Пары должны быть нормализованы (policy.Pair.Normalized)
+func (s *Storage) ReplaceExcludedPairs(ctx context.Context, teamName string, pairs []policy.Pair) error {
+	tx, pool, hasTx := s.getTx(ctx)
+
+	deleteSql := "DELETE FROM reviewer_exclusions WHERE team_name = $1"
+	insertSql := `
+		INSERT INTO reviewer_exclusions
+			(team_name, user_id, other_user_id)
+		VALUES
+			($1, $2, $3)
+	`
+
+	var err error
+	if hasTx {
+		_, err = tx.Exec(ctx, deleteSql, teamName)
+	} else {
+		_, err = pool.Exec(ctx, deleteSql, teamName)
+	}
+	if err != nil {
+		return storagePolicy.MapPGError(err)
+	}
+
+	for _, pair := range pairs {
+		entity := storagePolicy.ToExcludedPairEntity(teamName, pair)
+
+		if hasTx {
+			_, err = tx.Exec(ctx, insertSql, entity.TeamName, entity.UserId, entity.OtherUserId)
+		} else {
+			_, err = pool.Exec(ctx, insertSql, entity.TeamName, entity.UserId, entity.OtherUserId)
+		}
+		if err != nil {
+			return storagePolicy.MapPGError(err)
+		}
+	}
+
+	return nil
+}
+
+func (s *Storage) GetExcludedPairs(ctx context.Context, teamName string) ([]policy.Pair, error) {
+	tx, pool, hasTx := s.getTx(ctx)
+
+	query := `
+		SELECT team_name, user_id, other_user_id
+		FROM reviewer_exclusions
+		WHERE team_name = $1
+		ORDER BY user_id, other_user_id
+	`
+
+	var rows pgx.Rows
+	var err error
+
+	if hasTx {
+		rows, err = tx.Query(ctx, query, teamName)
+	} else {
+		rows, err = pool.Query(ctx, query, teamName)
+	}
+
+	if err != nil {
+		return nil, storagePolicy.MapPGError(err)
+	}
+	defer rows.Close()
+
+	pairs := make([]policy.Pair, 0)
+	for rows.Next() {
+		var entity storagePolicy.ExcludedPairEntity
+		if err := rows.Scan(&entity.TeamName, &entity.UserId, &entity.OtherUserId); err != nil {
+			return nil, storagePolicy.MapPGError(err)
+		}
+		pairs = append(pairs, storagePolicy.ToPair(&entity))
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, storagePolicy.MapPGError(err)
+	}
+
+	return pairs, nil
+}
+
+// SetRepeatLimit создает или заменяет лимит повторных ревью команды
+func (s *Storage) SetRepeatLimit(ctx context.Context, teamName string, limit policy.RepeatLimit) error {
+	tx, pool, hasTx := s.getTx(ctx)
+
+	sql := `
+		INSERT INTO team_repeat_limits
+			(team_name, max_reviews, window_days)
+		VALUES
+			($1, $2, $3)
+		ON CONFLICT (team_name) DO UPDATE SET
+			max_reviews = EXCLUDED.max_reviews,
+			window_days = EXCLUDED.window_days
+	`
+
+	var err error
+	if hasTx {
+		_, err = tx.Exec(ctx, sql, teamName, limit.MaxReviews, limit.WindowDays)
+	} else {
+		_, err = pool.Exec(ctx, sql, teamName, limit.MaxReviews, limit.WindowDays)
+	}
+
+	if err != nil {
+		return storagePolicy.MapPGError(err)
+	}
+
+	return nil
+}
+
+// DeleteRepeatLimit снимает лимит повторных ревью; отсутствие лимита ошибкой не считается
+func (s *Storage) DeleteRepeatLimit(ctx context.Context, teamName string) error {
+	tx, pool, hasTx := s.getTx(ctx)
+
+	sql := "DELETE FROM team_repeat_limits WHERE team_name = $1"
+
+	var err error
+	if hasTx {
+		_, err = tx.Exec(ctx, sql, teamName)
+	} else {
+		_, err = pool.Exec(ctx, sql, teamName)
+	}
+
+	if err != nil {
+		return storagePolicy.MapPGError(err)
+	}
+
+	return nil
+}
+
+// GetRepeatLimit возвращает лимит повторных ревью команды, без лимита - нулевое значение
+func (s *Storage) GetRepeatLimit(ctx context.Context, teamName string) (policy.RepeatLimit, error) {
+	tx, pool, hasTx := s.getTx(ctx)
+
+	sql := "SELECT team_name, max_reviews, window_days FROM team_repeat_limits WHERE team_name = $1"
+
+	var row pgx.Row
+	if hasTx {
+		row = tx.QueryRow(ctx, sql, teamName)
+	} else {
+		row = pool.QueryRow(ctx, sql, teamName)
+	}
+
+	var entity storagePolicy.RepeatLimitEntity
+	err := row.Scan(&entity.TeamName, &entity.MaxReviews, &entity.WindowDays)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return policy.RepeatLimit{}, nil
+	}
+	if err != nil {
+		return policy.RepeatLimit{}, storagePolicy.MapPGError(err)
+	}
+
+	return storagePolicy.ToRepeatLimit(&entity), nil
+}
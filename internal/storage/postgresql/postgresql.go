@@ -5,7 +5,18 @@ import (
 	"fmt"
 	"net/url"
 	"reviewer-service/internal/config"
+	"reviewer-service/internal/domain/apikey"
+	"reviewer-service/internal/domain/auth"
+	"reviewer-service/internal/domain/availability"
+	"reviewer-service/internal/domain/codeowners"
+	"reviewer-service/internal/domain/policy"
+	"reviewer-service/internal/domain/pullrequest"
+	"reviewer-service/internal/domain/repository"
+	"reviewer-service/internal/domain/sla"
+	"reviewer-service/internal/domain/subscription"
 	"reviewer-service/internal/domain/team"
+	"reviewer-service/internal/domain/user"
+	"reviewer-service/internal/domain/webhook"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -44,7 +55,13 @@ func (s *Storage) Close() {
 }
 
 // WithTransaction реализует интерфейс TransactionManager из domain слоя
+// Если в контексте уже есть транзакция, fn выполняется в ней, чтобы доменные
+// операции можно было объединять в одну транзакцию
 func (s *Storage) WithTransaction(ctx context.Context, fn func(context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return fn(ctx)
+	}
+
 	tx, err := s.Db.BeginTx(ctx, pgx.TxOptions{})
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
@@ -82,5 +99,18 @@ func (s *Storage) getTx(ctx context.Context) (pgx.Tx, *pgxpool.Pool, bool) {
 
 // EnsureStorageImplementsInterfaces проверяет, что Storage реализует необходимые интерфейсы
 var (
-	_ team.TransactionManager = (*Storage)(nil)
+	_ team.TransactionManager    = (*Storage)(nil)
+	_ team.Repository            = (*Storage)(nil)
+	_ user.Repository            = (*Storage)(nil)
+	_ pullrequest.Repository     = (*Storage)(nil)
+	_ apikey.Repository          = (*Storage)(nil)
+	_ auth.RoleAdminRepository   = (*Storage)(nil)
+	_ webhook.Repository         = (*Storage)(nil)
+	_ webhook.IdentityRepository = (*Storage)(nil)
+	_ subscription.Repository    = (*Storage)(nil)
+	_ sla.Repository             = (*Storage)(nil)
+	_ availability.Repository    = (*Storage)(nil)
+	_ codeowners.Repository      = (*Storage)(nil)
+	_ repository.Repository      = (*Storage)(nil)
+	_ policy.Repository          = (*Storage)(nil)
 )
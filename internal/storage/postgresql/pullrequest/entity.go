@@ -10,5 +10,16 @@ type Entity struct {
 	Status          string     `db:"status"`
 	CreatedAt       time.Time  `db:"created_at"`
 	MergedAt        *time.Time `db:"merged_at"`
+	Version         int64      `db:"version"`
+	RepositoryId    *int64     `db:"repository_id"`
+	Number          *int64     `db:"number"`
 }
 
+// CandidateEntity - строка выборки кандидатов в ревьюверы
+type CandidateEntity struct {
+	UserId             string     `db:"user_id"`
+	Seniority          string     `db:"seniority"`
+	OpenReviews        int        `db:"open_reviews"`
+	RepeatLimitReached bool       `db:"repeat_limit_reached"`
+	LastReviewedAt     *time.Time `db:"last_reviewed_at"`
+}
@@ -16,6 +16,12 @@ func ToEntity(pr *pullrequest.Model) *Entity {
 		createdAt = *pr.CreatedAt
 	}
 
+	var repositoryId, number *int64
+	if pr.RepositoryId != 0 {
+		repositoryId = &pr.RepositoryId
+		number = &pr.Number
+	}
+
 	return &Entity{
 		ID:              pr.ID,
 		PullRequestId:   pr.PullRequestId,
@@ -24,10 +30,21 @@ func ToEntity(pr *pullrequest.Model) *Entity {
 		Status:          pr.Status,
 		CreatedAt:       createdAt,
 		MergedAt:        pr.MergedAt,
+		Version:         pr.Version,
+		RepositoryId:    repositoryId,
+		Number:          number,
 	}
 }
 
 func ToDomain(entity *Entity, reviewers []string) *pullrequest.Model {
+	var repositoryId, number int64
+	if entity.RepositoryId != nil {
+		repositoryId = *entity.RepositoryId
+	}
+	if entity.Number != nil {
+		number = *entity.Number
+	}
+
 	return &pullrequest.Model{
 		ID:                entity.ID,
 		PullRequestId:     entity.PullRequestId,
@@ -37,6 +54,19 @@ func ToDomain(entity *Entity, reviewers []string) *pullrequest.Model {
 		AssignedReviewers: reviewers,
 		CreatedAt:         &entity.CreatedAt,
 		MergedAt:          entity.MergedAt,
+		Version:           entity.Version,
+		RepositoryId:      repositoryId,
+		Number:            number,
+	}
+}
+
+func ToCandidate(entity *CandidateEntity) *pullrequest.Candidate {
+	return &pullrequest.Candidate{
+		UserId:             entity.UserId,
+		Seniority:          entity.Seniority,
+		OpenReviews:        entity.OpenReviews,
+		RepeatLimitReached: entity.RepeatLimitReached,
+		LastReviewedAt:     entity.LastReviewedAt,
 	}
 }
 
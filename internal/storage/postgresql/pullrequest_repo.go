@@ -18,9 +18,9 @@ func (s *Storage) CreatePullRequest(ctx context.Context, pr *pullrequest.Model)
 
 	sql := `
 		INSERT INTO pull_requests 
-			(pull_request_id, pull_request_name, author_id, status, created_at) 
+			(pull_request_id, pull_request_name, author_id, status, created_at, repository_id, number) 
 		VALUES 
-			($1, $2, $3, $4, NOW())
+			($1, $2, $3, $4, NOW(), $5, $6)
 		RETURNING id
 	`
 
@@ -32,6 +32,8 @@ func (s *Storage) CreatePullRequest(ctx context.Context, pr *pullrequest.Model)
 			entity.PullRequestName,
 			entity.AuthorId,
 			entity.Status,
+			entity.RepositoryId,
+			entity.Number,
 		).Scan(&id)
 	} else {
 		err = pool.QueryRow(
@@ -41,6 +43,8 @@ func (s *Storage) CreatePullRequest(ctx context.Context, pr *pullrequest.Model)
 			entity.PullRequestName,
 			entity.AuthorId,
 			entity.Status,
+			entity.RepositoryId,
+			entity.Number,
 		).Scan(&id)
 	}
 
@@ -52,6 +56,16 @@ func (s *Storage) CreatePullRequest(ctx context.Context, pr *pullrequest.Model)
 }
 
 func (s *Storage) GetPullRequestById(ctx context.Context, pullRequestId string) (*pullrequest.Model, error) {
+	return s.getPullRequest(ctx, pullRequestId, false)
+}
+
+// GetPullRequestByIdForUpdate блокирует строку PR до конца транзакции,
+// чтобы конкурентные изменения одного PR выполнялись последовательно
+func (s *Storage) GetPullRequestByIdForUpdate(ctx context.Context, pullRequestId string) (*pullrequest.Model, error) {
+	return s.getPullRequest(ctx, pullRequestId, true)
+}
+
+func (s *Storage) getPullRequest(ctx context.Context, pullRequestId string, forUpdate bool) (*pullrequest.Model, error) {
 	tx, pool, hasTx := s.getTx(ctx)
 
 	query := `
@@ -62,10 +76,16 @@ func (s *Storage) GetPullRequestById(ctx context.Context, pullRequestId string)
 			pr.author_id,
 			pr.status,
 			pr.created_at,
-			pr.merged_at
+			pr.merged_at,
+			pr.version,
+			pr.repository_id,
+			pr.number
 		FROM pull_requests pr
 		WHERE pr.pull_request_id = $1
 	`
+	if forUpdate {
+		query += " FOR UPDATE"
+	}
 
 	var entity storagePR.Entity
 	var err error
@@ -79,6 +99,9 @@ func (s *Storage) GetPullRequestById(ctx context.Context, pullRequestId string)
 			&entity.Status,
 			&entity.CreatedAt,
 			&entity.MergedAt,
+			&entity.Version,
+			&entity.RepositoryId,
+			&entity.Number,
 		)
 	} else {
 		err = pool.QueryRow(ctx, query, pullRequestId).Scan(
@@ -89,6 +112,9 @@ func (s *Storage) GetPullRequestById(ctx context.Context, pullRequestId string)
 			&entity.Status,
 			&entity.CreatedAt,
 			&entity.MergedAt,
+			&entity.Version,
+			&entity.RepositoryId,
+			&entity.Number,
 		)
 	}
 
@@ -127,6 +153,28 @@ func (s *Storage) GetPullRequestById(ctx context.Context, pullRequestId string)
 	return storagePR.ToDomain(&entity, reviewers), nil
 }
 
+// GetPullRequestIdByNumber возвращает строковый идентификатор PR number репозитория
+func (s *Storage) GetPullRequestIdByNumber(ctx context.Context, repositoryId int64, number int64) (string, error) {
+	tx, pool, hasTx := s.getTx(ctx)
+
+	query := "SELECT pull_request_id FROM pull_requests WHERE repository_id = $1 AND number = $2"
+
+	var pullRequestId string
+	var err error
+
+	if hasTx {
+		err = tx.QueryRow(ctx, query, repositoryId, number).Scan(&pullRequestId)
+	} else {
+		err = pool.QueryRow(ctx, query, repositoryId, number).Scan(&pullRequestId)
+	}
+
+	if err != nil {
+		return "", storagePR.MapPGError(err)
+	}
+
+	return pullRequestId, nil
+}
+
 func (s *Storage) AssignReviewer(ctx context.Context, pullRequestId string, reviewerId string) error {
 	tx, pool, hasTx := s.getTx(ctx)
 
@@ -150,7 +198,8 @@ func (s *Storage) AssignReviewer(ctx context.Context, pullRequestId string, revi
 	return nil
 }
 
-func (s *Storage) GetPullRequestsByReviewer(ctx context.Context, reviewerId string) ([]*pullrequest.Model, error) {
+// GetPullRequestsByReviewer возвращает PR ревьювера, repositoryId = 0 - из всех репозиториев
+func (s *Storage) GetPullRequestsByReviewer(ctx context.Context, reviewerId string, repositoryId int64) ([]*pullrequest.Model, error) {
 	tx, pool, hasTx := s.getTx(ctx)
 
 	query := `
@@ -161,10 +210,13 @@ func (s *Storage) GetPullRequestsByReviewer(ctx context.Context, reviewerId stri
 			pr.author_id,
 			pr.status,
 			pr.created_at,
-			pr.merged_at
+			pr.merged_at,
+			pr.version,
+			pr.repository_id,
+			pr.number
 		FROM pull_requests pr
 		INNER JOIN pr_reviewers prr ON pr.pull_request_id = prr.pull_request_id
-		WHERE prr.user_id = $1
+		WHERE prr.user_id = $1 AND ($2::bigint = 0 OR pr.repository_id = $2)
 		ORDER BY pr.created_at DESC
 	`
 
@@ -172,9 +224,9 @@ func (s *Storage) GetPullRequestsByReviewer(ctx context.Context, reviewerId stri
 	var err error
 
 	if hasTx {
-		rows, err = tx.Query(ctx, query, reviewerId)
+		rows, err = tx.Query(ctx, query, reviewerId, repositoryId)
 	} else {
-		rows, err = pool.Query(ctx, query, reviewerId)
+		rows, err = pool.Query(ctx, query, reviewerId, repositoryId)
 	}
 
 	if err != nil {
@@ -193,6 +245,9 @@ func (s *Storage) GetPullRequestsByReviewer(ctx context.Context, reviewerId stri
 			&entity.Status,
 			&entity.CreatedAt,
 			&entity.MergedAt,
+			&entity.Version,
+			&entity.RepositoryId,
+			&entity.Number,
 		)
 		if err != nil {
 			return nil, storagePR.MapPGError(err)
@@ -242,9 +297,9 @@ func (s *Storage) MergePullRequest(ctx context.Context, pullRequestId string) (*
 
 	query := `
 		UPDATE pull_requests 
-		SET status = 'MERGED', merged_at = NOW()
+		SET status = 'MERGED', merged_at = NOW(), version = version + 1
 		WHERE pull_request_id = $1 AND status != 'MERGED'
-		RETURNING id, pull_request_id, pull_request_name, author_id, status, created_at, merged_at
+		RETURNING id, pull_request_id, pull_request_name, author_id, status, created_at, merged_at, version, repository_id, number
 	`
 
 	var entity storagePR.Entity
@@ -259,6 +314,9 @@ func (s *Storage) MergePullRequest(ctx context.Context, pullRequestId string) (*
 			&entity.Status,
 			&entity.CreatedAt,
 			&entity.MergedAt,
+			&entity.Version,
+			&entity.RepositoryId,
+			&entity.Number,
 		)
 	} else {
 		err = pool.QueryRow(ctx, query, pullRequestId).Scan(
@@ -269,6 +327,9 @@ func (s *Storage) MergePullRequest(ctx context.Context, pullRequestId string) (*
 			&entity.Status,
 			&entity.CreatedAt,
 			&entity.MergedAt,
+			&entity.Version,
+			&entity.RepositoryId,
+			&entity.Number,
 		)
 	}
 
@@ -310,6 +371,98 @@ func (s *Storage) MergePullRequest(ctx context.Context, pullRequestId string) (*
 	return storagePR.ToDomain(&entity, reviewers), nil
 }
 
+func (s *Storage) ClosePullRequest(ctx context.Context, pullRequestId string) (*pullrequest.Model, error) {
+	tx, pool, hasTx := s.getTx(ctx)
+
+	sql := `
+		UPDATE pull_requests 
+		SET status = 'CLOSED', version = version + 1
+		WHERE pull_request_id = $1 AND status IN ('OPEN', 'DRAFT')
+	`
+
+	var err error
+	if hasTx {
+		_, err = tx.Exec(ctx, sql, pullRequestId)
+	} else {
+		_, err = pool.Exec(ctx, sql, pullRequestId)
+	}
+
+	if err != nil {
+		return nil, storagePR.MapPGError(err)
+	}
+
+	return s.GetPullRequestById(ctx, pullRequestId)
+}
+
+func (s *Storage) ReopenPullRequest(ctx context.Context, pullRequestId string) (*pullrequest.Model, error) {
+	tx, pool, hasTx := s.getTx(ctx)
+
+	sql := `
+		UPDATE pull_requests 
+		SET status = 'OPEN', version = version + 1
+		WHERE pull_request_id = $1 AND status = 'CLOSED'
+	`
+
+	var err error
+	if hasTx {
+		_, err = tx.Exec(ctx, sql, pullRequestId)
+	} else {
+		_, err = pool.Exec(ctx, sql, pullRequestId)
+	}
+
+	if err != nil {
+		return nil, storagePR.MapPGError(err)
+	}
+
+	return s.GetPullRequestById(ctx, pullRequestId)
+}
+
+func (s *Storage) DraftPullRequest(ctx context.Context, pullRequestId string) (*pullrequest.Model, error) {
+	tx, pool, hasTx := s.getTx(ctx)
+
+	sql := `
+		UPDATE pull_requests 
+		SET status = 'DRAFT', version = version + 1
+		WHERE pull_request_id = $1 AND status = 'OPEN'
+	`
+
+	var err error
+	if hasTx {
+		_, err = tx.Exec(ctx, sql, pullRequestId)
+	} else {
+		_, err = pool.Exec(ctx, sql, pullRequestId)
+	}
+
+	if err != nil {
+		return nil, storagePR.MapPGError(err)
+	}
+
+	return s.GetPullRequestById(ctx, pullRequestId)
+}
+
+func (s *Storage) ReadyPullRequest(ctx context.Context, pullRequestId string) (*pullrequest.Model, error) {
+	tx, pool, hasTx := s.getTx(ctx)
+
+	sql := `
+		UPDATE pull_requests 
+		SET status = 'OPEN', version = version + 1
+		WHERE pull_request_id = $1 AND status = 'DRAFT'
+	`
+
+	var err error
+	if hasTx {
+		_, err = tx.Exec(ctx, sql, pullRequestId)
+	} else {
+		_, err = pool.Exec(ctx, sql, pullRequestId)
+	}
+
+	if err != nil {
+		return nil, storagePR.MapPGError(err)
+	}
+
+	return s.GetPullRequestById(ctx, pullRequestId)
+}
+
 func (s *Storage) RemoveReviewer(ctx context.Context, pullRequestId string, reviewerId string) error {
 	tx, pool, hasTx := s.getTx(ctx)
 
@@ -332,3 +485,25 @@ func (s *Storage) RemoveReviewer(ctx context.Context, pullRequestId string, revi
 	return nil
 }
 
+func (s *Storage) IncrementPullRequestVersion(ctx context.Context, pullRequestId string) error {
+	tx, pool, hasTx := s.getTx(ctx)
+
+	sql := `
+		UPDATE pull_requests 
+		SET version = version + 1
+		WHERE pull_request_id = $1
+	`
+
+	var err error
+	if hasTx {
+		_, err = tx.Exec(ctx, sql, pullRequestId)
+	} else {
+		_, err = pool.Exec(ctx, sql, pullRequestId)
+	}
+
+	if err != nil {
+		return storagePR.MapPGError(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,12 @@
+package repository
+
+import "time"
+
+type Entity struct {
+	ID             int64     `db:"id"`
+	Host           string    `db:"host"`
+	Name           string    `db:"name"`
+	TeamName       string    `db:"team_name"`
+	ReviewersCount *int      `db:"reviewers_count"`
+	CreatedAt      time.Time `db:"created_at"`
+}
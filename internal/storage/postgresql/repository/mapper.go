@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"errors"
+	"reviewer-service/internal/domain/repository"
+	"reviewer-service/internal/storage"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+func ToEntity(m *repository.Model) *Entity {
+	var reviewersCount *int
+	if m.ReviewersCount > 0 {
+		reviewersCount = &m.ReviewersCount
+	}
+
+	return &Entity{
+		ID:             m.ID,
+		Host:           m.Host,
+		Name:           m.Name,
+		TeamName:       m.TeamName,
+		ReviewersCount: reviewersCount,
+		CreatedAt:      m.CreatedAt,
+	}
+}
+
+func ToDomain(entity *Entity) *repository.Model {
+	var reviewersCount int
+	if entity.ReviewersCount != nil {
+		reviewersCount = *entity.ReviewersCount
+	}
+
+	return &repository.Model{
+		ID:             entity.ID,
+		Host:           entity.Host,
+		Name:           entity.Name,
+		TeamName:       entity.TeamName,
+		ReviewersCount: reviewersCount,
+		CreatedAt:      entity.CreatedAt,
+	}
+}
+
+func MapPGError(err error) error {
+	if errors.Is(err, pgx.ErrNoRows) {
+		return storage.ErrRepositoryNotFound
+	}
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case "23505":
+			return storage.ErrRepositoryAlreadyExists
+		case "23503":
+			return storage.ErrTeamNotFound
+		}
+	}
+	return err
+}
@@ -0,0 +1,144 @@
+package postgresql
+
+import (
+	"context"
+	"reviewer-service/internal/domain/repository"
+	storageRepository "reviewer-service/internal/storage/postgresql/repository"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const repositoryColumns = "id, host, name, team_name, reviewers_count, created_at"
+
+func (s *Storage) CreateRepository(ctx context.Context, m *repository.Model) (int64, error) {
+	entity := storageRepository.ToEntity(m)
+
+	var id int64
+	var err error
+
+	tx, pool, hasTx := s.getTx(ctx)
+
+	sql := `
+		INSERT INTO repositories
+			(host, name, team_name, reviewers_count)
+		VALUES
+			($1, $2, $3, $4)
+		RETURNING id
+	`
+
+	if hasTx {
+		err = tx.QueryRow(ctx, sql, entity.Host, entity.Name, entity.TeamName, entity.ReviewersCount).Scan(&id)
+	} else {
+		err = pool.QueryRow(ctx, sql, entity.Host, entity.Name, entity.TeamName, entity.ReviewersCount).Scan(&id)
+	}
+
+	if err != nil {
+		return 0, storageRepository.MapPGError(err)
+	}
+
+	return id, nil
+}
+
+func (s *Storage) GetRepositoryById(ctx context.Context, id int64) (*repository.Model, error) {
+	repositories, err := s.queryRepositories(ctx, "SELECT "+repositoryColumns+" FROM repositories WHERE id = $1", id)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(repositories) == 0 {
+		return nil, storageRepository.MapPGError(pgx.ErrNoRows)
+	}
+
+	return repositories[0], nil
+}
+
+func (s *Storage) GetRepositoryByName(ctx context.Context, host string, name string) (*repository.Model, error) {
+	query := "SELECT " + repositoryColumns + " FROM repositories WHERE host = $1 AND name = $2"
+	repositories, err := s.queryRepositories(ctx, query, host, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(repositories) == 0 {
+		return nil, storageRepository.MapPGError(pgx.ErrNoRows)
+	}
+
+	return repositories[0], nil
+}
+
+func (s *Storage) ListRepositories(ctx context.Context, teamName string) ([]*repository.Model, error) {
+	if teamName == "" {
+		return s.queryRepositories(ctx, "SELECT "+repositoryColumns+" FROM repositories ORDER BY host, name")
+	}
+
+	query := "SELECT " + repositoryColumns + " FROM repositories WHERE team_name = $1 ORDER BY host, name"
+	return s.queryRepositories(ctx, query, teamName)
+}
+
+func (s *Storage) UpdateRepositoryReviewersCount(ctx context.Context, id int64, reviewersCount int) error {
+	tx, pool, hasTx := s.getTx(ctx)
+
+	sql := "UPDATE repositories SET reviewers_count = NULLIF($1, 0) WHERE id = $2"
+
+	var rowsAffected int64
+	if hasTx {
+		result, err := tx.Exec(ctx, sql, reviewersCount, id)
+		if err != nil {
+			return storageRepository.MapPGError(err)
+		}
+		rowsAffected = result.RowsAffected()
+	} else {
+		result, err := pool.Exec(ctx, sql, reviewersCount, id)
+		if err != nil {
+			return storageRepository.MapPGError(err)
+		}
+		rowsAffected = result.RowsAffected()
+	}
+
+	if rowsAffected == 0 {
+		return storageRepository.MapPGError(pgx.ErrNoRows)
+	}
+
+	return nil
+}
+
+func (s *Storage) queryRepositories(ctx context.Context, query string, args ...any) ([]*repository.Model, error) {
+	tx, pool, hasTx := s.getTx(ctx)
+
+	var rows pgx.Rows
+	var err error
+
+	if hasTx {
+		rows, err = tx.Query(ctx, query, args...)
+	} else {
+		rows, err = pool.Query(ctx, query, args...)
+	}
+
+	if err != nil {
+		return nil, storageRepository.MapPGError(err)
+	}
+	defer rows.Close()
+
+	repositories := make([]*repository.Model, 0)
+	for rows.Next() {
+		var entity storageRepository.Entity
+		err := rows.Scan(
+			&entity.ID,
+			&entity.Host,
+			&entity.Name,
+			&entity.TeamName,
+			&entity.ReviewersCount,
+			&entity.CreatedAt,
+		)
+		if err != nil {
+			return nil, storageRepository.MapPGError(err)
+		}
+		repositories = append(repositories, storageRepository.ToDomain(&entity))
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, storageRepository.MapPGError(err)
+	}
+
+	return repositories, nil
+}
@@ -0,0 +1,8 @@
+package role
+
+type Entity struct {
+	ID       int64  `db:"id"`
+	UserId   string `db:"user_id"`
+	Role     string `db:"role"`
+	TeamName string `db:"team_name"`
+}
@@ -0,0 +1,42 @@
+package role
+
+import (
+	"errors"
+	"reviewer-service/internal/domain/auth"
+	"reviewer-service/internal/storage"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+func ToEntity(role *auth.Role) *Entity {
+	return &Entity{
+		ID:       role.ID,
+		UserId:   role.UserId,
+		Role:     role.Role,
+		TeamName: role.TeamName,
+	}
+}
+
+func ToDomain(entity *Entity) *auth.Role {
+	return &auth.Role{
+		ID:       entity.ID,
+		UserId:   entity.UserId,
+		Role:     entity.Role,
+		TeamName: entity.TeamName,
+	}
+}
+
+func MapPGError(err error) error {
+	if errors.Is(err, pgx.ErrNoRows) {
+		return storage.ErrRoleNotFound
+	}
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case "23503":
+			return storage.ErrUserNotFound
+		}
+	}
+	return err
+}
@@ -0,0 +1,136 @@
+package postgresql
+
+import (
+	"context"
+	"reviewer-service/internal/domain/auth"
+	storageRole "reviewer-service/internal/storage/postgresql/role"
+
+	"github.com/jackc/pgx/v5"
+)
+
+func (s *Storage) GrantRole(ctx context.Context, role *auth.Role) (*auth.Role, error) {
+	entity := storageRole.ToEntity(role)
+
+	tx, pool, hasTx := s.getTx(ctx)
+
+	// DO UPDATE вместо DO NOTHING, чтобы RETURNING вернул уже существующую роль
+	sql := `
+		INSERT INTO user_roles 
+			(user_id, role, team_name) 
+		VALUES 
+			($1, $2, $3)
+		ON CONFLICT (user_id, role, team_name) 
+		DO UPDATE SET role = EXCLUDED.role
+		RETURNING id, user_id, role, team_name
+	`
+
+	var row pgx.Row
+	if hasTx {
+		row = tx.QueryRow(ctx, sql, entity.UserId, entity.Role, entity.TeamName)
+	} else {
+		row = pool.QueryRow(ctx, sql, entity.UserId, entity.Role, entity.TeamName)
+	}
+
+	var granted storageRole.Entity
+	if err := row.Scan(&granted.ID, &granted.UserId, &granted.Role, &granted.TeamName); err != nil {
+		return nil, storageRole.MapPGError(err)
+	}
+
+	return storageRole.ToDomain(&granted), nil
+}
+
+func (s *Storage) RevokeRole(ctx context.Context, role *auth.Role) error {
+	entity := storageRole.ToEntity(role)
+
+	tx, pool, hasTx := s.getTx(ctx)
+
+	sql := `
+		DELETE FROM user_roles 
+		WHERE user_id = $1 AND role = $2 AND team_name = $3
+		RETURNING id
+	`
+
+	var id int64
+	var err error
+	if hasTx {
+		err = tx.QueryRow(ctx, sql, entity.UserId, entity.Role, entity.TeamName).Scan(&id)
+	} else {
+		err = pool.QueryRow(ctx, sql, entity.UserId, entity.Role, entity.TeamName).Scan(&id)
+	}
+
+	if err != nil {
+		return storageRole.MapPGError(err)
+	}
+
+	return nil
+}
+
+func (s *Storage) GetUserRoles(ctx context.Context, userId string) ([]*auth.Role, error) {
+	return s.queryRoles(ctx, `
+		SELECT id, user_id, role, team_name 
+		FROM user_roles 
+		WHERE user_id = $1
+		ORDER BY id
+	`, userId)
+}
+
+func (s *Storage) ListRoles(ctx context.Context) ([]*auth.Role, error) {
+	return s.queryRoles(ctx, `
+		SELECT id, user_id, role, team_name 
+		FROM user_roles 
+		ORDER BY id
+	`)
+}
+
+func (s *Storage) queryRoles(ctx context.Context, query string, args ...any) ([]*auth.Role, error) {
+	tx, pool, hasTx := s.getTx(ctx)
+
+	var rows pgx.Rows
+	var err error
+
+	if hasTx {
+		rows, err = tx.Query(ctx, query, args...)
+	} else {
+		rows, err = pool.Query(ctx, query, args...)
+	}
+
+	if err != nil {
+		return nil, storageRole.MapPGError(err)
+	}
+	defer rows.Close()
+
+	roles := make([]*auth.Role, 0)
+	for rows.Next() {
+		var entity storageRole.Entity
+		if err := rows.Scan(&entity.ID, &entity.UserId, &entity.Role, &entity.TeamName); err != nil {
+			return nil, storageRole.MapPGError(err)
+		}
+		roles = append(roles, storageRole.ToDomain(&entity))
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, storageRole.MapPGError(err)
+	}
+
+	return roles, nil
+}
+
+func (s *Storage) GetTeamExists(ctx context.Context, teamName string) (bool, error) {
+	tx, pool, hasTx := s.getTx(ctx)
+
+	query := "SELECT EXISTS (SELECT 1 FROM team WHERE name = $1)"
+
+	var exists bool
+	var err error
+	if hasTx {
+		err = tx.QueryRow(ctx, query, teamName).Scan(&exists)
+	} else {
+		err = pool.QueryRow(ctx, query, teamName).Scan(&exists)
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	return exists, nil
+}
@@ -0,0 +1,21 @@
+package sla
+
+import "time"
+
+type Entity struct {
+	TeamName        string    `db:"team_name"`
+	ReviewHours     int       `db:"review_hours"`
+	WorkingDaysOnly bool      `db:"working_days_only"`
+	AutoReassign    bool      `db:"auto_reassign"`
+	UpdatedAt       time.Time `db:"updated_at"`
+}
+
+// AssignmentEntity - назначение ревьювера вместе с SLA команды автора PR
+type AssignmentEntity struct {
+	PullRequestId   string    `db:"pull_request_id"`
+	PullRequestName string    `db:"pull_request_name"`
+	AuthorId        string    `db:"author_id"`
+	ReviewerId      string    `db:"user_id"`
+	AssignedAt      time.Time `db:"assigned_at"`
+	Entity
+}
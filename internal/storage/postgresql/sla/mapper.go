@@ -0,0 +1,53 @@
+package sla
+
+import (
+	"errors"
+	"reviewer-service/internal/domain/sla"
+	"reviewer-service/internal/storage"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+func ToEntity(p *sla.Policy) *Entity {
+	return &Entity{
+		TeamName:        p.TeamName,
+		ReviewHours:     int(p.ReviewWithin / time.Hour),
+		WorkingDaysOnly: p.WorkingDaysOnly,
+		AutoReassign:    p.AutoReassign,
+		UpdatedAt:       p.UpdatedAt,
+	}
+}
+
+func ToDomain(entity *Entity) *sla.Policy {
+	return &sla.Policy{
+		TeamName:        entity.TeamName,
+		ReviewWithin:    time.Duration(entity.ReviewHours) * time.Hour,
+		WorkingDaysOnly: entity.WorkingDaysOnly,
+		AutoReassign:    entity.AutoReassign,
+		UpdatedAt:       entity.UpdatedAt,
+	}
+}
+
+func AssignmentToDomain(entity *AssignmentEntity) *sla.Assignment {
+	return &sla.Assignment{
+		PullRequestId:   entity.PullRequestId,
+		PullRequestName: entity.PullRequestName,
+		AuthorId:        entity.AuthorId,
+		ReviewerId:      entity.ReviewerId,
+		AssignedAt:      entity.AssignedAt,
+		Policy:          ToDomain(&entity.Entity),
+	}
+}
+
+func MapPGError(err error) error {
+	if errors.Is(err, pgx.ErrNoRows) {
+		return storage.ErrTeamSLANotFound
+	}
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+		return storage.ErrTeamNotFound
+	}
+	return err
+}
@@ -0,0 +1,190 @@
+package postgresql
+
+import (
+	"context"
+	"reviewer-service/internal/domain/sla"
+	storageSLA "reviewer-service/internal/storage/postgresql/sla"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const teamSLAColumns = "team_name, review_hours, working_days_only, auto_reassign, updated_at"
+
+func (s *Storage) SetTeamSLA(ctx context.Context, p *sla.Policy) (*sla.Policy, error) {
+	entity := storageSLA.ToEntity(p)
+
+	tx, pool, hasTx := s.getTx(ctx)
+
+	sql := `
+		INSERT INTO team_sla 
+			(team_name, review_hours, working_days_only, auto_reassign) 
+		VALUES 
+			($1, $2, $3, $4)
+		ON CONFLICT (team_name) DO UPDATE SET 
+			review_hours = EXCLUDED.review_hours, 
+			working_days_only = EXCLUDED.working_days_only, 
+			auto_reassign = EXCLUDED.auto_reassign, 
+			updated_at = NOW()
+		RETURNING ` + teamSLAColumns
+
+	var row pgx.Row
+	if hasTx {
+		row = tx.QueryRow(ctx, sql, entity.TeamName, entity.ReviewHours, entity.WorkingDaysOnly, entity.AutoReassign)
+	} else {
+		row = pool.QueryRow(ctx, sql, entity.TeamName, entity.ReviewHours, entity.WorkingDaysOnly, entity.AutoReassign)
+	}
+
+	return scanTeamSLA(row)
+}
+
+func (s *Storage) GetTeamSLA(ctx context.Context, teamName string) (*sla.Policy, error) {
+	tx, pool, hasTx := s.getTx(ctx)
+
+	sql := "SELECT " + teamSLAColumns + " FROM team_sla WHERE team_name = $1"
+
+	var row pgx.Row
+	if hasTx {
+		row = tx.QueryRow(ctx, sql, teamName)
+	} else {
+		row = pool.QueryRow(ctx, sql, teamName)
+	}
+
+	return scanTeamSLA(row)
+}
+
+// DeleteTeamSLA отключает SLA команды; отсутствие настройки ошибкой не считается
+func (s *Storage) DeleteTeamSLA(ctx context.Context, teamName string) error {
+	tx, pool, hasTx := s.getTx(ctx)
+
+	sql := "DELETE FROM team_sla WHERE team_name = $1"
+
+	var err error
+	if hasTx {
+		_, err = tx.Exec(ctx, sql, teamName)
+	} else {
+		_, err = pool.Exec(ctx, sql, teamName)
+	}
+
+	if err != nil {
+		return storageSLA.MapPGError(err)
+	}
+
+	return nil
+}
+
+// GetOverdueAssignments возвращает назначения ревьюверов на открытые PR, у которых
+// по календарному времени истек SLA команды автора и нарушение еще не отмечено.
+// Рабочие дни учитывает вызывающий (sla.Policy.Breached)
+func (s *Storage) GetOverdueAssignments(ctx context.Context, now time.Time, limit int) ([]*sla.Assignment, error) {
+	tx, pool, hasTx := s.getTx(ctx)
+
+	query := `
+		SELECT 
+			pr.pull_request_id, 
+			pr.pull_request_name, 
+			pr.author_id, 
+			r.user_id, 
+			r.assigned_at, 
+			s.team_name, 
+			s.review_hours, 
+			s.working_days_only, 
+			s.auto_reassign, 
+			s.updated_at 
+		FROM pr_reviewers r 
+		JOIN pull_requests pr ON pr.pull_request_id = r.pull_request_id 
+		JOIN users author ON author.user_id = pr.author_id 
+		JOIN team_sla s ON s.team_name = author.team_name 
+		WHERE pr.status = 'OPEN' 
+			AND r.sla_breached_at IS NULL 
+			AND r.assigned_at + make_interval(hours => s.review_hours) <= $1 
+		ORDER BY r.assigned_at, r.pull_request_id 
+		LIMIT $2
+	`
+
+	var rows pgx.Rows
+	var err error
+
+	if hasTx {
+		rows, err = tx.Query(ctx, query, now.UTC(), limit)
+	} else {
+		rows, err = pool.Query(ctx, query, now.UTC(), limit)
+	}
+
+	if err != nil {
+		return nil, storageSLA.MapPGError(err)
+	}
+	defer rows.Close()
+
+	assignments := make([]*sla.Assignment, 0)
+	for rows.Next() {
+		var entity storageSLA.AssignmentEntity
+		err := rows.Scan(
+			&entity.PullRequestId,
+			&entity.PullRequestName,
+			&entity.AuthorId,
+			&entity.ReviewerId,
+			&entity.AssignedAt,
+			&entity.TeamName,
+			&entity.ReviewHours,
+			&entity.WorkingDaysOnly,
+			&entity.AutoReassign,
+			&entity.UpdatedAt,
+		)
+		if err != nil {
+			return nil, storageSLA.MapPGError(err)
+		}
+		assignments = append(assignments, storageSLA.AssignmentToDomain(&entity))
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, storageSLA.MapPGError(err)
+	}
+
+	return assignments, nil
+}
+
+// MarkReviewSLABreached отмечает нарушение SLA назначения. false означает, что
+// назначение уже отмечено (другим экземпляром) или снято
+func (s *Storage) MarkReviewSLABreached(ctx context.Context, pullRequestId string, reviewerId string, at time.Time) (bool, error) {
+	tx, pool, hasTx := s.getTx(ctx)
+
+	sql := `
+		UPDATE pr_reviewers 
+		SET sla_breached_at = $3 
+		WHERE pull_request_id = $1 AND user_id = $2 AND sla_breached_at IS NULL
+	`
+
+	var rowsAffected int64
+	if hasTx {
+		result, err := tx.Exec(ctx, sql, pullRequestId, reviewerId, at.UTC())
+		if err != nil {
+			return false, err
+		}
+		rowsAffected = result.RowsAffected()
+	} else {
+		result, err := pool.Exec(ctx, sql, pullRequestId, reviewerId, at.UTC())
+		if err != nil {
+			return false, err
+		}
+		rowsAffected = result.RowsAffected()
+	}
+
+	return rowsAffected > 0, nil
+}
+
+func scanTeamSLA(row pgx.Row) (*sla.Policy, error) {
+	var entity storageSLA.Entity
+	err := row.Scan(
+		&entity.TeamName,
+		&entity.ReviewHours,
+		&entity.WorkingDaysOnly,
+		&entity.AutoReassign,
+		&entity.UpdatedAt,
+	)
+	if err != nil {
+		return nil, storageSLA.MapPGError(err)
+	}
+
+	return storageSLA.ToDomain(&entity), nil
+}
@@ -0,0 +1,94 @@
+package subscription
+
+import (
+	"errors"
+	"reviewer-service/internal/domain/subscription"
+	"reviewer-service/internal/storage"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+func ToEntity(s *subscription.Subscription) *Entity {
+	eventTypes := s.EventTypes
+	if eventTypes == nil {
+		eventTypes = []string{}
+	}
+
+	return &Entity{
+		ID:         s.ID,
+		URL:        s.URL,
+		Secret:     s.Secret,
+		EventTypes: eventTypes,
+		TeamName:   s.TeamName,
+		Active:     s.Active,
+		CreatedAt:  s.CreatedAt,
+	}
+}
+
+func ToDomain(entity *Entity) *subscription.Subscription {
+	return &subscription.Subscription{
+		ID:         entity.ID,
+		URL:        entity.URL,
+		Secret:     entity.Secret,
+		EventTypes: entity.EventTypes,
+		TeamName:   entity.TeamName,
+		Active:     entity.Active,
+		CreatedAt:  entity.CreatedAt,
+	}
+}
+
+func DeliveryToEntity(d *subscription.Delivery) *DeliveryEntity {
+	return &DeliveryEntity{
+		ID:             d.ID,
+		SubscriptionId: d.SubscriptionId,
+		EventId:        d.EventId,
+		EventType:      d.EventType,
+		Payload:        d.Payload,
+		Status:         d.Status,
+		Attempts:       d.Attempts,
+		NextAttemptAt:  d.NextAttemptAt,
+		LastStatusCode: d.LastStatusCode,
+		LastError:      d.LastError,
+		CreatedAt:      d.CreatedAt,
+		DeliveredAt:    d.DeliveredAt,
+	}
+}
+
+func DeliveryToDomain(entity *DeliveryEntity) *subscription.Delivery {
+	return &subscription.Delivery{
+		ID:             entity.ID,
+		SubscriptionId: entity.SubscriptionId,
+		EventId:        entity.EventId,
+		EventType:      entity.EventType,
+		Payload:        entity.Payload,
+		Status:         entity.Status,
+		Attempts:       entity.Attempts,
+		NextAttemptAt:  entity.NextAttemptAt,
+		LastStatusCode: entity.LastStatusCode,
+		LastError:      entity.LastError,
+		CreatedAt:      entity.CreatedAt,
+		DeliveredAt:    entity.DeliveredAt,
+	}
+}
+
+func MapPGError(err error) error {
+	if errors.Is(err, pgx.ErrNoRows) {
+		return storage.ErrSubscriptionNotFound
+	}
+	return err
+}
+
+func MapDeliveryPGError(err error) error {
+	if errors.Is(err, pgx.ErrNoRows) {
+		return storage.ErrDeliveryNotFound
+	}
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case "23503":
+			return storage.ErrSubscriptionNotFound
+		}
+	}
+	return err
+}
@@ -0,0 +1,377 @@
+package postgresql
+
+import (
+	"context"
+	"reviewer-service/internal/domain/subscription"
+	storageSubscription "reviewer-service/internal/storage/postgresql/subscription"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	subscriptionColumns = "id, url, secret, event_types, team_name, active, created_at"
+	deliveryColumns     = "id, subscription_id, event_id, event_type, payload, status, attempts, next_attempt_at, last_status_code, last_error, created_at, delivered_at"
+)
+
+func (s *Storage) CreateSubscription(ctx context.Context, sub *subscription.Subscription) (int64, error) {
+	entity := storageSubscription.ToEntity(sub)
+
+	var id int64
+	var err error
+
+	tx, pool, hasTx := s.getTx(ctx)
+
+	sql := `
+		INSERT INTO subscriptions 
+			(url, secret, event_types, team_name, active) 
+		VALUES 
+			($1, $2, $3, $4, $5)
+		RETURNING id
+	`
+
+	if hasTx {
+		err = tx.QueryRow(ctx, sql, entity.URL, entity.Secret, entity.EventTypes, entity.TeamName, entity.Active).Scan(&id)
+	} else {
+		err = pool.QueryRow(ctx, sql, entity.URL, entity.Secret, entity.EventTypes, entity.TeamName, entity.Active).Scan(&id)
+	}
+
+	if err != nil {
+		return 0, storageSubscription.MapPGError(err)
+	}
+
+	return id, nil
+}
+
+func (s *Storage) GetSubscription(ctx context.Context, id int64) (*subscription.Subscription, error) {
+	subscriptions, err := s.querySubscriptions(ctx, "SELECT "+subscriptionColumns+" FROM subscriptions WHERE id = $1", id)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(subscriptions) == 0 {
+		return nil, storageSubscription.MapPGError(pgx.ErrNoRows)
+	}
+
+	return subscriptions[0], nil
+}
+
+// ListSubscriptions возвращает подписки команды, а для пустого teamName - все
+func (s *Storage) ListSubscriptions(ctx context.Context, teamName string) ([]*subscription.Subscription, error) {
+	query := "SELECT " + subscriptionColumns + " FROM subscriptions WHERE $1 = '' OR team_name = $1 ORDER BY id"
+	return s.querySubscriptions(ctx, query, teamName)
+}
+
+// GetMatchingSubscriptions возвращает активные подписки на тип события.
+// Подписки без команды получают события всех команд
+func (s *Storage) GetMatchingSubscriptions(ctx context.Context, eventType string, teamName string) ([]*subscription.Subscription, error) {
+	query := `
+		SELECT ` + subscriptionColumns + ` 
+		FROM subscriptions 
+		WHERE active AND $1 = ANY(event_types) AND (team_name = '' OR team_name = $2)
+		ORDER BY id
+	`
+	return s.querySubscriptions(ctx, query, eventType, teamName)
+}
+
+func (s *Storage) DeleteSubscription(ctx context.Context, id int64) error {
+	tx, pool, hasTx := s.getTx(ctx)
+
+	sql := "DELETE FROM subscriptions WHERE id = $1"
+
+	var rowsAffected int64
+	if hasTx {
+		result, err := tx.Exec(ctx, sql, id)
+		if err != nil {
+			return storageSubscription.MapPGError(err)
+		}
+		rowsAffected = result.RowsAffected()
+	} else {
+		result, err := pool.Exec(ctx, sql, id)
+		if err != nil {
+			return storageSubscription.MapPGError(err)
+		}
+		rowsAffected = result.RowsAffected()
+	}
+
+	if rowsAffected == 0 {
+		return storageSubscription.MapPGError(pgx.ErrNoRows)
+	}
+
+	return nil
+}
+
+func (s *Storage) querySubscriptions(ctx context.Context, query string, args ...any) ([]*subscription.Subscription, error) {
+	tx, pool, hasTx := s.getTx(ctx)
+
+	var rows pgx.Rows
+	var err error
+
+	if hasTx {
+		rows, err = tx.Query(ctx, query, args...)
+	} else {
+		rows, err = pool.Query(ctx, query, args...)
+	}
+
+	if err != nil {
+		return nil, storageSubscription.MapPGError(err)
+	}
+	defer rows.Close()
+
+	subscriptions := make([]*subscription.Subscription, 0)
+	for rows.Next() {
+		var entity storageSubscription.Entity
+		err := rows.Scan(
+			&entity.ID,
+			&entity.URL,
+			&entity.Secret,
+			&entity.EventTypes,
+			&entity.TeamName,
+			&entity.Active,
+			&entity.CreatedAt,
+		)
+		if err != nil {
+			return nil, storageSubscription.MapPGError(err)
+		}
+		subscriptions = append(subscriptions, storageSubscription.ToDomain(&entity))
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, storageSubscription.MapPGError(err)
+	}
+
+	return subscriptions, nil
+}
+
+// AddDelivery ставит событие в очередь доставки подписке. Повторная постановка
+// того же события ничего не меняет
+func (s *Storage) AddDelivery(ctx context.Context, d *subscription.Delivery) error {
+	entity := storageSubscription.DeliveryToEntity(d)
+
+	tx, pool, hasTx := s.getTx(ctx)
+
+	sql := `
+		INSERT INTO subscription_deliveries 
+			(subscription_id, event_id, event_type, payload) 
+		VALUES 
+			($1, $2, $3, $4)
+		ON CONFLICT (subscription_id, event_id) DO NOTHING
+	`
+
+	var err error
+	if hasTx {
+		_, err = tx.Exec(ctx, sql, entity.SubscriptionId, entity.EventId, entity.EventType, entity.Payload)
+	} else {
+		_, err = pool.Exec(ctx, sql, entity.SubscriptionId, entity.EventId, entity.EventType, entity.Payload)
+	}
+
+	if err != nil {
+		return storageSubscription.MapDeliveryPGError(err)
+	}
+
+	return nil
+}
+
+func (s *Storage) GetDelivery(ctx context.Context, id int64) (*subscription.Delivery, error) {
+	deliveries, err := s.queryDeliveries(ctx, "SELECT "+deliveryColumns+" FROM subscription_deliveries WHERE id = $1", id)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(deliveries) == 0 {
+		return nil, storageSubscription.MapDeliveryPGError(pgx.ErrNoRows)
+	}
+
+	return deliveries[0], nil
+}
+
+func (s *Storage) ListDeliveries(ctx context.Context, filter subscription.DeliveryFilter) ([]*subscription.Delivery, error) {
+	query := `
+		SELECT ` + deliveryColumns + ` 
+		FROM subscription_deliveries 
+		WHERE subscription_id = $1 AND ($2 = '' OR status = $2)
+		ORDER BY id DESC
+		LIMIT $3
+	`
+	return s.queryDeliveries(ctx, query, filter.SubscriptionId, filter.Status, filter.Limit)
+}
+
+func (s *Storage) RetryDelivery(ctx context.Context, id int64) (*subscription.Delivery, error) {
+	query := `
+		UPDATE subscription_deliveries 
+		SET status = 'pending', attempts = 0, next_attempt_at = NOW()
+		WHERE id = $1
+		RETURNING ` + deliveryColumns
+
+	deliveries, err := s.queryDeliveries(ctx, query, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(deliveries) == 0 {
+		return nil, storageSubscription.MapDeliveryPGError(pgx.ErrNoRows)
+	}
+
+	return deliveries[0], nil
+}
+
+// ClaimDueDeliveries забирает доставки, время попытки которых наступило, и
+// откладывает их следующую попытку на lease: если экземпляр сервиса упадет,
+// не отметив результат, доставка будет отправлена снова. Строки, уже взятые
+// другим экземпляром сервиса, пропускаются
+func (s *Storage) ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]*subscription.Target, error) {
+	tx, pool, hasTx := s.getTx(ctx)
+
+	query := `
+		WITH due AS (
+			SELECT d.id
+			FROM subscription_deliveries d
+			JOIN subscriptions s ON s.id = d.subscription_id
+			WHERE d.status = 'pending' AND d.next_attempt_at <= NOW() AND s.active
+			ORDER BY d.next_attempt_at, d.id
+			LIMIT $1
+			FOR UPDATE OF d SKIP LOCKED
+		)
+		UPDATE subscription_deliveries d
+		SET next_attempt_at = NOW() + make_interval(secs => $2)
+		FROM due, subscriptions s
+		WHERE d.id = due.id AND s.id = d.subscription_id
+		RETURNING d.id, d.subscription_id, d.event_id, d.event_type, d.payload, d.status, d.attempts, 
+			d.next_attempt_at, d.last_status_code, d.last_error, d.created_at, d.delivered_at, s.url, s.secret
+	`
+
+	var rows pgx.Rows
+	var err error
+
+	if hasTx {
+		rows, err = tx.Query(ctx, query, limit, lease.Seconds())
+	} else {
+		rows, err = pool.Query(ctx, query, limit, lease.Seconds())
+	}
+
+	if err != nil {
+		return nil, storageSubscription.MapDeliveryPGError(err)
+	}
+	defer rows.Close()
+
+	targets := make([]*subscription.Target, 0)
+	for rows.Next() {
+		var entity storageSubscription.DeliveryEntity
+		var target subscription.Target
+		err := rows.Scan(
+			&entity.ID,
+			&entity.SubscriptionId,
+			&entity.EventId,
+			&entity.EventType,
+			&entity.Payload,
+			&entity.Status,
+			&entity.Attempts,
+			&entity.NextAttemptAt,
+			&entity.LastStatusCode,
+			&entity.LastError,
+			&entity.CreatedAt,
+			&entity.DeliveredAt,
+			&target.URL,
+			&target.Secret,
+		)
+		if err != nil {
+			return nil, storageSubscription.MapDeliveryPGError(err)
+		}
+		target.Delivery = storageSubscription.DeliveryToDomain(&entity)
+		targets = append(targets, &target)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, storageSubscription.MapDeliveryPGError(err)
+	}
+
+	return targets, nil
+}
+
+func (s *Storage) MarkDeliverySucceeded(ctx context.Context, id int64, statusCode int) error {
+	tx, pool, hasTx := s.getTx(ctx)
+
+	sql := `
+		UPDATE subscription_deliveries 
+		SET status = 'delivered', attempts = attempts + 1, last_status_code = $2, last_error = '', delivered_at = NOW()
+		WHERE id = $1
+	`
+
+	var err error
+	if hasTx {
+		_, err = tx.Exec(ctx, sql, id, statusCode)
+	} else {
+		_, err = pool.Exec(ctx, sql, id, statusCode)
+	}
+
+	return err
+}
+
+// MarkDeliveryFailed фиксирует неудачную попытку: следующая будет через retryIn,
+// а доставка с dead остается в статусе dead до ручного повтора
+func (s *Storage) MarkDeliveryFailed(ctx context.Context, id int64, statusCode int, reason string, retryIn time.Duration, dead bool) error {
+	tx, pool, hasTx := s.getTx(ctx)
+
+	sql := `
+		UPDATE subscription_deliveries 
+		SET status = CASE WHEN $5 THEN 'dead' ELSE 'pending' END, 
+			attempts = attempts + 1, last_status_code = $2, last_error = $3, next_attempt_at = NOW() + make_interval(secs => $4)
+		WHERE id = $1
+	`
+
+	var err error
+	if hasTx {
+		_, err = tx.Exec(ctx, sql, id, statusCode, reason, retryIn.Seconds(), dead)
+	} else {
+		_, err = pool.Exec(ctx, sql, id, statusCode, reason, retryIn.Seconds(), dead)
+	}
+
+	return err
+}
+
+func (s *Storage) queryDeliveries(ctx context.Context, query string, args ...any) ([]*subscription.Delivery, error) {
+	tx, pool, hasTx := s.getTx(ctx)
+
+	var rows pgx.Rows
+	var err error
+
+	if hasTx {
+		rows, err = tx.Query(ctx, query, args...)
+	} else {
+		rows, err = pool.Query(ctx, query, args...)
+	}
+
+	if err != nil {
+		return nil, storageSubscription.MapDeliveryPGError(err)
+	}
+	defer rows.Close()
+
+	deliveries := make([]*subscription.Delivery, 0)
+	for rows.Next() {
+		var entity storageSubscription.DeliveryEntity
+		err := rows.Scan(
+			&entity.ID,
+			&entity.SubscriptionId,
+			&entity.EventId,
+			&entity.EventType,
+			&entity.Payload,
+			&entity.Status,
+			&entity.Attempts,
+			&entity.NextAttemptAt,
+			&entity.LastStatusCode,
+			&entity.LastError,
+			&entity.CreatedAt,
+			&entity.DeliveredAt,
+		)
+		if err != nil {
+			return nil, storageSubscription.MapDeliveryPGError(err)
+		}
+		deliveries = append(deliveries, storageSubscription.DeliveryToDomain(&entity))
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, storageSubscription.MapDeliveryPGError(err)
+	}
+
+	return deliveries, nil
+}
@@ -3,4 +3,6 @@ package team
 type Entity struct {
 	ID   int64  `db:"id"`
 	Name string `db:"name"`
+	// DefaultMaxOpenReviews хранится как NULL, если лимит не задан
+	DefaultMaxOpenReviews int `db:"default_max_open_reviews"`
 }
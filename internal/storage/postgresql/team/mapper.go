@@ -13,20 +13,22 @@ import (
 
 func ToEntity(team *team.Model) *Entity {
 	return &Entity{
-		ID:   team.ID,
-		Name: team.Name,
+		ID:                    team.ID,
+		Name:                  team.Name,
+		DefaultMaxOpenReviews: team.DefaultMaxOpenReviews,
 	}
 }
 
 func ToDomain(teamEntity *Entity, members []*user.Model) *team.Model {
 	return &team.Model{
-		ID:      teamEntity.ID,
-		Name:    teamEntity.Name,
-		Members: members,
+		ID:                    teamEntity.ID,
+		Name:                  teamEntity.Name,
+		Members:               members,
+		DefaultMaxOpenReviews: teamEntity.DefaultMaxOpenReviews,
 	}
 }
 
-func ToDomainFromJoinResult(teamID int64, teamName string, members []*storageUser.Entity) *team.Model {
+func ToDomainFromJoinResult(teamID int64, teamName string, defaultMaxOpenReviews int, members []*storageUser.Entity) *team.Model {
 	memberModels := make([]*user.Model, 0, len(members))
 	for _, member := range members {
 		if member != nil && member.ID != 0 {
@@ -35,9 +37,10 @@ func ToDomainFromJoinResult(teamID int64, teamName string, members []*storageUse
 	}
 
 	return &team.Model{
-		ID:      teamID,
-		Name:    teamName,
-		Members: memberModels,
+		ID:                    teamID,
+		Name:                  teamName,
+		Members:               memberModels,
+		DefaultMaxOpenReviews: defaultMaxOpenReviews,
 	}
 }
 
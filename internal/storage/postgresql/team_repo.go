@@ -2,7 +2,9 @@ package postgresql
 
 import (
 	"context"
+	"reviewer-service/internal/domain/pullrequest"
 	"reviewer-service/internal/domain/team"
+	storagePR "reviewer-service/internal/storage/postgresql/pullrequest"
 	storageTeam "reviewer-service/internal/storage/postgresql/team"
 	storageUser "reviewer-service/internal/storage/postgresql/user"
 
@@ -19,14 +21,16 @@ func (s *Storage) CreateTeam(ctx context.Context, t *team.Model) (int64, error)
 	if hasTx {
 		err = tx.QueryRow(
 			ctx,
-			"INSERT INTO team (name) VALUES ($1) RETURNING id",
+			"INSERT INTO team (name, default_max_open_reviews) VALUES ($1, NULLIF($2, 0)) RETURNING id",
 			entity.Name,
+			entity.DefaultMaxOpenReviews,
 		).Scan(&id)
 	} else {
 		err = pool.QueryRow(
 			ctx,
-			"INSERT INTO team (name) VALUES ($1) RETURNING id",
+			"INSERT INTO team (name, default_max_open_reviews) VALUES ($1, NULLIF($2, 0)) RETURNING id",
 			entity.Name,
+			entity.DefaultMaxOpenReviews,
 		).Scan(&id)
 	}
 
@@ -44,11 +48,15 @@ func (s *Storage) GetTeam(ctx context.Context, id int64) (*team.Model, error) {
 		SELECT 
 			team.id, 
 			team.name, 
+			COALESCE(team.default_max_open_reviews, 0), 
 			users.id, 
 			users.user_id, 
 			users.username, 
 			users.team_name, 
-			users.is_active 
+			users.is_active, 
+			users.chat_handle, 
+			users.max_open_reviews, 
+			users.seniority 
 		FROM team 
 		LEFT JOIN users ON team.name = users.team_name 
 		WHERE team.id = $1
@@ -71,6 +79,7 @@ func (s *Storage) GetTeam(ctx context.Context, id int64) (*team.Model, error) {
 
 	var teamID int64
 	var teamName string
+	var defaultMaxOpenReviews int
 	var members []*storageUser.Entity
 
 	for rows.Next() {
@@ -79,15 +88,22 @@ func (s *Storage) GetTeam(ctx context.Context, id int64) (*team.Model, error) {
 		var userUsername *string
 		var userTeamName *string
 		var userIsActive *bool
+		var userChatHandle *string
+		var userMaxOpenReviews *int
+		var userSeniority *string
 
 		err := rows.Scan(
 			&teamID,
 			&teamName,
+			&defaultMaxOpenReviews,
 			&userID,
 			&userUserId,
 			&userUsername,
 			&userTeamName,
 			&userIsActive,
+			&userChatHandle,
+			&userMaxOpenReviews,
+			&userSeniority,
 		)
 
 		if err != nil {
@@ -95,13 +111,21 @@ func (s *Storage) GetTeam(ctx context.Context, id int64) (*team.Model, error) {
 		}
 
 		if userID != nil {
-			members = append(members, &storageUser.Entity{
-				ID:       *userID,
-				UserId:   *userUserId,
-				Username: *userUsername,
-				TeamName: *userTeamName,
-				IsActive: *userIsActive,
-			})
+			member := &storageUser.Entity{
+				ID:         *userID,
+				UserId:     *userUserId,
+				Username:   *userUsername,
+				TeamName:   *userTeamName,
+				IsActive:   *userIsActive,
+				ChatHandle: *userChatHandle,
+			}
+			if userMaxOpenReviews != nil {
+				member.MaxOpenReviews = *userMaxOpenReviews
+			}
+			if userSeniority != nil {
+				member.Seniority = *userSeniority
+			}
+			members = append(members, member)
 		}
 	}
 
@@ -113,72 +137,110 @@ func (s *Storage) GetTeam(ctx context.Context, id int64) (*team.Model, error) {
 		return nil, storageTeam.MapPGError(pgx.ErrNoRows)
 	}
 
-	return storageTeam.ToDomainFromJoinResult(teamID, teamName, members), nil
+	return storageTeam.ToDomainFromJoinResult(teamID, teamName, defaultMaxOpenReviews, members), nil
 }
 
-func (s *Storage) GetActiveReviewersByTeam(ctx context.Context, teamName string, excludeUserId string, limit int) ([]string, error) {
-	tx, pool, hasTx := s.getTx(ctx)
+// availableReviewerCondition отбирает из users (с LEFT JOIN team) тех, кто сейчас
+// не отсутствует и не достиг лимита открытых ревью
+const availableReviewerCondition = `NOT EXISTS (
+				SELECT 1 
+				FROM user_unavailability u 
+				WHERE u.user_id = users.user_id AND u.starts_at <= NOW() AND u.ends_at > NOW()
+			)
+			AND (
+				COALESCE(users.max_open_reviews, team.default_max_open_reviews) IS NULL 
+				OR (
+					SELECT COUNT(*) 
+					FROM pr_reviewers r 
+					JOIN pull_requests pr ON pr.pull_request_id = r.pull_request_id 
+					WHERE r.user_id = users.user_id AND pr.status = 'OPEN'
+				) < COALESCE(users.max_open_reviews, team.default_max_open_reviews)
+			)`
+
+// notExcludedCondition отбирает из users тех, кто не исключен в паре с автором
+// политикой текущей команды кандидата; author - параметр запроса с user_id автора
+func notExcludedCondition(author string) string {
+	return `NOT EXISTS (
+				SELECT 1 
+				FROM reviewer_exclusions ex 
+				WHERE ex.team_name = users.team_name 
+					AND (
+						(ex.user_id = users.user_id AND ex.other_user_id = ` + author + `) 
+						OR (ex.user_id = ` + author + ` AND ex.other_user_id = users.user_id)
+					)
+			)`
+}
 
-	query := `
-		SELECT user_id 
-		FROM users 
-		WHERE team_name = $1 
-			AND is_active = true 
-			AND user_id != $2
-		ORDER BY user_id
-		LIMIT $3
-	`
+// repeatLimitReached истинно для кандидата, которого за окно лимита команды уже
+// назначали на PR автора столько раз, сколько лимит позволяет
+func repeatLimitReached(author string) string {
+	return `COALESCE((
+				SELECT COUNT(*) >= lim.max_reviews 
+				FROM pr_reviewers r 
+				JOIN pull_requests pr ON pr.pull_request_id = r.pull_request_id 
+				JOIN team_repeat_limits lim ON lim.team_name = users.team_name 
+				WHERE r.user_id = users.user_id 
+					AND pr.author_id = ` + author + ` 
+					AND r.assigned_at > NOW() - make_interval(days => lim.window_days)
+				GROUP BY lim.max_reviews
+			), false)`
+}
 
-	var rows pgx.Rows
-	var err error
+// GetReviewerCandidates возвращает участников команды teamName, которых сейчас
+// можно назначить ревьювером PR автора authorId. Порядок не гарантируется:
+// кандидатов упорядочивает домен. В транзакции участники команды сначала
+// блокируются до ее конца, а открытые ревью считаются уже после блокировки:
+// иначе конкурентные назначения видят одно и то же число ревью и вместе
+// превышают лимит max_open_reviews
+func (s *Storage) GetReviewerCandidates(ctx context.Context, teamName string, authorId string) ([]*pullrequest.Candidate, error) {
+	tx, pool, hasTx := s.getTx(ctx)
 
 	if hasTx {
-		rows, err = tx.Query(ctx, query, teamName, excludeUserId, limit)
-	} else {
-		rows, err = pool.Query(ctx, query, teamName, excludeUserId, limit)
-	}
-
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-
-	var reviewers = make([]string, 0)
-	for rows.Next() {
-		var reviewerId string
-		if err := rows.Scan(&reviewerId); err != nil {
+		lockQuery := `
+			SELECT user_id 
+			FROM users 
+			WHERE team_name = $1 
+			ORDER BY user_id 
+			FOR NO KEY UPDATE
+		`
+		if _, err := tx.Exec(ctx, lockQuery, teamName); err != nil {
 			return nil, err
 		}
-		reviewers = append(reviewers, reviewerId)
 	}
 
-	if err = rows.Err(); err != nil {
-		return nil, err
-	}
-
-	return reviewers, nil
-}
-
-func (s *Storage) GetActiveReviewersByTeamExcluding(ctx context.Context, teamName string, excludeUserIds []string, limit int) ([]string, error) {
-	tx, pool, hasTx := s.getTx(ctx)
-
 	query := `
-		SELECT user_id 
+		SELECT 
+			users.user_id, 
+			COALESCE(users.seniority, ''), 
+			(
+				SELECT COUNT(*) 
+				FROM pr_reviewers r 
+				JOIN pull_requests pr ON pr.pull_request_id = r.pull_request_id 
+				WHERE r.user_id = users.user_id AND pr.status = 'OPEN'
+			), 
+			` + repeatLimitReached("$2") + `, 
+			(
+				SELECT MAX(r.assigned_at) 
+				FROM pr_reviewers r 
+				JOIN pull_requests pr ON pr.pull_request_id = r.pull_request_id 
+				WHERE r.user_id = users.user_id AND pr.author_id = $2
+			)
 		FROM users 
-		WHERE team_name = $1 
-			AND is_active = true 
-			AND user_id != ALL($2::text[])
-		ORDER BY RANDOM()
-		LIMIT $3
+		LEFT JOIN team ON team.name = users.team_name 
+		WHERE users.team_name = $1 
+			AND users.is_active = true 
+			AND users.user_id != $2
+			AND ` + availableReviewerCondition + `
+			AND ` + notExcludedCondition("$2") + `
 	`
 
 	var rows pgx.Rows
 	var err error
 
 	if hasTx {
-		rows, err = tx.Query(ctx, query, teamName, excludeUserIds, limit)
+		rows, err = tx.Query(ctx, query, teamName, authorId)
 	} else {
-		rows, err = pool.Query(ctx, query, teamName, excludeUserIds, limit)
+		rows, err = pool.Query(ctx, query, teamName, authorId)
 	}
 
 	if err != nil {
@@ -186,20 +248,20 @@ func (s *Storage) GetActiveReviewersByTeamExcluding(ctx context.Context, teamNam
 	}
 	defer rows.Close()
 
-	var reviewers []string
+	candidates := make([]*pullrequest.Candidate, 0)
 	for rows.Next() {
-		var reviewerId string
-		if err := rows.Scan(&reviewerId); err != nil {
+		var entity storagePR.CandidateEntity
+		if err := rows.Scan(&entity.UserId, &entity.Seniority, &entity.OpenReviews, &entity.RepeatLimitReached, &entity.LastReviewedAt); err != nil {
 			return nil, err
 		}
-		reviewers = append(reviewers, reviewerId)
+		candidates = append(candidates, storagePR.ToCandidate(&entity))
 	}
 
 	if err = rows.Err(); err != nil {
 		return nil, err
 	}
 
-	return reviewers, nil
+	return candidates, nil
 }
 
 func (s *Storage) GetTeamByName(ctx context.Context, name string) (*team.Model, error) {
@@ -209,11 +271,15 @@ func (s *Storage) GetTeamByName(ctx context.Context, name string) (*team.Model,
 		SELECT 
 			team.id, 
 			team.name, 
+			COALESCE(team.default_max_open_reviews, 0), 
 			users.id, 
 			users.user_id, 
 			users.username, 
 			users.team_name, 
-			users.is_active 
+			users.is_active, 
+			users.chat_handle, 
+			users.max_open_reviews, 
+			users.seniority 
 		FROM team 
 		LEFT JOIN users ON team.name = users.team_name 
 		WHERE team.name = $1
@@ -236,6 +302,7 @@ func (s *Storage) GetTeamByName(ctx context.Context, name string) (*team.Model,
 
 	var teamID int64
 	var teamName string
+	var defaultMaxOpenReviews int
 	var members []*storageUser.Entity
 
 	for rows.Next() {
@@ -244,15 +311,22 @@ func (s *Storage) GetTeamByName(ctx context.Context, name string) (*team.Model,
 		var userUsername *string
 		var userTeamName *string
 		var userIsActive *bool
+		var userChatHandle *string
+		var userMaxOpenReviews *int
+		var userSeniority *string
 
 		err := rows.Scan(
 			&teamID,
 			&teamName,
+			&defaultMaxOpenReviews,
 			&userID,
 			&userUserId,
 			&userUsername,
 			&userTeamName,
 			&userIsActive,
+			&userChatHandle,
+			&userMaxOpenReviews,
+			&userSeniority,
 		)
 
 		if err != nil {
@@ -260,13 +334,21 @@ func (s *Storage) GetTeamByName(ctx context.Context, name string) (*team.Model,
 		}
 
 		if userID != nil {
-			members = append(members, &storageUser.Entity{
-				ID:       *userID,
-				UserId:   *userUserId,
-				Username: *userUsername,
-				TeamName: *userTeamName,
-				IsActive: *userIsActive,
-			})
+			member := &storageUser.Entity{
+				ID:         *userID,
+				UserId:     *userUserId,
+				Username:   *userUsername,
+				TeamName:   *userTeamName,
+				IsActive:   *userIsActive,
+				ChatHandle: *userChatHandle,
+			}
+			if userMaxOpenReviews != nil {
+				member.MaxOpenReviews = *userMaxOpenReviews
+			}
+			if userSeniority != nil {
+				member.Seniority = *userSeniority
+			}
+			members = append(members, member)
 		}
 	}
 
@@ -278,5 +360,71 @@ func (s *Storage) GetTeamByName(ctx context.Context, name string) (*team.Model,
 		return nil, storageTeam.MapPGError(pgx.ErrNoRows)
 	}
 
-	return storageTeam.ToDomainFromJoinResult(teamID, teamName, members), nil
+	return storageTeam.ToDomainFromJoinResult(teamID, teamName, defaultMaxOpenReviews, members), nil
+}
+
+func (s *Storage) UpdateTeamChatWebhook(ctx context.Context, teamName string, webhookURL string) error {
+	tx, pool, hasTx := s.getTx(ctx)
+
+	sql := "UPDATE team SET chat_webhook_url = $1 WHERE name = $2 RETURNING id"
+
+	var id int64
+	var err error
+
+	if hasTx {
+		err = tx.QueryRow(ctx, sql, webhookURL, teamName).Scan(&id)
+	} else {
+		err = pool.QueryRow(ctx, sql, webhookURL, teamName).Scan(&id)
+	}
+
+	if err != nil {
+		return storageTeam.MapPGError(err)
+	}
+
+	return nil
+}
+
+// GetTeamChatWebhook возвращает адрес вебхука чата команды или пустую строку,
+// если он не настроен
+func (s *Storage) GetTeamChatWebhook(ctx context.Context, teamName string) (string, error) {
+	tx, pool, hasTx := s.getTx(ctx)
+
+	query := "SELECT chat_webhook_url FROM team WHERE name = $1"
+
+	var webhookURL string
+	var err error
+
+	if hasTx {
+		err = tx.QueryRow(ctx, query, teamName).Scan(&webhookURL)
+	} else {
+		err = pool.QueryRow(ctx, query, teamName).Scan(&webhookURL)
+	}
+
+	if err != nil {
+		return "", storageTeam.MapPGError(err)
+	}
+
+	return webhookURL, nil
+}
+
+// UpdateTeamDefaultMaxOpenReviews задает лимит открытых ревью участников команды; 0 снимает лимит
+func (s *Storage) UpdateTeamDefaultMaxOpenReviews(ctx context.Context, teamName string, maxOpenReviews int) error {
+	tx, pool, hasTx := s.getTx(ctx)
+
+	sql := "UPDATE team SET default_max_open_reviews = NULLIF($1, 0) WHERE name = $2 RETURNING id"
+
+	var id int64
+	var err error
+
+	if hasTx {
+		err = tx.QueryRow(ctx, sql, maxOpenReviews, teamName).Scan(&id)
+	} else {
+		err = pool.QueryRow(ctx, sql, maxOpenReviews, teamName).Scan(&id)
+	}
+
+	if err != nil {
+		return storageTeam.MapPGError(err)
+	}
+
+	return nil
 }
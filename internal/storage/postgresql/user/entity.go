@@ -1,9 +1,14 @@
 package user
 
 type Entity struct {
-	ID       int64  `json:"id"`
-	UserId   string `json:"user_id"`
-	Username string `json:"username"`
-	TeamName string `json:"team_name"`
-	IsActive bool   `json:"is_active"`
+	ID         int64  `json:"id"`
+	UserId     string `json:"user_id"`
+	Username   string `json:"username"`
+	TeamName   string `json:"team_name"`
+	IsActive   bool   `json:"is_active"`
+	ChatHandle string `json:"chat_handle"`
+	// MaxOpenReviews хранится как NULL, если лимит не задан
+	MaxOpenReviews int `json:"max_open_reviews"`
+	// Seniority хранится как NULL, если уровень не задан
+	Seniority string `json:"seniority"`
 }
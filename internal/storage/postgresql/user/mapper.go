@@ -11,21 +11,27 @@ import (
 
 func ToEntity(dto *user.Model) *user.Model {
 	return &user.Model{
-		ID:       dto.ID,
-		UserId:   dto.UserId,
-		Username: dto.Username,
-		TeamName: dto.TeamName,
-		IsActive: dto.IsActive,
+		ID:             dto.ID,
+		UserId:         dto.UserId,
+		Username:       dto.Username,
+		TeamName:       dto.TeamName,
+		IsActive:       dto.IsActive,
+		ChatHandle:     dto.ChatHandle,
+		MaxOpenReviews: dto.MaxOpenReviews,
+		Seniority:      dto.Seniority,
 	}
 }
 
 func ToDomain(dto *Entity) *user.Model {
 	return &user.Model{
-		ID:       dto.ID,
-		UserId:   dto.UserId,
-		Username: dto.Username,
-		TeamName: dto.TeamName,
-		IsActive: dto.IsActive,
+		ID:             dto.ID,
+		UserId:         dto.UserId,
+		Username:       dto.Username,
+		TeamName:       dto.TeamName,
+		IsActive:       dto.IsActive,
+		ChatHandle:     dto.ChatHandle,
+		MaxOpenReviews: dto.MaxOpenReviews,
+		Seniority:      dto.Seniority,
 	}
 }
 
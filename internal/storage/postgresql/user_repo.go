@@ -4,6 +4,8 @@ import (
 	"context"
 	"reviewer-service/internal/domain/user"
 	storageUser "reviewer-service/internal/storage/postgresql/user"
+
+	"github.com/jackc/pgx/v5"
 )
 
 func (s *Storage) CreateUser(ctx context.Context, u *user.Model) (int64, error) {
@@ -16,14 +18,17 @@ func (s *Storage) CreateUser(ctx context.Context, u *user.Model) (int64, error)
 
 	sql := `
 		INSERT INTO users 
-    		(user_id, username, team_name, is_active) 
+    		(user_id, username, team_name, is_active, chat_handle, max_open_reviews, seniority) 
 		VALUES 
-    		($1, $2, $3, $4)
+    		($1, $2, $3, $4, $5, NULLIF($6, 0), NULLIF($7, ''))
 		ON CONFLICT (user_id) 
 		DO UPDATE SET 
 			username = EXCLUDED.username,
 			team_name = EXCLUDED.team_name,
-			is_active = EXCLUDED.is_active
+			is_active = EXCLUDED.is_active,
+			chat_handle = COALESCE(NULLIF(EXCLUDED.chat_handle, ''), users.chat_handle),
+			max_open_reviews = COALESCE(EXCLUDED.max_open_reviews, users.max_open_reviews),
+			seniority = COALESCE(EXCLUDED.seniority, users.seniority)
 		RETURNING id
 	`
 	if hasTx {
@@ -34,6 +39,9 @@ func (s *Storage) CreateUser(ctx context.Context, u *user.Model) (int64, error)
 			entity.Username,
 			entity.TeamName,
 			entity.IsActive,
+			entity.ChatHandle,
+			entity.MaxOpenReviews,
+			entity.Seniority,
 		).Scan(&id)
 	} else {
 		err = pool.QueryRow(
@@ -43,6 +51,9 @@ func (s *Storage) CreateUser(ctx context.Context, u *user.Model) (int64, error)
 			entity.Username,
 			entity.TeamName,
 			entity.IsActive,
+			entity.ChatHandle,
+			entity.MaxOpenReviews,
+			entity.Seniority,
 		).Scan(&id)
 	}
 
@@ -58,19 +69,19 @@ func (s *Storage) GetUser(ctx context.Context, id int64) (*user.Model, error) {
 	var err error
 
 	tx, pool, hasTx := s.getTx(ctx)
-	sql := "SELECT id, user_id, username, team_name, is_active FROM users WHERE id=$1"
+	sql := "SELECT id, user_id, username, team_name, is_active, chat_handle, COALESCE(max_open_reviews, 0), COALESCE(seniority, '') FROM users WHERE id=$1"
 	if hasTx {
 		err = tx.QueryRow(
 			ctx,
 			sql,
 			id,
-		).Scan(&entity.ID, &entity.UserId, &entity.Username, &entity.TeamName, &entity.IsActive)
+		).Scan(&entity.ID, &entity.UserId, &entity.Username, &entity.TeamName, &entity.IsActive, &entity.ChatHandle, &entity.MaxOpenReviews, &entity.Seniority)
 	} else {
 		err = pool.QueryRow(
 			ctx,
 			sql,
 			id,
-		).Scan(&entity.ID, &entity.UserId, &entity.Username, &entity.TeamName, &entity.IsActive)
+		).Scan(&entity.ID, &entity.UserId, &entity.Username, &entity.TeamName, &entity.IsActive, &entity.ChatHandle, &entity.MaxOpenReviews, &entity.Seniority)
 	}
 
 	if err != nil {
@@ -85,19 +96,19 @@ func (s *Storage) GetUserByUserId(ctx context.Context, userId string) (*user.Mod
 	var err error
 
 	tx, pool, hasTx := s.getTx(ctx)
-	sql := "SELECT id, user_id, username, team_name, is_active FROM users WHERE user_id=$1"
+	sql := "SELECT id, user_id, username, team_name, is_active, chat_handle, COALESCE(max_open_reviews, 0), COALESCE(seniority, '') FROM users WHERE user_id=$1"
 	if hasTx {
 		err = tx.QueryRow(
 			ctx,
 			sql,
 			userId,
-		).Scan(&entity.ID, &entity.UserId, &entity.Username, &entity.TeamName, &entity.IsActive)
+		).Scan(&entity.ID, &entity.UserId, &entity.Username, &entity.TeamName, &entity.IsActive, &entity.ChatHandle, &entity.MaxOpenReviews, &entity.Seniority)
 	} else {
 		err = pool.QueryRow(
 			ctx,
 			sql,
 			userId,
-		).Scan(&entity.ID, &entity.UserId, &entity.Username, &entity.TeamName, &entity.IsActive)
+		).Scan(&entity.ID, &entity.UserId, &entity.Username, &entity.TeamName, &entity.IsActive, &entity.ChatHandle, &entity.MaxOpenReviews, &entity.Seniority)
 	}
 
 	if err != nil {
@@ -142,3 +153,120 @@ func (s *Storage) UpdateUserIsActive(ctx context.Context, userId string, isActiv
 
 	return id, nil
 }
+
+func (s *Storage) UpdateUserChatHandle(ctx context.Context, userId string, chatHandle string) (int64, error) {
+	tx, pool, hasTx := s.getTx(ctx)
+
+	sql := `
+		UPDATE users 
+		SET chat_handle = $1 
+		WHERE user_id = $2 
+		RETURNING id
+	`
+
+	var id int64
+	var err error
+
+	if hasTx {
+		err = tx.QueryRow(ctx, sql, chatHandle, userId).Scan(&id)
+	} else {
+		err = pool.QueryRow(ctx, sql, chatHandle, userId).Scan(&id)
+	}
+
+	if err != nil {
+		return 0, storageUser.MapPGError(err)
+	}
+
+	return id, nil
+}
+
+// UpdateUserMaxOpenReviews задает лимит открытых ревью; 0 возвращает лимит команды
+func (s *Storage) UpdateUserMaxOpenReviews(ctx context.Context, userId string, maxOpenReviews int) (int64, error) {
+	tx, pool, hasTx := s.getTx(ctx)
+
+	sql := `
+		UPDATE users 
+		SET max_open_reviews = NULLIF($1, 0) 
+		WHERE user_id = $2 
+		RETURNING id
+	`
+
+	var id int64
+	var err error
+
+	if hasTx {
+		err = tx.QueryRow(ctx, sql, maxOpenReviews, userId).Scan(&id)
+	} else {
+		err = pool.QueryRow(ctx, sql, maxOpenReviews, userId).Scan(&id)
+	}
+
+	if err != nil {
+		return 0, storageUser.MapPGError(err)
+	}
+
+	return id, nil
+}
+
+// UpdateUserSeniority задает уровень пользователя; пустая строка снимает уровень
+func (s *Storage) UpdateUserSeniority(ctx context.Context, userId string, seniority string) (int64, error) {
+	tx, pool, hasTx := s.getTx(ctx)
+
+	sql := `
+		UPDATE users 
+		SET seniority = NULLIF($1, '') 
+		WHERE user_id = $2 
+		RETURNING id
+	`
+
+	var id int64
+	var err error
+
+	if hasTx {
+		err = tx.QueryRow(ctx, sql, seniority, userId).Scan(&id)
+	} else {
+		err = pool.QueryRow(ctx, sql, seniority, userId).Scan(&id)
+	}
+
+	if err != nil {
+		return 0, storageUser.MapPGError(err)
+	}
+
+	return id, nil
+}
+
+// GetUserSeniorities возвращает уровни пользователей из userIds; пользователи
+// без уровня в результат не попадают
+func (s *Storage) GetUserSeniorities(ctx context.Context, userIds []string) (map[string]string, error) {
+	tx, pool, hasTx := s.getTx(ctx)
+
+	sql := "SELECT user_id, seniority FROM users WHERE user_id = ANY($1::text[]) AND seniority IS NOT NULL"
+
+	var rows pgx.Rows
+	var err error
+
+	if hasTx {
+		rows, err = tx.Query(ctx, sql, userIds)
+	} else {
+		rows, err = pool.Query(ctx, sql, userIds)
+	}
+
+	if err != nil {
+		return nil, storageUser.MapPGError(err)
+	}
+	defer rows.Close()
+
+	seniorities := make(map[string]string)
+	for rows.Next() {
+		var userId, seniority string
+		if err := rows.Scan(&userId, &seniority); err != nil {
+			return nil, storageUser.MapPGError(err)
+		}
+		seniorities[userId] = seniority
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, storageUser.MapPGError(err)
+	}
+
+	return seniorities, nil
+}
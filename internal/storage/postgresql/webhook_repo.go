@@ -0,0 +1,223 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"reviewer-service/internal/domain/webhook"
+	storageIdentity "reviewer-service/internal/storage/postgresql/identity"
+
+	"github.com/jackc/pgx/v5"
+)
+
+func (s *Storage) RecordWebhookDelivery(ctx context.Context, provider string, deliveryId string, event string) (bool, error) {
+	tx, pool, hasTx := s.getTx(ctx)
+
+	sql := `
+		INSERT INTO webhook_deliveries 
+			(provider, delivery_id, event) 
+		VALUES 
+			($1, $2, $3)
+		ON CONFLICT (provider, delivery_id) DO NOTHING
+		RETURNING delivery_id
+	`
+
+	var recorded string
+	var err error
+	if hasTx {
+		err = tx.QueryRow(ctx, sql, provider, deliveryId, event).Scan(&recorded)
+	} else {
+		err = pool.QueryRow(ctx, sql, provider, deliveryId, event).Scan(&recorded)
+	}
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (s *Storage) GetIdentity(ctx context.Context, provider string, login string) (*webhook.Identity, error) {
+	tx, pool, hasTx := s.getTx(ctx)
+
+	query := `
+		SELECT id, provider, login, user_id, external_id
+		FROM git_identities 
+		WHERE provider = $1 AND login = $2
+	`
+
+	var row pgx.Row
+	if hasTx {
+		row = tx.QueryRow(ctx, query, provider, login)
+	} else {
+		row = pool.QueryRow(ctx, query, provider, login)
+	}
+
+	var entity storageIdentity.Entity
+	if err := row.Scan(&entity.ID, &entity.Provider, &entity.Login, &entity.UserId, &entity.ExternalId); err != nil {
+		return nil, storageIdentity.MapPGError(err)
+	}
+
+	return storageIdentity.ToDomain(&entity), nil
+}
+
+// GetIdentityByExternalId возвращает сопоставление по числовому id пользователя
+// на хостинге provider
+func (s *Storage) GetIdentityByExternalId(ctx context.Context, provider string, externalId string) (*webhook.Identity, error) {
+	tx, pool, hasTx := s.getTx(ctx)
+
+	query := `
+		SELECT id, provider, login, user_id, external_id
+		FROM git_identities 
+		WHERE provider = $1 AND external_id = $2
+	`
+
+	var row pgx.Row
+	if hasTx {
+		row = tx.QueryRow(ctx, query, provider, externalId)
+	} else {
+		row = pool.QueryRow(ctx, query, provider, externalId)
+	}
+
+	var entity storageIdentity.Entity
+	if err := row.Scan(&entity.ID, &entity.Provider, &entity.Login, &entity.UserId, &entity.ExternalId); err != nil {
+		return nil, storageIdentity.MapPGError(err)
+	}
+
+	return storageIdentity.ToDomain(&entity), nil
+}
+
+// GetIdentityByUserId возвращает логин пользователя на хостинге provider.
+// Если логинов несколько, берется добавленный первым
+func (s *Storage) GetIdentityByUserId(ctx context.Context, provider string, userId string) (*webhook.Identity, error) {
+	tx, pool, hasTx := s.getTx(ctx)
+
+	query := `
+		SELECT id, provider, login, user_id, external_id
+		FROM git_identities 
+		WHERE provider = $1 AND user_id = $2
+		ORDER BY id
+		LIMIT 1
+	`
+
+	var row pgx.Row
+	if hasTx {
+		row = tx.QueryRow(ctx, query, provider, userId)
+	} else {
+		row = pool.QueryRow(ctx, query, provider, userId)
+	}
+
+	var entity storageIdentity.Entity
+	if err := row.Scan(&entity.ID, &entity.Provider, &entity.Login, &entity.UserId, &entity.ExternalId); err != nil {
+		return nil, storageIdentity.MapPGError(err)
+	}
+
+	return storageIdentity.ToDomain(&entity), nil
+}
+
+func (s *Storage) SetIdentity(ctx context.Context, identity *webhook.Identity) (*webhook.Identity, error) {
+	entity := storageIdentity.ToEntity(identity)
+
+	tx, pool, hasTx := s.getTx(ctx)
+
+	sql := `
+		INSERT INTO git_identities 
+			(provider, login, user_id, external_id) 
+		VALUES 
+			($1, $2, $3, $4)
+		ON CONFLICT (provider, login) 
+		DO UPDATE SET user_id = EXCLUDED.user_id, external_id = EXCLUDED.external_id
+		RETURNING id, provider, login, user_id, external_id
+	`
+
+	var row pgx.Row
+	if hasTx {
+		row = tx.QueryRow(ctx, sql, entity.Provider, entity.Login, entity.UserId, entity.ExternalId)
+	} else {
+		row = pool.QueryRow(ctx, sql, entity.Provider, entity.Login, entity.UserId, entity.ExternalId)
+	}
+
+	var saved storageIdentity.Entity
+	if err := row.Scan(&saved.ID, &saved.Provider, &saved.Login, &saved.UserId, &saved.ExternalId); err != nil {
+		return nil, storageIdentity.MapPGError(err)
+	}
+
+	return storageIdentity.ToDomain(&saved), nil
+}
+
+func (s *Storage) DeleteIdentity(ctx context.Context, provider string, login string) error {
+	tx, pool, hasTx := s.getTx(ctx)
+
+	sql := `
+		DELETE FROM git_identities 
+		WHERE provider = $1 AND login = $2
+		RETURNING id
+	`
+
+	var id int64
+	var err error
+	if hasTx {
+		err = tx.QueryRow(ctx, sql, provider, login).Scan(&id)
+	} else {
+		err = pool.QueryRow(ctx, sql, provider, login).Scan(&id)
+	}
+
+	if err != nil {
+		return storageIdentity.MapPGError(err)
+	}
+
+	return nil
+}
+
+// ListIdentities возвращает сопоставления хостинга provider, пустой provider - все
+func (s *Storage) ListIdentities(ctx context.Context, provider string) ([]*webhook.Identity, error) {
+	tx, pool, hasTx := s.getTx(ctx)
+
+	query := `
+		SELECT id, provider, login, user_id, external_id
+		FROM git_identities 
+		WHERE $1 = '' OR provider = $1
+		ORDER BY provider, login
+	`
+
+	var rows pgx.Rows
+	var err error
+
+	if hasTx {
+		rows, err = tx.Query(ctx, query, provider)
+	} else {
+		rows, err = pool.Query(ctx, query, provider)
+	}
+
+	if err != nil {
+		return nil, storageIdentity.MapPGError(err)
+	}
+	defer rows.Close()
+
+	identities := make([]*webhook.Identity, 0)
+	for rows.Next() {
+		var entity storageIdentity.Entity
+		if err := rows.Scan(&entity.ID, &entity.Provider, &entity.Login, &entity.UserId, &entity.ExternalId); err != nil {
+			return nil, storageIdentity.MapPGError(err)
+		}
+		identities = append(identities, storageIdentity.ToDomain(&entity))
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, storageIdentity.MapPGError(err)
+	}
+
+	return identities, nil
+}
+
+// GetUserIdByGitLogin возвращает user_id, привязанный к логину на хостинге provider
+func (s *Storage) GetUserIdByGitLogin(ctx context.Context, provider string, login string) (string, error) {
+	identity, err := s.GetIdentity(ctx, provider, login)
+	if err != nil {
+		return "", err
+	}
+
+	return identity.UserId, nil
+}
@@ -23,6 +23,38 @@ var (
 	ErrPullRequestMerged        = &Error{Code: "PR_MERGED", Message: "cannot reassign on merged PR"}
 	ErrReviewerNotAssigned      = &Error{Code: "NOT_ASSIGNED", Message: "reviewer is not assigned to this PR"}
 	ErrNoReplacementCandidate   = &Error{Code: "NO_CANDIDATE", Message: "no active replacement candidate in team"}
+	ErrPullRequestVersion       = &Error{Code: "VERSION_CONFLICT", Message: "pull request was modified, version does not match If-Match"}
+	ErrPullRequestClosed        = &Error{Code: "PR_CLOSED", Message: "pull request is closed"}
+	ErrPullRequestDraft         = &Error{Code: "PR_DRAFT", Message: "pull request is a draft"}
+
+	ErrAPIKeyNotFound = &Error{Code: "NOT_FOUND", Message: "api key not found"}
+	ErrUnknownScope   = &Error{Code: "INVALID_SCOPE", Message: "unknown scope"}
+	ErrUnauthorized   = &Error{Code: "UNAUTHORIZED", Message: "missing, invalid or revoked credentials"}
+	ErrForbidden      = &Error{Code: "FORBIDDEN", Message: "not allowed to perform this operation"}
+
+	ErrInvalidRole  = &Error{Code: "INVALID_ROLE", Message: "role must be admin (without team) or team_lead (with team_name)"}
+	ErrRoleNotFound = &Error{Code: "NOT_FOUND", Message: "role not found"}
+
+	ErrIdentityNotFound      = &Error{Code: "NOT_FOUND", Message: "git identity not found"}
+	ErrExternalIdAlreadyUsed = &Error{Code: "IDENTITY_EXISTS", Message: "external_id is already mapped to another login"}
+	ErrInvalidSignature      = &Error{Code: "INVALID_SIGNATURE", Message: "webhook signature or token is invalid"}
+
+	ErrSubscriptionNotFound = &Error{Code: "NOT_FOUND", Message: "subscription not found"}
+	ErrDeliveryNotFound     = &Error{Code: "NOT_FOUND", Message: "subscription delivery not found"}
+	ErrUnknownEventType     = &Error{Code: "INVALID_EVENT_TYPE", Message: "unknown event type"}
+	ErrForbiddenTarget      = &Error{Code: "INVALID_URL", Message: "subscription url must be http or https and must not point to a loopback, link-local or private address"}
+
+	ErrTeamSLANotFound = &Error{Code: "NOT_FOUND", Message: "review sla is not configured for team"}
+
+	ErrUnavailabilityNotFound = &Error{Code: "NOT_FOUND", Message: "unavailability period not found"}
+
+	ErrInvalidCodeOwnerPattern = &Error{Code: "INVALID_PATTERN", Message: "invalid code owners pattern"}
+	ErrCodeOwnerNotMember      = &Error{Code: "UNKNOWN_OWNER", Message: "code owner is not a member of the team"}
+
+	ErrExcludedPairNotMember = &Error{Code: "UNKNOWN_MEMBER", Message: "user in excluded pair is not a member of the team"}
+
+	ErrRepositoryNotFound      = &Error{Code: "NOT_FOUND", Message: "repository not found"}
+	ErrRepositoryAlreadyExists = &Error{Code: "REPOSITORY_EXISTS", Message: "repository with this host and name already exists"}
 )
 
 func IsError(err error) (*Error, bool) {
@@ -0,0 +1,166 @@
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"reviewer-service/internal/domain/event"
+	"reviewer-service/internal/lib/metrics"
+	"slices"
+	"sync"
+)
+
+// Types - события, которые транслируются в поток по умолчанию
+var Types = []string{
+	event.TypePullRequestCreated,
+	event.TypePullRequestMerged,
+	event.TypePullRequestClosed,
+	event.TypePullRequestReopened,
+	event.TypePullRequestDrafted,
+	event.TypePullRequestReady,
+	event.TypeReviewerAssigned,
+	event.TypeReviewerReassigned,
+	event.TypeSLABreached,
+}
+
+// Filter отбирает события для клиента. Пустые поля не ограничивают выборку
+type Filter struct {
+	Types    []string
+	TeamName string
+	// UserId - автор PR или ревьювер, которого касается событие
+	UserId string
+}
+
+func (f *Filter) Match(e *event.Event) bool {
+	types := f.Types
+	if len(types) == 0 {
+		types = Types
+	}
+	if !slices.Contains(types, e.Type) {
+		return false
+	}
+
+	if f.TeamName != "" && f.TeamName != e.TeamName {
+		return false
+	}
+
+	if f.UserId != "" && !slices.Contains(involvedUsers(e), f.UserId) {
+		return false
+	}
+
+	return true
+}
+
+// involvement объединяет поля пользователей из payload событий PR
+type involvement struct {
+	AuthorId           string   `json:"author_id"`
+	ReviewerId         string   `json:"reviewer_id"`
+	ReplacedReviewerId string   `json:"replaced_reviewer_id"`
+	OldReviewerId      string   `json:"old_reviewer_id"`
+	NewReviewerId      string   `json:"new_reviewer_id"`
+	AssignedReviewers  []string `json:"assigned_reviewers"`
+}
+
+func involvedUsers(e *event.Event) []string {
+	var inv involvement
+	if err := json.Unmarshal(e.Payload, &inv); err != nil {
+		return nil
+	}
+
+	return append(inv.AssignedReviewers, inv.AuthorId, inv.ReviewerId, inv.ReplacedReviewerId, inv.OldReviewerId, inv.NewReviewerId)
+}
+
+// Subscription - подписка клиента на хаб. Канал Events закрывается при
+// отписке или если клиент не успевает забирать события (Lagged)
+type Subscription struct {
+	filter Filter
+	events chan *event.Event
+	lagged bool
+}
+
+func (s *Subscription) Events() <-chan *event.Event {
+	return s.events
+}
+
+// Lagged сообщает, что подписка закрыта из-за переполнения буфера. Клиент
+// должен переподключиться с Last-Event-ID и дочитать пропущенное из журнала.
+// Читать можно только после закрытия канала Events
+func (s *Subscription) Lagged() bool {
+	return s.lagged
+}
+
+// Hub раздает события outbox подключенным клиентам. Публикация не блокируется:
+// у каждого клиента свой буфер, и медленный клиент отключается, а не задерживает
+// остальных
+type Hub struct {
+	mu         sync.Mutex
+	subs       map[*Subscription]struct{}
+	bufferSize int
+}
+
+func NewHub(bufferSize int) *Hub {
+	if bufferSize <= 0 {
+		bufferSize = 64
+	}
+
+	return &Hub{
+		subs:       make(map[*Subscription]struct{}),
+		bufferSize: bufferSize,
+	}
+}
+
+func (h *Hub) Subscribe(filter Filter) *Subscription {
+	s := &Subscription{
+		filter: filter,
+		events: make(chan *event.Event, h.bufferSize),
+	}
+
+	h.mu.Lock()
+	h.subs[s] = struct{}{}
+	h.mu.Unlock()
+
+	metrics.StreamClients.Inc()
+
+	return s
+}
+
+func (h *Hub) Unsubscribe(s *Subscription) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.remove(s)
+}
+
+// Send реализует outbox.Sink и никогда не возвращает ошибку
+func (h *Hub) Send(ctx context.Context, e *event.Event) error {
+	h.Publish(e)
+	return nil
+}
+
+func (h *Hub) Publish(e *event.Event) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for s := range h.subs {
+		if !s.filter.Match(e) {
+			continue
+		}
+
+		select {
+		case s.events <- e:
+		default:
+			s.lagged = true
+			h.remove(s)
+			metrics.StreamLaggedClients.Inc()
+		}
+	}
+}
+
+func (h *Hub) remove(s *Subscription) {
+	if _, ok := h.subs[s]; !ok {
+		return
+	}
+
+	delete(h.subs, s)
+	close(s.events)
+	metrics.StreamClients.Dec()
+}
@@ -707,3 +707,98 @@ func TestPullRequestCreate_OnlyInactiveReviewers(t *testing.T) {
 	require.True(t, ok)
 	assert.Len(t, reviewers, 0)
 }
+
+func TestPullRequestMerge_IfMatchMismatch(t *testing.T) {
+	ts, err := SetupTestServer(t)
+	require.NoError(t, err)
+	defer ts.Close()
+
+	ctx := context.Background()
+	_, err = ts.Storage.Db.Exec(ctx, `
+		INSERT INTO team (name) VALUES ('backend');
+		INSERT INTO users (user_id, username, team_name, is_active) VALUES 
+			('u1', 'Alice', 'backend', true);
+		INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status, version) VALUES 
+			('pr-1', 'PR 1', 'u1', 'OPEN', 3);
+	`)
+	require.NoError(t, err)
+
+	reqBody := map[string]interface{}{
+		"pull_request_id": "pr-1",
+	}
+
+	body, _ := json.Marshal(reqBody)
+	req := httptest.NewRequest("POST", "/pullRequest/merge", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-Match", `"2"`)
+	w := httptest.NewRecorder()
+
+	ts.Server.Handler.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusPreconditionFailed, w.Code)
+
+	var response map[string]interface{}
+	err = json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+
+	errorObj, ok := response["error"].(map[string]interface{})
+	require.True(t, ok)
+	assert.Equal(t, "VERSION_CONFLICT", errorObj["code"])
+
+	req = httptest.NewRequest("POST", "/pullRequest/merge", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-Match", `"3"`)
+	w = httptest.NewRecorder()
+
+	ts.Server.Handler.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `"4"`, w.Header().Get("ETag"))
+}
+
+func TestPullRequestReassign_ConcurrentSameVersion(t *testing.T) {
+	ts, err := SetupTestServer(t)
+	require.NoError(t, err)
+	defer ts.Close()
+
+	ctx := context.Background()
+	_, err = ts.Storage.Db.Exec(ctx, `
+		INSERT INTO team (name) VALUES ('backend');
+		INSERT INTO users (user_id, username, team_name, is_active) VALUES 
+			('u1', 'Alice', 'backend', true),
+			('u2', 'Bob', 'backend', true),
+			('u3', 'Charlie', 'backend', true),
+			('u4', 'Dave', 'backend', true),
+			('u5', 'Eve', 'backend', true);
+		INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status) VALUES 
+			('pr-1', 'PR 1', 'u1', 'OPEN');
+		INSERT INTO pr_reviewers (pull_request_id, user_id) VALUES ('pr-1', 'u2'), ('pr-1', 'u3');
+	`)
+	require.NoError(t, err)
+
+	reassign := func(oldReviewerId string, codes chan<- int) {
+		body, _ := json.Marshal(map[string]interface{}{
+			"pull_request_id": "pr-1",
+			"old_reviewer_id": oldReviewerId,
+		})
+		req := httptest.NewRequest("POST", "/pullRequest/reassign", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("If-Match", `"1"`)
+		w := httptest.NewRecorder()
+
+		ts.Server.Handler.ServeHTTP(w, req)
+		codes <- w.Code
+	}
+
+	codes := make(chan int, 2)
+	go reassign("u2", codes)
+	go reassign("u3", codes)
+
+	results := []int{<-codes, <-codes}
+	assert.ElementsMatch(t, []int{http.StatusOK, http.StatusPreconditionFailed}, results)
+
+	var version int64
+	err = ts.Storage.Db.QueryRow(ctx, `SELECT version FROM pull_requests WHERE pull_request_id = 'pr-1'`).Scan(&version)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), version)
+}
@@ -0,0 +1,140 @@
+package integration
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testBootstrapKey = "test-bootstrap-key"
+
+func TestAuth_MissingKey(t *testing.T) {
+	ts, err := SetupTestServerWithAuth(t, testBootstrapKey)
+	require.NoError(t, err)
+	defer ts.Close()
+
+	req := httptest.NewRequest("GET", "/team/get?team_name=backend", nil)
+	w := httptest.NewRecorder()
+
+	ts.Server.Handler.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	var response map[string]interface{}
+	err = json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+
+	errorObj, ok := response["error"].(map[string]interface{})
+	require.True(t, ok)
+	assert.Equal(t, "UNAUTHORIZED", errorObj["code"])
+}
+
+func TestAuth_InvalidKey(t *testing.T) {
+	ts, err := SetupTestServerWithAuth(t, testBootstrapKey)
+	require.NoError(t, err)
+	defer ts.Close()
+
+	req := httptest.NewRequest("GET", "/team/get?team_name=backend", nil)
+	req.Header.Set("X-API-Key", "rs_not-a-real-key")
+	w := httptest.NewRecorder()
+
+	ts.Server.Handler.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestAPIKeys_CreateScopeRevoke(t *testing.T) {
+	ts, err := SetupTestServerWithAuth(t, testBootstrapKey)
+	require.NoError(t, err)
+	defer ts.Close()
+
+	body, _ := json.Marshal(map[string]interface{}{
+		"name":   "dashboard",
+		"scopes": []string{"read"},
+	})
+	req := httptest.NewRequest("POST", "/admin/apiKeys/create", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-API-Key", testBootstrapKey)
+	w := httptest.NewRecorder()
+
+	ts.Server.Handler.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusCreated, w.Code)
+
+	var created map[string]interface{}
+	err = json.Unmarshal(w.Body.Bytes(), &created)
+	require.NoError(t, err)
+
+	rawKey, ok := created["key"].(string)
+	require.True(t, ok)
+	apiKey, ok := created["api_key"].(map[string]interface{})
+	require.True(t, ok)
+	assert.Equal(t, "dashboard", apiKey["name"])
+
+	var keyHash string
+	err = ts.Storage.Db.QueryRow(req.Context(), `SELECT key_hash FROM api_keys WHERE name = 'dashboard'`).Scan(&keyHash)
+	require.NoError(t, err)
+	assert.NotEqual(t, rawKey, keyHash)
+
+	req = httptest.NewRequest("GET", "/team/get?team_name=backend", nil)
+	req.Header.Set("X-API-Key", rawKey)
+	w = httptest.NewRecorder()
+	ts.Server.Handler.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	teamBody, _ := json.Marshal(map[string]interface{}{
+		"team": map[string]interface{}{"team_name": "backend", "members": []interface{}{}},
+	})
+	req = httptest.NewRequest("POST", "/team/add", bytes.NewReader(teamBody))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-API-Key", rawKey)
+	w = httptest.NewRecorder()
+	ts.Server.Handler.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	revokeBody, _ := json.Marshal(map[string]interface{}{"id": apiKey["id"]})
+	req = httptest.NewRequest("POST", "/admin/apiKeys/revoke", bytes.NewReader(revokeBody))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-API-Key", testBootstrapKey)
+	w = httptest.NewRecorder()
+	ts.Server.Handler.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	req = httptest.NewRequest("GET", "/team/get?team_name=backend", nil)
+	req.Header.Set("X-API-Key", rawKey)
+	w = httptest.NewRecorder()
+	ts.Server.Handler.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestAPIKeys_UnknownScope(t *testing.T) {
+	ts, err := SetupTestServerWithAuth(t, testBootstrapKey)
+	require.NoError(t, err)
+	defer ts.Close()
+
+	body, _ := json.Marshal(map[string]interface{}{
+		"name":   "ci",
+		"scopes": []string{"everything"},
+	})
+	req := httptest.NewRequest("POST", "/admin/apiKeys/create", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-API-Key", testBootstrapKey)
+	w := httptest.NewRecorder()
+
+	ts.Server.Handler.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	var response map[string]interface{}
+	err = json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+
+	errorObj, ok := response["error"].(map[string]interface{})
+	require.True(t, ok)
+	assert.Equal(t, "INVALID_SCOPE", errorObj["code"])
+}
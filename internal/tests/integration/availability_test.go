@@ -0,0 +1,183 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reviewer-service/internal/availability"
+	"reviewer-service/internal/config"
+	"reviewer-service/internal/domain/event"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupAvailabilityTeam(t *testing.T, ts *TestServer) {
+	w := postJSON(ts, "/team/add", map[string]interface{}{
+		"team": map[string]interface{}{
+			"team_name": "backend",
+			"members": []map[string]interface{}{
+				{"user_id": "u1", "username": "Alice", "is_active": true},
+				{"user_id": "u2", "username": "Bob", "is_active": true},
+				{"user_id": "u3", "username": "Charlie", "is_active": true},
+				{"user_id": "u4", "username": "Dave", "is_active": true},
+			},
+		},
+	})
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+}
+
+func createUnavailability(t *testing.T, ts *TestServer, userId string, startsAt time.Time, endsAt time.Time) int64 {
+	w := postJSON(ts, "/users/unavailability/create", map[string]interface{}{
+		"user_id":   userId,
+		"starts_at": startsAt.Format(time.RFC3339),
+		"ends_at":   endsAt.Format(time.RFC3339),
+		"reason":    "vacation",
+	})
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	var resp struct {
+		Period struct {
+			ID int64 `json:"id"`
+		} `json:"period"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	return resp.Period.ID
+}
+
+func TestUnavailability_CreateListDelete(t *testing.T) {
+	ts, err := SetupTestServer(t)
+	require.NoError(t, err)
+	defer ts.Close()
+
+	setupAvailabilityTeam(t, ts)
+
+	startsAt := time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)
+	id := createUnavailability(t, ts, "u2", startsAt, startsAt.AddDate(0, 0, 14))
+
+	req := httptest.NewRequest("GET", "/users/unavailability/list?user_id=u2", nil)
+	w := httptest.NewRecorder()
+	ts.Server.Handler.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var list struct {
+		Periods []struct {
+			ID       int64     `json:"id"`
+			StartsAt time.Time `json:"starts_at"`
+			Reason   string    `json:"reason"`
+		} `json:"periods"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+	require.Len(t, list.Periods, 1)
+	assert.Equal(t, id, list.Periods[0].ID)
+	assert.True(t, list.Periods[0].StartsAt.Equal(startsAt))
+	assert.Equal(t, "vacation", list.Periods[0].Reason)
+
+	w = postJSON(ts, "/users/unavailability/create", map[string]interface{}{
+		"user_id":   "u2",
+		"starts_at": "2026-07-15T00:00:00Z",
+		"ends_at":   "2026-07-01T00:00:00Z",
+	})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = postJSON(ts, "/users/unavailability/create", map[string]interface{}{
+		"user_id":   "missing",
+		"starts_at": "2026-07-01T00:00:00Z",
+		"ends_at":   "2026-07-15T00:00:00Z",
+	})
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	w = postJSON(ts, "/users/unavailability/delete", map[string]interface{}{"id": id})
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = postJSON(ts, "/users/unavailability/delete", map[string]interface{}{"id": id})
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestUnavailability_ExcludedFromSelection(t *testing.T) {
+	ts, err := SetupTestServer(t)
+	require.NoError(t, err)
+	defer ts.Close()
+
+	setupAvailabilityTeam(t, ts)
+
+	now := time.Now()
+	createUnavailability(t, ts, "u2", now.Add(-time.Hour), now.Add(24*time.Hour))
+	// Будущий период пока не влияет на выбор
+	createUnavailability(t, ts, "u3", now.Add(time.Hour), now.Add(24*time.Hour))
+
+	createPR(t, ts, "pr-1", "u1")
+
+	pr, err := ts.Storage.GetPullRequestById(context.Background(), "pr-1")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"u3", "u4"}, pr.AssignedReviewers)
+
+	// Замена тоже не выбирает отсутствующего
+	w := postJSON(ts, "/pullRequest/reassign", map[string]interface{}{
+		"pull_request_id": "pr-1",
+		"old_reviewer_id": "u3",
+	})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	pr, err = ts.Storage.GetPullRequestById(context.Background(), "pr-1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u4"}, pr.AssignedReviewers)
+}
+
+func TestUnavailability_SchedulerReassignsOpenReviews(t *testing.T) {
+	ts, err := SetupTestServer(t)
+	require.NoError(t, err)
+	defer ts.Close()
+
+	setupAvailabilityTeam(t, ts)
+
+	createPR(t, ts, "pr-1", "u1")
+	createPR(t, ts, "pr-2", "u1")
+
+	w := postJSON(ts, "/pullRequest/merge", map[string]interface{}{"pull_request_id": "pr-2"})
+	require.Equal(t, http.StatusOK, w.Code)
+
+	pr, err := ts.Storage.GetPullRequestById(context.Background(), "pr-1")
+	require.NoError(t, err)
+	require.Equal(t, []string{"u2", "u3"}, pr.AssignedReviewers)
+
+	scheduler := availability.NewScheduler(config.MustConfigureLogger("test"), ts.Storage, ts.Storage, ts.Storage, nil, availability.Options{})
+
+	now := time.Now()
+	createUnavailability(t, ts, "u3", now.Add(time.Hour), now.Add(24*time.Hour))
+
+	reassigned, err := scheduler.ReassignOnce(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 0, reassigned)
+
+	// Период начался: ревью открытого PR уходит к свободному участнику, смерженный PR не трогаем
+	createUnavailability(t, ts, "u2", now.Add(-time.Minute), now.Add(24*time.Hour))
+
+	reassigned, err = scheduler.ReassignOnce(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, reassigned)
+
+	pr, err = ts.Storage.GetPullRequestById(context.Background(), "pr-1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u3", "u4"}, pr.AssignedReviewers)
+
+	merged, err := ts.Storage.GetPullRequestById(context.Background(), "pr-2")
+	require.NoError(t, err)
+	assert.Contains(t, merged.AssignedReviewers, "u2")
+
+	reassigned, err = scheduler.ReassignOnce(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 0, reassigned)
+
+	events := outboxEvents(t, ts, event.TypeReviewerReassigned)
+	require.Len(t, events, 1)
+
+	var payload event.ReviewerReassignedPayload
+	require.NoError(t, json.Unmarshal(events[0].Payload, &payload))
+	assert.Equal(t, "u2", payload.OldReviewerId)
+	assert.Equal(t, "u4", payload.NewReviewerId)
+	assert.Equal(t, event.AssignReasonUnavailable, payload.Reason)
+}
@@ -0,0 +1,229 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createPRUnderStaffed(t *testing.T, ts *TestServer, id string, authorId string) bool {
+	w := postJSON(ts, "/pullRequest/create", map[string]interface{}{
+		"pull_request_id":   id,
+		"pull_request_name": "PR " + id,
+		"author_id":         authorId,
+	})
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	var resp struct {
+		UnderStaffed bool `json:"under_staffed"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	return resp.UnderStaffed
+}
+
+func TestCapacity_UserLimitSkipsBusyReviewer(t *testing.T) {
+	ts, err := SetupTestServer(t)
+	require.NoError(t, err)
+	defer ts.Close()
+
+	w := postJSON(ts, "/team/add", map[string]interface{}{
+		"team": map[string]interface{}{
+			"team_name": "backend",
+			"members": []map[string]interface{}{
+				{"user_id": "u1", "username": "Alice", "is_active": true},
+				{"user_id": "u2", "username": "Bob", "is_active": true, "max_open_reviews": 1},
+				{"user_id": "u3", "username": "Charlie", "is_active": true},
+				{"user_id": "u4", "username": "Dave", "is_active": true},
+			},
+		},
+	})
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	assert.False(t, createPRUnderStaffed(t, ts, "pr-1", "u1"))
+
+	pr, err := ts.Storage.GetPullRequestById(context.Background(), "pr-1")
+	require.NoError(t, err)
+	require.Contains(t, pr.AssignedReviewers, "u2")
+
+	// У u2 уже одно открытое ревью - он больше не выбирается
+	assert.False(t, createPRUnderStaffed(t, ts, "pr-2", "u1"))
+
+	pr, err = ts.Storage.GetPullRequestById(context.Background(), "pr-2")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"u3", "u4"}, pr.AssignedReviewers)
+
+	// После merge ревью больше не считается открытым
+	w = postJSON(ts, "/pullRequest/merge", map[string]interface{}{"pull_request_id": "pr-1"})
+	require.Equal(t, http.StatusOK, w.Code)
+
+	user, err := ts.Storage.GetUserByUserId(context.Background(), "u2")
+	require.NoError(t, err)
+	assert.Equal(t, 1, user.MaxOpenReviews)
+
+	req := httptest.NewRequest("GET", "/team/get?team_name=backend", nil)
+	rec := httptest.NewRecorder()
+	ts.Server.Handler.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"max_open_reviews":1`)
+}
+
+func TestCapacity_TeamDefaultAndUnderStaffed(t *testing.T) {
+	ts, err := SetupTestServer(t)
+	require.NoError(t, err)
+	defer ts.Close()
+
+	w := postJSON(ts, "/team/add", map[string]interface{}{
+		"team": map[string]interface{}{
+			"team_name": "backend",
+			"members": []map[string]interface{}{
+				{"user_id": "u1", "username": "Alice", "is_active": true},
+				{"user_id": "u2", "username": "Bob", "is_active": true},
+				{"user_id": "u3", "username": "Charlie", "is_active": true, "max_open_reviews": 2},
+			},
+		},
+	})
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	w = postJSON(ts, "/team/setDefaultMaxOpenReviews", map[string]interface{}{
+		"team_name":                "backend",
+		"default_max_open_reviews": 1,
+	})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	assert.False(t, createPRUnderStaffed(t, ts, "pr-1", "u1"))
+
+	// u2 на лимите команды, у u3 свой лимит выше
+	assert.True(t, createPRUnderStaffed(t, ts, "pr-2", "u1"))
+
+	pr, err := ts.Storage.GetPullRequestById(context.Background(), "pr-2")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u3"}, pr.AssignedReviewers)
+
+	// Все на лимите: PR создается без ревьюверов
+	assert.True(t, createPRUnderStaffed(t, ts, "pr-3", "u1"))
+
+	pr, err = ts.Storage.GetPullRequestById(context.Background(), "pr-3")
+	require.NoError(t, err)
+	assert.Empty(t, pr.AssignedReviewers)
+
+	// 0 снимает лимит команды, у u3 остается свой
+	w = postJSON(ts, "/team/setDefaultMaxOpenReviews", map[string]interface{}{
+		"team_name":                "backend",
+		"default_max_open_reviews": 0,
+	})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	assert.True(t, createPRUnderStaffed(t, ts, "pr-4", "u1"))
+
+	pr, err = ts.Storage.GetPullRequestById(context.Background(), "pr-4")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u2"}, pr.AssignedReviewers)
+
+	w = postJSON(ts, "/users/setMaxOpenReviews", map[string]interface{}{
+		"user_id":          "u3",
+		"max_open_reviews": 0,
+	})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	assert.False(t, createPRUnderStaffed(t, ts, "pr-5", "u1"))
+}
+
+func TestCapacity_ConcurrentCreatesDoNotOverfill(t *testing.T) {
+	ts, err := SetupTestServer(t)
+	require.NoError(t, err)
+	defer ts.Close()
+
+	w := postJSON(ts, "/team/add", map[string]interface{}{
+		"team": map[string]interface{}{
+			"team_name": "backend",
+			"members": []map[string]interface{}{
+				{"user_id": "u1", "username": "Alice", "is_active": true},
+				{"user_id": "u2", "username": "Bob", "is_active": true},
+				{"user_id": "u3", "username": "Charlie", "is_active": true},
+				{"user_id": "u4", "username": "Dave", "is_active": true},
+			},
+		},
+	})
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	w = postJSON(ts, "/team/setDefaultMaxOpenReviews", map[string]interface{}{
+		"team_name":                "backend",
+		"default_max_open_reviews": 1,
+	})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	const prs = 8
+	codes := make([]int, prs)
+	var wg sync.WaitGroup
+	for i := range prs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			codes[i] = postJSON(ts, "/pullRequest/create", map[string]interface{}{
+				"pull_request_id":   fmt.Sprintf("pr-%d", i),
+				"pull_request_name": "PR",
+				"author_id":         "u1",
+			}).Code
+		}()
+	}
+	wg.Wait()
+
+	for i, code := range codes {
+		assert.Equal(t, http.StatusCreated, code, "pr-%d", i)
+	}
+
+	// Каждый ревьювер получил не больше одного ревью, всего - не больше трех
+	rows, err := ts.Storage.Db.Query(context.Background(), `SELECT user_id, COUNT(*) FROM pr_reviewers GROUP BY user_id`)
+	require.NoError(t, err)
+	defer rows.Close()
+
+	total := 0
+	for rows.Next() {
+		var userId string
+		var count int
+		require.NoError(t, rows.Scan(&userId, &count))
+		assert.Equal(t, 1, count, userId)
+		total += count
+	}
+	require.NoError(t, rows.Err())
+	assert.Equal(t, 3, total)
+}
+
+func TestCapacity_Validation(t *testing.T) {
+	ts, err := SetupTestServer(t)
+	require.NoError(t, err)
+	defer ts.Close()
+
+	setupAvailabilityTeam(t, ts)
+
+	w := postJSON(ts, "/users/setMaxOpenReviews", map[string]interface{}{
+		"user_id":          "u2",
+		"max_open_reviews": -1,
+	})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = postJSON(ts, "/team/setDefaultMaxOpenReviews", map[string]interface{}{
+		"team_name":                "backend",
+		"default_max_open_reviews": -1,
+	})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = postJSON(ts, "/users/setMaxOpenReviews", map[string]interface{}{
+		"user_id":          "missing",
+		"max_open_reviews": 1,
+	})
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	w = postJSON(ts, "/team/setDefaultMaxOpenReviews", map[string]interface{}{
+		"team_name":                "missing",
+		"default_max_open_reviews": 1,
+	})
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -0,0 +1,274 @@
+package integration
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reviewer-service/internal/domain/event"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type codeOwnersCreateResponse struct {
+	PR struct {
+		AssignedReviewers []string `json:"assigned_reviewers"`
+	} `json:"pr"`
+	MatchedRules map[string]string `json:"matched_rules"`
+}
+
+func setCodeOwners(t *testing.T, ts *TestServer) {
+	w := postJSON(ts, "/team/setCodeOwners", map[string]interface{}{
+		"team_name": "backend",
+		"rules": []map[string]interface{}{
+			{"pattern": "*", "owners": []string{"u4"}},
+			{"pattern": "internal/storage/", "owners": []string{"u3"}},
+			{"pattern": "*.sql", "owners": []string{"u3", "u2"}},
+		},
+	})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+}
+
+func createPRWithFiles(t *testing.T, ts *TestServer, id string, files []string) codeOwnersCreateResponse {
+	w := postJSON(ts, "/pullRequest/create", map[string]interface{}{
+		"pull_request_id":   id,
+		"pull_request_name": "PR " + id,
+		"author_id":         "u1",
+		"changed_files":     files,
+	})
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	var resp codeOwnersCreateResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	return resp
+}
+
+func TestCodeOwners_SetAndGet(t *testing.T) {
+	ts, err := SetupTestServer(t)
+	require.NoError(t, err)
+	defer ts.Close()
+
+	setupAvailabilityTeam(t, ts)
+	setCodeOwners(t, ts)
+
+	req := httptest.NewRequest("GET", "/team/getCodeOwners?team_name=backend", nil)
+	w := httptest.NewRecorder()
+	ts.Server.Handler.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var resp struct {
+		Rules []struct {
+			Pattern string   `json:"pattern"`
+			Owners  []string `json:"owners"`
+		} `json:"rules"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Len(t, resp.Rules, 3)
+	assert.Equal(t, "*", resp.Rules[0].Pattern)
+	assert.Equal(t, "*.sql", resp.Rules[2].Pattern)
+	assert.Equal(t, []string{"u3", "u2"}, resp.Rules[2].Owners)
+
+	// Владелец не из команды и невалидный шаблон не сохраняются
+	w = postJSON(ts, "/team/setCodeOwners", map[string]interface{}{
+		"team_name": "backend",
+		"rules":     []map[string]interface{}{{"pattern": "docs/", "owners": []string{"stranger"}}},
+	})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "UNKNOWN_OWNER")
+
+	w = postJSON(ts, "/team/setCodeOwners", map[string]interface{}{
+		"team_name": "backend",
+		"rules":     []map[string]interface{}{{"pattern": "!docs/", "owners": []string{"u2"}}},
+	})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "INVALID_PATTERN")
+
+	w = postJSON(ts, "/team/setCodeOwners", map[string]interface{}{
+		"team_name": "missing",
+		"rules":     []map[string]interface{}{},
+	})
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	// Пустой список удаляет правила
+	w = postJSON(ts, "/team/setCodeOwners", map[string]interface{}{
+		"team_name": "backend",
+		"rules":     []map[string]interface{}{},
+	})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	w = httptest.NewRecorder()
+	ts.Server.Handler.ServeHTTP(w, httptest.NewRequest("GET", "/team/getCodeOwners?team_name=backend", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Empty(t, resp.Rules)
+}
+
+func TestCodeOwners_PreferOwnersOnCreate(t *testing.T) {
+	ts, err := SetupTestServer(t)
+	require.NoError(t, err)
+	defer ts.Close()
+
+	setupAvailabilityTeam(t, ts)
+	setCodeOwners(t, ts)
+
+	// Для файла действует последнее подходящее правило, недостающий ревьювер - обычным способом
+	resp := createPRWithFiles(t, ts, "pr-1", []string{"internal/storage/user_repo.go"})
+	assert.ElementsMatch(t, []string{"u3", "u2"}, resp.PR.AssignedReviewers)
+	assert.Equal(t, map[string]string{"u3": "internal/storage/"}, resp.MatchedRules)
+
+	resp = createPRWithFiles(t, ts, "pr-2", []string{"migrations/001_init.sql", "README.md"})
+	assert.ElementsMatch(t, []string{"u3", "u2"}, resp.PR.AssignedReviewers)
+	assert.Equal(t, map[string]string{"u3": "*.sql", "u2": "*.sql"}, resp.MatchedRules)
+
+	resp = createPRWithFiles(t, ts, "pr-3", []string{"docs/guide.md"})
+	assert.ElementsMatch(t, []string{"u4", "u2"}, resp.PR.AssignedReviewers)
+	assert.Equal(t, map[string]string{"u4": "*"}, resp.MatchedRules)
+
+	// Без changed_files - обычная стратегия
+	resp = createPRWithFiles(t, ts, "pr-4", nil)
+	assert.ElementsMatch(t, []string{"u2", "u3"}, resp.PR.AssignedReviewers)
+	assert.Empty(t, resp.MatchedRules)
+
+	events := outboxEvents(t, ts, event.TypeReviewerAssigned)
+	matched := make(map[string]string)
+	for _, e := range events {
+		var payload event.ReviewerAssignedPayload
+		require.NoError(t, json.Unmarshal(e.Payload, &payload))
+		if payload.PullRequestId == "pr-3" {
+			matched[payload.ReviewerId] = payload.MatchedRule
+		}
+	}
+	assert.Equal(t, map[string]string{"u4": "*", "u2": ""}, matched)
+}
+
+func TestCodeOwners_SkipsUnavailableOwners(t *testing.T) {
+	ts, err := SetupTestServer(t)
+	require.NoError(t, err)
+	defer ts.Close()
+
+	setupAvailabilityTeam(t, ts)
+	setCodeOwners(t, ts)
+
+	w := postJSON(ts, "/users/setIsActive", map[string]interface{}{"user_id": "u3", "is_active": false})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	resp := createPRWithFiles(t, ts, "pr-1", []string{"internal/storage/team_repo.go"})
+	assert.ElementsMatch(t, []string{"u2", "u4"}, resp.PR.AssignedReviewers)
+	assert.Empty(t, resp.MatchedRules)
+
+	// Автор не назначается ревьювером своего PR, даже если он владелец
+	w = postJSON(ts, "/team/setCodeOwners", map[string]interface{}{
+		"team_name": "backend",
+		"rules":     []map[string]interface{}{{"pattern": "/api/**/*.proto", "owners": []string{"u1", "u4"}}},
+	})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	resp = createPRWithFiles(t, ts, "pr-2", []string{"api/proto/reviewer/v1/reviewer.proto"})
+	assert.ElementsMatch(t, []string{"u4", "u2"}, resp.PR.AssignedReviewers)
+	assert.Equal(t, map[string]string{"u4": "/api/**/*.proto"}, resp.MatchedRules)
+}
+
+func TestCodeOwners_Import(t *testing.T) {
+	ts, err := SetupTestServer(t)
+	require.NoError(t, err)
+	defer ts.Close()
+
+	setupAvailabilityTeam(t, ts)
+
+	w := postJSON(ts, "/team/add", map[string]interface{}{
+		"team": map[string]interface{}{
+			"team_name": "frontend",
+			"members":   []map[string]interface{}{{"user_id": "f1", "username": "f1", "is_active": true}},
+		},
+	})
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	w = postJSON(ts, "/admin/identities/set", map[string]interface{}{
+		"provider": "github",
+		"login":    "bob-gh",
+		"user_id":  "u2",
+	})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	content := "# Владельцы кода\n" +
+		"*                  @acme/backend\n" +
+		"*.sql              @bob-gh @stranger  # миграции\n" +
+		"\n" +
+		"/internal/storage/ @u3 dev@example.com\n" +
+		"!docs/             @u2\n" +
+		"docs/\n" +
+		"/web/              @acme/frontend @acme/ghosts\n"
+
+	w = postJSON(ts, "/team/importCodeOwners", map[string]interface{}{
+		"team_name":  "backend",
+		"codeowners": content,
+	})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var resp struct {
+		Rules []struct {
+			Pattern string   `json:"pattern"`
+			Owners  []string `json:"owners"`
+		} `json:"rules"`
+		UnknownHandles []struct {
+			Line   int    `json:"line"`
+			Handle string `json:"handle"`
+		} `json:"unknown_handles"`
+		InvalidLines []int `json:"invalid_lines"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+
+	require.Len(t, resp.Rules, 5)
+	assert.Equal(t, "*", resp.Rules[0].Pattern)
+	assert.ElementsMatch(t, []string{"u1", "u2", "u3", "u4"}, resp.Rules[0].Owners)
+	assert.Equal(t, []string{"u2"}, resp.Rules[1].Owners)
+	assert.Equal(t, "/internal/storage/", resp.Rules[2].Pattern)
+	assert.Equal(t, []string{"u3"}, resp.Rules[2].Owners)
+	assert.Equal(t, "docs/", resp.Rules[3].Pattern)
+	assert.Empty(t, resp.Rules[3].Owners)
+	// Команды, кроме импортирующей, не становятся владельцами и попадают в ответ
+	assert.Equal(t, "/web/", resp.Rules[4].Pattern)
+	assert.Empty(t, resp.Rules[4].Owners)
+
+	require.Len(t, resp.UnknownHandles, 4)
+	assert.Equal(t, 3, resp.UnknownHandles[0].Line)
+	assert.Equal(t, "@stranger", resp.UnknownHandles[0].Handle)
+	assert.Equal(t, 5, resp.UnknownHandles[1].Line)
+	assert.Equal(t, "dev@example.com", resp.UnknownHandles[1].Handle)
+	assert.Equal(t, 8, resp.UnknownHandles[2].Line)
+	assert.Equal(t, "@acme/frontend", resp.UnknownHandles[2].Handle)
+	assert.Equal(t, 8, resp.UnknownHandles[3].Line)
+	assert.Equal(t, "@acme/ghosts", resp.UnknownHandles[3].Handle)
+	assert.Equal(t, []int{6}, resp.InvalidLines)
+
+	// Правило без владельцев перекрывает "*": для docs/ выбор обычный
+	created := createPRWithFiles(t, ts, "pr-1", []string{"docs/guide.md"})
+	assert.Empty(t, created.MatchedRules)
+
+	created = createPRWithFiles(t, ts, "pr-2", []string{"internal/storage/team_repo.go"})
+	assert.Equal(t, map[string]string{"u3": "/internal/storage/"}, created.MatchedRules)
+
+	// Повторный импорт заменяет прежние правила
+	w = postJSON(ts, "/team/importCodeOwners", map[string]interface{}{
+		"team_name":  "backend",
+		"codeowners": "*.go @u4\n",
+	})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	w = httptest.NewRecorder()
+	ts.Server.Handler.ServeHTTP(w, httptest.NewRequest("GET", "/team/getCodeOwners?team_name=backend", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	resp.Rules = nil
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Len(t, resp.Rules, 1)
+	assert.Equal(t, "*.go", resp.Rules[0].Pattern)
+	assert.Equal(t, []string{"u4"}, resp.Rules[0].Owners)
+
+	w = postJSON(ts, "/team/importCodeOwners", map[string]interface{}{
+		"team_name":  "missing",
+		"codeowners": "*.go @u4\n",
+	})
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -0,0 +1,99 @@
+package integration
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reviewer-service/internal/http-server/middleware/validation"
+	"reviewer-service/internal/http-server/openapi"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+)
+
+// contractChecker сверяет трафик тестов со спецификацией openapi.yaml:
+// каждый ответ и каждый успешно обработанный запрос должны ей соответствовать.
+// Потоковые ответы (text/event-stream) не проверяются
+type contractChecker struct {
+	t      *testing.T
+	next   http.Handler
+	router routers.Router
+}
+
+func newContractChecker(t *testing.T, next http.Handler) (http.Handler, error) {
+	doc, err := openapi.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	router, err := validation.NewRouter(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	return &contractChecker{t: t, next: next, router: router}, nil
+}
+
+func (c *contractChecker) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	route, pathParams, err := c.router.FindRoute(r)
+	if err != nil {
+		c.t.Errorf("openapi: %s %s is not documented: %v", r.Method, r.URL.Path, err)
+		c.next.ServeHTTP(w, r)
+		return
+	}
+
+	if isStream(route) {
+		c.next.ServeHTTP(w, r)
+		return
+	}
+
+	var body []byte
+	if r.Body != nil {
+		body, _ = io.ReadAll(r.Body)
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	rec := httptest.NewRecorder()
+	c.next.ServeHTTP(rec, r)
+
+	for key, values := range rec.Header() {
+		w.Header()[key] = values
+	}
+	w.WriteHeader(rec.Code)
+	w.Write(rec.Body.Bytes())
+
+	checked := r.Clone(r.Context())
+	checked.Body = io.NopCloser(bytes.NewReader(body))
+	input := &openapi3filter.RequestValidationInput{
+		Request:    checked,
+		PathParams: pathParams,
+		Route:      route,
+		Options:    validation.Options,
+	}
+
+	if rec.Code < http.StatusMultipleChoices {
+		if err := openapi3filter.ValidateRequest(r.Context(), input); err != nil {
+			c.t.Errorf("openapi: %s %s request accepted with %d does not match spec: %s",
+				r.Method, r.URL.Path, rec.Code, validation.Describe(err))
+		}
+	}
+
+	err = openapi3filter.ValidateResponse(r.Context(), &openapi3filter.ResponseValidationInput{
+		RequestValidationInput: input,
+		Status:                 rec.Code,
+		Header:                 rec.Header(),
+		Body:                   io.NopCloser(bytes.NewReader(rec.Body.Bytes())),
+		Options:                &openapi3filter.Options{IncludeResponseStatus: true},
+	})
+	if err != nil {
+		c.t.Errorf("openapi: %s %s response %d does not match spec: %v\n%s",
+			r.Method, r.URL.Path, rec.Code, err, rec.Body.String())
+	}
+}
+
+func isStream(route *routers.Route) bool {
+	response := route.Operation.Responses.Status(http.StatusOK)
+	return response != nil && response.Value.Content.Get("text/event-stream") != nil
+}
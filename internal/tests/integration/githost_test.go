@@ -0,0 +1,127 @@
+package integration
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reviewer-service/internal/githost"
+	"reviewer-service/internal/githost/fake"
+	"reviewer-service/internal/githost/github"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGitHost_SyncReviewersAfterCommit(t *testing.T) {
+	client := fake.NewClient("github")
+	client.FailNext(2)
+
+	ts, err := SetupTestServerWithGitHost(t, testWebhookSecret, client)
+	require.NoError(t, err)
+	defer ts.Close()
+
+	setupWebhookData(t, ts)
+
+	ctx := context.Background()
+	_, err = ts.Storage.Db.Exec(ctx, `
+		INSERT INTO users (user_id, username, team_name, is_active) VALUES ('u4', 'Dave', 'backend', true);
+		INSERT INTO git_identities (provider, login, user_id) VALUES 
+			('github', 'bob-gh', 'u2'),
+			('github', 'charlie-gh', 'u3'),
+			('github', 'dave-gh', 'u4');
+	`)
+	require.NoError(t, err)
+
+	logins := map[string]string{"u2": "bob-gh", "u3": "charlie-gh", "u4": "dave-gh"}
+	ref := githost.PullRequestRef{Owner: "acme", Repo: "backend", Number: 42}
+
+	w := postGitHubFixture(t, ts, "pull_request_opened.json", "delivery-1", testWebhookSecret)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	pr, err := ts.Storage.GetPullRequestById(ctx, "acme/backend#42")
+	require.NoError(t, err)
+	require.Len(t, pr.AssignedReviewers, 2)
+
+	// Первые две попытки падают, синхронизация повторяется
+	require.Eventually(t, func() bool { return len(client.Calls()) == 1 }, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, 3, client.Attempts())
+
+	call := client.Calls()[0]
+	sort.Strings(call.Logins)
+	assert.Equal(t, fake.MethodRequest, call.Method)
+	assert.Equal(t, ref, call.Ref)
+	assert.Equal(t, []string{logins[pr.AssignedReviewers[0]], logins[pr.AssignedReviewers[1]]}, call.Logins)
+
+	oldReviewer := pr.AssignedReviewers[0]
+	body, _ := json.Marshal(map[string]interface{}{
+		"pull_request_id": "acme/backend#42",
+		"old_reviewer_id": oldReviewer,
+	})
+	req := httptest.NewRequest("POST", "/pullRequest/reassign", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rw := httptest.NewRecorder()
+	ts.Server.Handler.ServeHTTP(rw, req)
+	require.Equal(t, http.StatusOK, rw.Code)
+
+	var reassignResp map[string]interface{}
+	require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &reassignResp))
+	newReviewer, _ := reassignResp["replaced_by"].(string)
+	require.NotEmpty(t, newReviewer)
+
+	require.Eventually(t, func() bool { return len(client.Calls()) == 3 }, 5*time.Second, 10*time.Millisecond)
+	calls := client.Calls()
+	assert.Equal(t, fake.Call{Method: fake.MethodRemove, Ref: ref, Logins: []string{logins[oldReviewer]}}, calls[1])
+	assert.Equal(t, fake.Call{Method: fake.MethodRequest, Ref: ref, Logins: []string{logins[newReviewer]}}, calls[2])
+}
+
+func TestGitHubClient_RequestedReviewers(t *testing.T) {
+	type received struct {
+		method string
+		path   string
+		auth   string
+		body   string
+	}
+	requests := make(chan received, 4)
+
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests <- received{method: r.Method, path: r.URL.Path, auth: r.Header.Get("Authorization"), body: string(body)}
+
+		if r.URL.Path == "/repos/acme/missing/pulls/1/requested_reviewers" {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			w.Write([]byte(`{"message":"Reviews may only be requested from collaborators."}`))
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer api.Close()
+
+	client := github.NewClient(api.URL, "test-token", 5*time.Second)
+	ctx := context.Background()
+
+	err := client.RequestReviewers(ctx, githost.PullRequestRef{Owner: "acme", Repo: "backend", Number: 42}, []string{"bob-gh"})
+	require.NoError(t, err)
+
+	got := <-requests
+	assert.Equal(t, http.MethodPost, got.method)
+	assert.Equal(t, "/repos/acme/backend/pulls/42/requested_reviewers", got.path)
+	assert.Equal(t, "Bearer test-token", got.auth)
+	assert.JSONEq(t, `{"reviewers":["bob-gh"]}`, got.body)
+
+	err = client.RemoveReviewers(ctx, githost.PullRequestRef{Owner: "acme", Repo: "backend", Number: 42}, []string{"bob-gh"})
+	require.NoError(t, err)
+	assert.Equal(t, http.MethodDelete, (<-requests).method)
+
+	err = client.RequestReviewers(ctx, githost.PullRequestRef{Owner: "acme", Repo: "missing", Number: 1}, []string{"bob-gh"})
+	var statusErr *github.StatusError
+	require.True(t, errors.As(err, &statusErr))
+	assert.Equal(t, http.StatusUnprocessableEntity, statusErr.StatusCode)
+	assert.False(t, statusErr.Retryable())
+}
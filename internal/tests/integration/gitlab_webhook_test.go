@@ -0,0 +1,174 @@
+package integration
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reviewer-service/internal/domain/event"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func postGitLabFixture(t *testing.T, ts *TestServer, fixture string, deliveryId string, token string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	body, err := os.ReadFile(filepath.Join("testdata", "gitlab", fixture))
+	require.NoError(t, err)
+
+	req := httptest.NewRequest("POST", "/webhooks/gitlab", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Gitlab-Event", "Merge Request Hook")
+	req.Header.Set("X-Gitlab-Event-UUID", deliveryId)
+	req.Header.Set("X-Gitlab-Token", token)
+	w := httptest.NewRecorder()
+
+	ts.Server.Handler.ServeHTTP(w, req)
+	return w
+}
+
+func TestGitLabWebhook_Lifecycle(t *testing.T) {
+	ts, err := SetupTestServerWithWebhooks(t, testWebhookSecret, testWebhookSecret)
+	require.NoError(t, err)
+	defer ts.Close()
+
+	setupWebhookData(t, ts)
+
+	rw := postJSON(ts, "/admin/identities/set", map[string]interface{}{
+		"provider":    "gitlab",
+		"login":       "alice-gl",
+		"user_id":     "u1",
+		"external_id": "2731",
+	})
+	require.Equal(t, http.StatusOK, rw.Code, rw.Body.String())
+
+	const mrId = "acme/billing!17"
+	ctx := context.Background()
+
+	w := postGitLabFixture(t, ts, "merge_request_open_draft.json", "uuid-1", testWebhookSecret)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "ignored", decodeWebhookResponse(t, w)["status"])
+
+	w = postGitLabFixture(t, ts, "merge_request_update_ready.json", "uuid-2", testWebhookSecret)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "processed", decodeWebhookResponse(t, w)["status"])
+
+	pr, err := ts.Storage.GetPullRequestById(ctx, mrId)
+	require.NoError(t, err)
+	assert.Equal(t, "u1", pr.AuthorId)
+	assert.Equal(t, "Render invoices as PDF", pr.PullRequestName)
+	reviewers := pr.AssignedReviewers
+
+	// Открытый MR снова стал черновиком: ревью приостанавливается до снятия статуса
+	w = postGitLabFixture(t, ts, "merge_request_update_draft.json", "uuid-2a", testWebhookSecret)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "draft", decodeWebhookResponse(t, w)["action"])
+
+	pr, err = ts.Storage.GetPullRequestById(ctx, mrId)
+	require.NoError(t, err)
+	assert.Equal(t, "DRAFT", pr.Status)
+	assert.Equal(t, reviewers, pr.AssignedReviewers)
+
+	w = postGitLabFixture(t, ts, "merge_request_update_ready.json", "uuid-2b", testWebhookSecret)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "processed", decodeWebhookResponse(t, w)["status"])
+
+	pr, err = ts.Storage.GetPullRequestById(ctx, mrId)
+	require.NoError(t, err)
+	assert.Equal(t, "OPEN", pr.Status)
+	assert.Equal(t, reviewers, pr.AssignedReviewers)
+
+	// Черновик - не закрытие: pr.closed и pr.reopened не записываются
+	rows, err := ts.Storage.Db.Query(ctx, `SELECT type FROM outbox WHERE aggregate_id = $1 AND type LIKE 'pr.%' ORDER BY id`, mrId)
+	require.NoError(t, err)
+	var types []string
+	for rows.Next() {
+		var eventType string
+		require.NoError(t, rows.Scan(&eventType))
+		types = append(types, eventType)
+	}
+	rows.Close()
+	assert.Equal(t, []string{event.TypePullRequestCreated, event.TypePullRequestDrafted, event.TypePullRequestReady}, types)
+
+	w = postGitLabFixture(t, ts, "merge_request_close.json", "uuid-3", testWebhookSecret)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	// Повторная доставка close после reopen не должна снова закрыть MR
+	w = postGitLabFixture(t, ts, "merge_request_reopen.json", "uuid-4", testWebhookSecret)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	w = postGitLabFixture(t, ts, "merge_request_close.json", "uuid-3", testWebhookSecret)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "duplicate", decodeWebhookResponse(t, w)["status"])
+
+	pr, err = ts.Storage.GetPullRequestById(ctx, mrId)
+	require.NoError(t, err)
+	assert.Equal(t, "OPEN", pr.Status)
+	assert.Equal(t, reviewers, pr.AssignedReviewers)
+
+	w = postGitLabFixture(t, ts, "merge_request_merge.json", "uuid-5", testWebhookSecret)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	pr, err = ts.Storage.GetPullRequestById(ctx, mrId)
+	require.NoError(t, err)
+	assert.Equal(t, "MERGED", pr.Status)
+}
+
+func TestGitLabWebhook_AuthorMappedByExternalId(t *testing.T) {
+	ts, err := SetupTestServerWithWebhooks(t, testWebhookSecret, testWebhookSecret)
+	require.NoError(t, err)
+	defer ts.Close()
+
+	setupWebhookData(t, ts)
+
+	w := postJSON(ts, "/admin/identities/set", map[string]interface{}{
+		"provider": "gitlab",
+		"login":    "alice-gl",
+		"user_id":  "u1",
+	})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	// Черновик снял мейнтейнер: логина автора в событии нет, а id автора не сопоставлен
+	w = postGitLabFixture(t, ts, "merge_request_update_ready_by_maintainer.json", "uuid-1", testWebhookSecret)
+	require.Equal(t, http.StatusOK, w.Code)
+	resp := decodeWebhookResponse(t, w)
+	assert.Equal(t, "ignored", resp["status"])
+	assert.Equal(t, "no user is mapped to gitlab user id 2731", resp["reason"])
+
+	w = postJSON(ts, "/admin/identities/set", map[string]interface{}{
+		"provider":    "gitlab",
+		"login":       "alice-gl",
+		"user_id":     "u1",
+		"external_id": "2731",
+	})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	w = postJSON(ts, "/admin/identities/set", map[string]interface{}{
+		"provider":    "gitlab",
+		"login":       "alice-old",
+		"user_id":     "u2",
+		"external_id": "2731",
+	})
+	assert.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+
+	w = postGitLabFixture(t, ts, "merge_request_update_ready_by_maintainer.json", "uuid-1", testWebhookSecret)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "processed", decodeWebhookResponse(t, w)["status"])
+
+	pr, err := ts.Storage.GetPullRequestById(context.Background(), "acme/billing!17")
+	require.NoError(t, err)
+	assert.Equal(t, "u1", pr.AuthorId)
+}
+
+func TestGitLabWebhook_InvalidToken(t *testing.T) {
+	ts, err := SetupTestServerWithWebhooks(t, testWebhookSecret, testWebhookSecret)
+	require.NoError(t, err)
+	defer ts.Close()
+
+	w := postGitLabFixture(t, ts, "merge_request_open_draft.json", "uuid-1", "wrong-token")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
@@ -0,0 +1,210 @@
+package integration
+
+import (
+	"context"
+	"net"
+	"reviewer-service/internal/config"
+	grpcserver "reviewer-service/internal/grpc-server"
+	authMiddleware "reviewer-service/internal/http-server/middleware/auth"
+	"reviewer-service/internal/lib/random"
+	reviewerv1 "reviewer-service/pkg/api/reviewer/v1"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// startGRPC поднимает gRPC сервер поверх хранилища тестового сервера и
+// возвращает подключение к нему
+func startGRPC(t *testing.T, ts *TestServer, opts grpcserver.Options) *grpc.ClientConn {
+	log := config.MustConfigureLogger("test")
+
+	server := grpcserver.New(log, opts)
+	reviewerv1.RegisterTeamServiceServer(server, grpcserver.NewTeamService(log, ts.Storage, ts.Storage))
+	reviewerv1.RegisterUserServiceServer(server, grpcserver.NewUserService(log, ts.Storage, ts.Storage, ts.Storage))
+	reviewerv1.RegisterPullRequestServiceServer(server, grpcserver.NewPullRequestService(log, ts.Storage, ts.Storage, nil, random.New(0)))
+
+	listener := bufconn.Listen(1024 * 1024)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return conn
+}
+
+// requireStatus проверяет код gRPC и код ошибки REST API в ErrorInfo
+func requireStatus(t *testing.T, err error, code codes.Code, reason string) {
+	require.Error(t, err)
+
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	assert.Equal(t, code, st.Code(), st.Message())
+
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok {
+			assert.Equal(t, reason, info.GetReason())
+			return
+		}
+	}
+	t.Fatalf("status %v has no ErrorInfo", st)
+}
+
+func TestGRPC_TeamAndPullRequestFlow(t *testing.T) {
+	ts, err := SetupTestServer(t)
+	require.NoError(t, err)
+	defer ts.Close()
+
+	conn := startGRPC(t, ts, grpcserver.Options{})
+	teams := reviewerv1.NewTeamServiceClient(conn)
+	users := reviewerv1.NewUserServiceClient(conn)
+	prs := reviewerv1.NewPullRequestServiceClient(conn)
+	ctx := context.Background()
+
+	team := &reviewerv1.Team{
+		TeamName: "backend",
+		Members: []*reviewerv1.TeamMember{
+			{UserId: "u1", Username: "Alice", IsActive: true},
+			{UserId: "u2", Username: "Bob", IsActive: true},
+			{UserId: "u3", Username: "Charlie", IsActive: true},
+		},
+	}
+	added, err := teams.AddTeam(ctx, &reviewerv1.AddTeamRequest{Team: team})
+	require.NoError(t, err)
+	assert.Len(t, added.GetTeam().GetMembers(), 3)
+
+	_, err = teams.AddTeam(ctx, &reviewerv1.AddTeamRequest{Team: team})
+	requireStatus(t, err, codes.AlreadyExists, "TEAM_EXISTS")
+
+	got, err := teams.GetTeam(ctx, &reviewerv1.GetTeamRequest{TeamName: "backend"})
+	require.NoError(t, err)
+	assert.Equal(t, "backend", got.GetTeam().GetTeamName())
+
+	_, err = teams.GetTeam(ctx, &reviewerv1.GetTeamRequest{TeamName: "missing"})
+	requireStatus(t, err, codes.NotFound, "NOT_FOUND")
+
+	_, err = teams.GetTeam(ctx, &reviewerv1.GetTeamRequest{})
+	requireStatus(t, err, codes.InvalidArgument, "VALIDATION_ERROR")
+
+	created, err := prs.CreatePullRequest(ctx, &reviewerv1.CreatePullRequestRequest{
+		PullRequestId:   "pr-1",
+		PullRequestName: "Add feature",
+		AuthorId:        "u1",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "OPEN", created.GetPr().GetStatus())
+	assert.Len(t, created.GetPr().GetAssignedReviewers(), 2)
+	assert.NotContains(t, created.GetPr().GetAssignedReviewers(), "u1")
+
+	_, err = prs.CreatePullRequest(ctx, &reviewerv1.CreatePullRequestRequest{
+		PullRequestId:   "pr-1",
+		PullRequestName: "Add feature",
+		AuthorId:        "u1",
+	})
+	requireStatus(t, err, codes.AlreadyExists, "PR_EXISTS")
+
+	// В команде нет свободного кандидата для замены
+	_, err = prs.ReassignReviewer(ctx, &reviewerv1.ReassignReviewerRequest{
+		PullRequestId: "pr-1",
+		OldReviewerId: created.GetPr().GetAssignedReviewers()[0],
+	})
+	requireStatus(t, err, codes.FailedPrecondition, "NO_CANDIDATE")
+
+	_, err = prs.MergePullRequest(ctx, &reviewerv1.MergePullRequestRequest{
+		PullRequestId:   "pr-1",
+		ExpectedVersion: created.GetPr().GetVersion() + 1,
+	})
+	requireStatus(t, err, codes.Aborted, "VERSION_CONFLICT")
+
+	merged, err := prs.MergePullRequest(ctx, &reviewerv1.MergePullRequestRequest{
+		PullRequestId:   "pr-1",
+		ExpectedVersion: created.GetPr().GetVersion(),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "MERGED", merged.GetPr().GetStatus())
+	assert.NotNil(t, merged.GetPr().GetMergedAt())
+
+	_, err = prs.ReassignReviewer(ctx, &reviewerv1.ReassignReviewerRequest{
+		PullRequestId: "pr-1",
+		OldReviewerId: created.GetPr().GetAssignedReviewers()[0],
+	})
+	requireStatus(t, err, codes.FailedPrecondition, "PR_MERGED")
+
+	review, err := users.GetReview(ctx, &reviewerv1.GetReviewRequest{UserId: created.GetPr().GetAssignedReviewers()[0]})
+	require.NoError(t, err)
+	require.Len(t, review.GetPullRequests(), 1)
+	assert.Equal(t, "pr-1", review.GetPullRequests()[0].GetPullRequestId())
+
+	updated, err := users.SetIsActive(ctx, &reviewerv1.SetIsActiveRequest{UserId: "u2", IsActive: false})
+	require.NoError(t, err)
+	assert.False(t, updated.GetUser().GetIsActive())
+	assert.Equal(t, "backend", updated.GetUser().GetTeamName())
+
+	_, err = users.SetIsActive(ctx, &reviewerv1.SetIsActiveRequest{UserId: "missing"})
+	requireStatus(t, err, codes.NotFound, "NOT_FOUND")
+}
+
+func TestGRPC_Auth(t *testing.T) {
+	ts, err := SetupTestServer(t)
+	require.NoError(t, err)
+	defer ts.Close()
+
+	conn := startGRPC(t, ts, grpcserver.Options{
+		Auth: &authMiddleware.Options{
+			APIKeys:      ts.Storage,
+			BootstrapKey: "bootstrap",
+			Users:        ts.Storage,
+		},
+	})
+	teams := reviewerv1.NewTeamServiceClient(conn)
+
+	_, err = teams.GetTeam(context.Background(), &reviewerv1.GetTeamRequest{TeamName: "backend"})
+	requireStatus(t, err, codes.Unauthenticated, "UNAUTHORIZED")
+
+	invalid := metadata.AppendToOutgoingContext(context.Background(), "x-api-key", "wrong")
+	_, err = teams.GetTeam(invalid, &reviewerv1.GetTeamRequest{TeamName: "backend"})
+	requireStatus(t, err, codes.Unauthenticated, "UNAUTHORIZED")
+
+	admin := metadata.AppendToOutgoingContext(context.Background(), "x-api-key", "bootstrap")
+	_, err = teams.GetTeam(admin, &reviewerv1.GetTeamRequest{TeamName: "backend"})
+	requireStatus(t, err, codes.NotFound, "NOT_FOUND")
+
+	// Health и reflection доступны без ключа
+	health, err := healthpb.NewHealthClient(conn).Check(context.Background(), &healthpb.HealthCheckRequest{
+		Service: reviewerv1.PullRequestService_ServiceDesc.ServiceName,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, health.GetStatus())
+
+	stream, err := grpc_reflection_v1.NewServerReflectionClient(conn).ServerReflectionInfo(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(&grpc_reflection_v1.ServerReflectionRequest{
+		MessageRequest: &grpc_reflection_v1.ServerReflectionRequest_ListServices{},
+	}))
+	resp, err := stream.Recv()
+	require.NoError(t, err)
+
+	var services []string
+	for _, service := range resp.GetListServicesResponse().GetService() {
+		services = append(services, service.GetName())
+	}
+	assert.Contains(t, services, reviewerv1.TeamService_ServiceDesc.ServiceName)
+	assert.Contains(t, services, reviewerv1.UserService_ServiceDesc.ServiceName)
+	assert.Contains(t, services, reviewerv1.PullRequestService_ServiceDesc.ServiceName)
+}
@@ -0,0 +1,206 @@
+package integration
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reviewer-service/internal/config"
+	"reviewer-service/internal/lib/jwt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testKeyId = "test-key"
+
+// newTestIssuer создает RSA ключ и JWKS файл с его публичной частью
+func newTestIssuer(t *testing.T) (*rsa.PrivateKey, *jwt.Verifier) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	jwks := map[string]interface{}{
+		"keys": []map[string]interface{}{{
+			"kty": "RSA",
+			"kid": testKeyId,
+			"use": "sig",
+			"alg": "RS256",
+			"n":   base64.RawURLEncoding.EncodeToString(privateKey.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(privateKey.E)).Bytes()),
+		}},
+	}
+	data, err := json.Marshal(jwks)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+
+	verifier, err := jwt.NewVerifier(context.Background(), config.MustConfigureLogger("test"), jwt.Config{
+		JWKS:     path,
+		Issuer:   "https://idp.test",
+		Audience: "reviewer-service",
+	})
+	require.NoError(t, err)
+
+	return privateKey, verifier
+}
+
+func signTestToken(t *testing.T, key *rsa.PrivateKey, claims map[string]interface{}) string {
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT", "kid": testKeyId})
+	require.NoError(t, err)
+	payload, err := json.Marshal(claims)
+	require.NoError(t, err)
+
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	require.NoError(t, err)
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func testClaims(sub string) map[string]interface{} {
+	return map[string]interface{}{
+		"sub": sub,
+		"iss": "https://idp.test",
+		"aud": "reviewer-service",
+		"exp": time.Now().Add(time.Hour).Unix(),
+	}
+}
+
+func TestJWT_GetReviewUsesTokenUser(t *testing.T) {
+	key, verifier := newTestIssuer(t)
+	ts, err := SetupTestServerWithJWT(t, testBootstrapKey, verifier)
+	require.NoError(t, err)
+	defer ts.Close()
+
+	ctx := context.Background()
+	_, err = ts.Storage.Db.Exec(ctx, `
+		INSERT INTO team (name) VALUES ('backend');
+		INSERT INTO users (user_id, username, team_name, is_active) VALUES 
+			('u1', 'Alice', 'backend', true),
+			('u2', 'Bob', 'backend', true);
+		INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status) VALUES 
+			('pr-1', 'PR 1', 'u1', 'OPEN');
+		INSERT INTO pr_reviewers (pull_request_id, user_id) VALUES ('pr-1', 'u2');
+	`)
+	require.NoError(t, err)
+
+	token := signTestToken(t, key, testClaims("u2"))
+
+	req := httptest.NewRequest("GET", "/users/getReview", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+
+	ts.Server.Handler.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var response map[string]interface{}
+	err = json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, "u2", response["user_id"])
+	pullRequests, ok := response["pull_requests"].([]interface{})
+	require.True(t, ok)
+	assert.Len(t, pullRequests, 1)
+
+	req = httptest.NewRequest("GET", "/users/getReview?user_id=u1", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	w = httptest.NewRecorder()
+
+	ts.Server.Handler.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestJWT_RejectsInvalidTokens(t *testing.T) {
+	key, verifier := newTestIssuer(t)
+	otherKey, _ := newTestIssuer(t)
+	ts, err := SetupTestServerWithJWT(t, testBootstrapKey, verifier)
+	require.NoError(t, err)
+	defer ts.Close()
+
+	ctx := context.Background()
+	_, err = ts.Storage.Db.Exec(ctx, `
+		INSERT INTO team (name) VALUES ('backend');
+		INSERT INTO users (user_id, username, team_name, is_active) VALUES ('u1', 'Alice', 'backend', true);
+	`)
+	require.NoError(t, err)
+
+	expired := testClaims("u1")
+	expired["exp"] = time.Now().Add(-time.Hour).Unix()
+
+	wrongAudience := testClaims("u1")
+	wrongAudience["aud"] = "another-service"
+
+	tokens := map[string]string{
+		"expired":        signTestToken(t, key, expired),
+		"wrong audience": signTestToken(t, key, wrongAudience),
+		"unknown user":   signTestToken(t, key, testClaims("ghost")),
+		"foreign key":    signTestToken(t, otherKey, testClaims("u1")),
+		"garbage":        "not.a.jwt",
+	}
+
+	for name, token := range tokens {
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/users/getReview", nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			w := httptest.NewRecorder()
+
+			ts.Server.Handler.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusUnauthorized, w.Code)
+		})
+	}
+}
+
+func TestJWT_AdminOnlyFromRole(t *testing.T) {
+	key, verifier := newTestIssuer(t)
+	ts, err := SetupTestServerWithJWT(t, testBootstrapKey, verifier)
+	require.NoError(t, err)
+	defer ts.Close()
+
+	ctx := context.Background()
+	_, err = ts.Storage.Db.Exec(ctx, `
+		INSERT INTO team (name) VALUES ('backend');
+		INSERT INTO users (user_id, username, team_name, is_active) VALUES ('u1', 'Alice', 'backend', true);
+	`)
+	require.NoError(t, err)
+
+	claims := testClaims("u1")
+	claims["scope"] = "read admin"
+	token := signTestToken(t, key, claims)
+
+	listKeys := func() int {
+		req := httptest.NewRequest("GET", "/admin/apiKeys/list", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		ts.Server.Handler.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	// scope admin из токена игнорируется
+	assert.Equal(t, http.StatusForbidden, listKeys())
+
+	_, err = ts.Storage.Db.Exec(ctx, `INSERT INTO user_roles (user_id, role) VALUES ('u1', 'admin')`)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, listKeys())
+}
+
+func TestJWT_RequiresIssuerAndAudience(t *testing.T) {
+	_, err := jwt.NewVerifier(context.Background(), config.MustConfigureLogger("test"), jwt.Config{JWKS: "unused.json", Issuer: "https://idp.test"})
+	assert.Error(t, err)
+
+	_, err = jwt.NewVerifier(context.Background(), config.MustConfigureLogger("test"), jwt.Config{JWKS: "unused.json", Audience: "reviewer-service"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,162 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reviewer-service/internal/notifier"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// chatStandIn - локальная замена входящего вебхука Slack, запоминает тексты сообщений
+type chatStandIn struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	messages []string
+}
+
+func newChatStandIn(t *testing.T) *chatStandIn {
+	chat := &chatStandIn{}
+	chat.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Text string `json:"text"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.Text == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		chat.mu.Lock()
+		chat.messages = append(chat.messages, body.Text)
+		chat.mu.Unlock()
+
+		w.Write([]byte("ok"))
+	}))
+	t.Cleanup(chat.Close)
+	return chat
+}
+
+func (c *chatStandIn) Messages() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]string(nil), c.messages...)
+}
+
+func setupNotificationData(t *testing.T, ts *TestServer, chatURL string) {
+	w := postJSON(ts, "/team/add", map[string]interface{}{
+		"team": map[string]interface{}{
+			"team_name": "backend",
+			"members": []map[string]interface{}{
+				{"user_id": "u1", "username": "Alice", "is_active": true, "chat_handle": "U001"},
+				{"user_id": "u2", "username": "Bob", "is_active": true, "chat_handle": "U002"},
+				{"user_id": "u3", "username": "Charlie", "is_active": true},
+			},
+		},
+	})
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	w = postJSON(ts, "/team/setChatWebhook", map[string]interface{}{
+		"team_name":   "backend",
+		"webhook_url": chatURL,
+	})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.NotContains(t, w.Body.String(), chatURL)
+}
+
+func TestNotifications_AssignmentMessages(t *testing.T) {
+	ts, err := SetupTestServerWithNotifications(t, notifier.Options{})
+	require.NoError(t, err)
+	defer ts.Close()
+
+	chat := newChatStandIn(t)
+	setupNotificationData(t, ts, chat.URL)
+
+	w := postJSON(ts, "/pullRequest/create", map[string]interface{}{
+		"pull_request_id":   "pr-1",
+		"pull_request_name": "Add feature",
+		"author_id":         "u1",
+	})
+	require.Equal(t, http.StatusCreated, w.Code)
+
+	require.Eventually(t, func() bool {
+		return len(chat.Messages()) == 2
+	}, 5*time.Second, 20*time.Millisecond)
+
+	messages := chat.Messages()
+	assert.ElementsMatch(t, []string{
+		"<@U002>, you were assigned to review *Add feature* (pr-1) by <@U001>",
+		"Charlie, you were assigned to review *Add feature* (pr-1) by <@U001>",
+	}, messages)
+
+	// Четвертый участник станет заменой Bob при переназначении
+	_, err = ts.Storage.Db.Exec(context.Background(),
+		"INSERT INTO users (user_id, username, team_name, is_active) VALUES ('u4', 'Dave', 'backend', true)")
+	require.NoError(t, err)
+
+	w = postJSON(ts, "/users/setChatHandle", map[string]interface{}{"user_id": "u4", "chat_handle": "U004"})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	w = postJSON(ts, "/pullRequest/reassign", map[string]interface{}{
+		"pull_request_id": "pr-1",
+		"old_reviewer_id": "u2",
+	})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	require.Eventually(t, func() bool {
+		return len(chat.Messages()) == 3
+	}, 5*time.Second, 20*time.Millisecond)
+
+	assert.Equal(t, "<@U004>, you were assigned to review *Add feature* (pr-1) by <@U001> instead of Bob", chat.Messages()[2])
+}
+
+func TestNotifications_CustomTemplate(t *testing.T) {
+	ts, err := SetupTestServerWithNotifications(t, notifier.Options{
+		AssignedTemplate: `[{{.TeamName}}] {{.Reviewer.Mention}} -> {{.PullRequestId}}`,
+		MentionFormat:    "@%s",
+	})
+	require.NoError(t, err)
+	defer ts.Close()
+
+	chat := newChatStandIn(t)
+	setupNotificationData(t, ts, chat.URL)
+
+	// Без вебхука команды уведомления не отправляются
+	w := postJSON(ts, "/team/setChatWebhook", map[string]interface{}{"team_name": "backend", "webhook_url": ""})
+	require.Equal(t, http.StatusOK, w.Code)
+
+	w = postJSON(ts, "/pullRequest/create", map[string]interface{}{
+		"pull_request_id":   "pr-1",
+		"pull_request_name": "Silent",
+		"author_id":         "u3",
+	})
+	require.Equal(t, http.StatusCreated, w.Code)
+
+	require.Eventually(t, func() bool {
+		var unsent int
+		err := ts.Storage.Db.QueryRow(context.Background(), "SELECT COUNT(*) FROM outbox WHERE sent_at IS NULL").Scan(&unsent)
+		return err == nil && unsent == 0
+	}, 5*time.Second, 20*time.Millisecond)
+
+	w = postJSON(ts, "/team/setChatWebhook", map[string]interface{}{"team_name": "backend", "webhook_url": chat.URL})
+	require.Equal(t, http.StatusOK, w.Code)
+
+	w = postJSON(ts, "/pullRequest/create", map[string]interface{}{
+		"pull_request_id":   "pr-2",
+		"pull_request_name": "Loud",
+		"author_id":         "u3",
+	})
+	require.Equal(t, http.StatusCreated, w.Code)
+
+	require.Eventually(t, func() bool {
+		return len(chat.Messages()) == 2
+	}, 5*time.Second, 20*time.Millisecond)
+
+	assert.ElementsMatch(t, []string{"[backend] @U001 -> pr-2", "[backend] @U002 -> pr-2"}, chat.Messages())
+}
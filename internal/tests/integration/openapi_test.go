@@ -0,0 +1,97 @@
+package integration
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenAPI_SpecAndDocs(t *testing.T) {
+	ts, err := SetupTestServer(t)
+	require.NoError(t, err)
+	defer ts.Close()
+
+	req := httptest.NewRequest("GET", "/openapi.json", nil)
+	w := httptest.NewRecorder()
+	ts.Server.Handler.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+
+	var spec struct {
+		OpenAPI string                     `json:"openapi"`
+		Paths   map[string]json.RawMessage `json:"paths"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &spec))
+	assert.Equal(t, "3.0.3", spec.OpenAPI)
+	for _, path := range []string{"/team/add", "/users/getReview", "/pullRequest/create", "/pullRequest/reassign"} {
+		assert.Contains(t, spec.Paths, path)
+	}
+
+	// Спецификация отдается только как JSON, другие расширения не маршрутизируются
+	for _, path := range []string{"/openapi", "/openapi.xml"} {
+		w = httptest.NewRecorder()
+		ts.Server.Handler.ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+		assert.Equal(t, http.StatusNotFound, w.Code, path)
+	}
+
+	req = httptest.NewRequest("GET", "/docs", nil)
+	w = httptest.NewRecorder()
+	ts.Server.Handler.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "swagger-ui")
+	assert.Contains(t, w.Body.String(), "/openapi.json")
+}
+
+func TestOpenAPI_RejectsNonConformingRequests(t *testing.T) {
+	ts, err := SetupTestServerWithValidation(t)
+	require.NoError(t, err)
+	defer ts.Close()
+
+	// Обработчик принял бы участника без username, спецификация - нет
+	w := postJSON(ts, "/team/add", map[string]interface{}{
+		"team": map[string]interface{}{
+			"team_name": "backend",
+			"members":   []map[string]interface{}{{"user_id": "u1", "is_active": true}},
+		},
+	})
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "VALIDATION_ERROR")
+	assert.Contains(t, w.Body.String(), "username")
+
+	req := httptest.NewRequest("GET", "/team/get?team_name=backend", nil)
+	rec := httptest.NewRecorder()
+	ts.Server.Handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	w = postJSON(ts, "/pullRequest/create", map[string]interface{}{
+		"pull_request_id":   "pr-1",
+		"pull_request_name": 42,
+		"author_id":         "u1",
+	})
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "pull_request_name")
+
+	// Запросы по спецификации проходят как обычно
+	w = postJSON(ts, "/team/add", map[string]interface{}{
+		"team": map[string]interface{}{
+			"team_name": "backend",
+			"members": []map[string]interface{}{
+				{"user_id": "u1", "username": "Alice", "is_active": true},
+				{"user_id": "u2", "username": "Bob", "is_active": true},
+			},
+		},
+	})
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	req = httptest.NewRequest("GET", "/subscriptions/deliveries?subscription_id=abc", nil)
+	rec = httptest.NewRecorder()
+	ts.Server.Handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "subscription_id")
+}
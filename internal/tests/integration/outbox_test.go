@@ -0,0 +1,196 @@
+package integration
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reviewer-service/internal/domain/event"
+	"reviewer-service/internal/outbox"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func postJSON(ts *TestServer, path string, reqBody map[string]interface{}) *httptest.ResponseRecorder {
+	body, _ := json.Marshal(reqBody)
+	req := httptest.NewRequest("POST", path, bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	ts.Server.Handler.ServeHTTP(w, req)
+	return w
+}
+
+func eventTypes(events []*event.Event, aggregateId string) []string {
+	types := make([]string, 0)
+	for _, e := range events {
+		if e.AggregateId == aggregateId {
+			types = append(types, e.Type)
+		}
+	}
+	return types
+}
+
+func TestOutbox_DeliversEventsInOrder(t *testing.T) {
+	sink := outbox.NewMemorySink()
+
+	// Первая попытка доставить pr.created падает, следующие события PR должны ждать
+	failed := false
+	sink.FailWith(func(e *event.Event) error {
+		if e.Type == event.TypePullRequestCreated && !failed {
+			failed = true
+			return errors.New("sink is down")
+		}
+		return nil
+	})
+
+	ts, err := SetupTestServerWithOutbox(t, sink)
+	require.NoError(t, err)
+	defer ts.Close()
+
+	ctx := context.Background()
+	_, err = ts.Storage.Db.Exec(ctx, `
+		INSERT INTO team (name) VALUES ('backend');
+		INSERT INTO users (user_id, username, team_name, is_active) VALUES 
+			('u1', 'Alice', 'backend', true),
+			('u2', 'Bob', 'backend', true),
+			('u3', 'Charlie', 'backend', true),
+			('u4', 'Dave', 'backend', true);
+	`)
+	require.NoError(t, err)
+
+	w := postJSON(ts, "/pullRequest/create", map[string]interface{}{
+		"pull_request_id":   "pr-1",
+		"pull_request_name": "Add feature",
+		"author_id":         "u1",
+	})
+	require.Equal(t, http.StatusCreated, w.Code)
+
+	pr, err := ts.Storage.GetPullRequestById(ctx, "pr-1")
+	require.NoError(t, err)
+	require.NotEmpty(t, pr.AssignedReviewers)
+
+	w = postJSON(ts, "/pullRequest/reassign", map[string]interface{}{
+		"pull_request_id": "pr-1",
+		"old_reviewer_id": pr.AssignedReviewers[0],
+	})
+	require.Equal(t, http.StatusOK, w.Code)
+
+	w = postJSON(ts, "/pullRequest/merge", map[string]interface{}{"pull_request_id": "pr-1"})
+	require.Equal(t, http.StatusOK, w.Code)
+
+	w = postJSON(ts, "/users/setIsActive", map[string]interface{}{"user_id": "u4", "is_active": false})
+	require.Equal(t, http.StatusOK, w.Code)
+
+	require.Eventually(t, func() bool {
+		return len(sink.Events()) == 7
+	}, 5*time.Second, 20*time.Millisecond)
+
+	events := sink.Events()
+	assert.Equal(t, []string{
+		event.TypePullRequestCreated,
+		event.TypeReviewerAssigned,
+		event.TypeReviewerAssigned,
+		event.TypeReviewerReassigned,
+		event.TypeReviewerAssigned,
+		event.TypePullRequestMerged,
+	}, eventTypes(events, "pr-1"))
+	assert.Equal(t, []string{event.TypeUserDeactivated}, eventTypes(events, "u4"))
+
+	var payload event.ReviewerReassignedPayload
+	for _, e := range events {
+		if e.Type == event.TypeReviewerReassigned {
+			require.NoError(t, json.Unmarshal(e.Payload, &payload))
+		}
+	}
+	assert.Equal(t, pr.AssignedReviewers[0], payload.OldReviewerId)
+
+	var unsent, failedAttempts int
+	err = ts.Storage.Db.QueryRow(ctx, `
+		SELECT 
+			COUNT(*) FILTER (WHERE sent_at IS NULL),
+			COALESCE(MAX(attempts), 0)
+		FROM outbox
+	`).Scan(&unsent, &failedAttempts)
+	require.NoError(t, err)
+	assert.Equal(t, 0, unsent)
+	assert.Equal(t, 2, failedAttempts)
+}
+
+func TestOutbox_FailedEventStopsBlockingAggregate(t *testing.T) {
+	sink := outbox.NewMemorySink()
+	sink.FailWith(func(e *event.Event) error {
+		if e.Type == event.TypePullRequestCreated {
+			return errors.New("sink rejects event")
+		}
+		return nil
+	})
+
+	ts, err := SetupTestServerWithOutbox(t, sink)
+	require.NoError(t, err)
+	defer ts.Close()
+
+	ctx := context.Background()
+	_, err = ts.Storage.Db.Exec(ctx, `
+		INSERT INTO team (name) VALUES ('backend');
+		INSERT INTO users (user_id, username, team_name, is_active) VALUES 
+			('u1', 'Alice', 'backend', true),
+			('u2', 'Bob', 'backend', true),
+			('u3', 'Charlie', 'backend', true);
+	`)
+	require.NoError(t, err)
+
+	w := postJSON(ts, "/pullRequest/create", map[string]interface{}{
+		"pull_request_id":   "pr-1",
+		"pull_request_name": "Add feature",
+		"author_id":         "u1",
+	})
+	require.Equal(t, http.StatusCreated, w.Code)
+
+	// После трех попыток pr.created помечается failed_at, и назначения доставляются
+	require.Eventually(t, func() bool {
+		return len(sink.Events()) == 2
+	}, 5*time.Second, 20*time.Millisecond)
+
+	assert.Equal(t, []string{
+		event.TypeReviewerAssigned,
+		event.TypeReviewerAssigned,
+	}, eventTypes(sink.Events(), "pr-1"))
+
+	var attempts int
+	var lastError string
+	var failed bool
+	err = ts.Storage.Db.QueryRow(ctx, `
+		SELECT attempts, last_error, failed_at IS NOT NULL
+		FROM outbox
+		WHERE event_type = $1
+	`, event.TypePullRequestCreated).Scan(&attempts, &lastError, &failed)
+	require.NoError(t, err)
+	assert.Equal(t, 3, attempts)
+	assert.Equal(t, "sink rejects event", lastError)
+	assert.True(t, failed)
+}
+
+func TestOutbox_NoEventOnRollback(t *testing.T) {
+	sink := outbox.NewMemorySink()
+	ts, err := SetupTestServerWithOutbox(t, sink)
+	require.NoError(t, err)
+	defer ts.Close()
+
+	w := postJSON(ts, "/pullRequest/create", map[string]interface{}{
+		"pull_request_id":   "pr-1",
+		"pull_request_name": "Add feature",
+		"author_id":         "missing",
+	})
+	require.Equal(t, http.StatusNotFound, w.Code)
+
+	var count int
+	err = ts.Storage.Db.QueryRow(context.Background(), "SELECT COUNT(*) FROM outbox").Scan(&count)
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+}
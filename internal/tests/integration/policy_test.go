@@ -0,0 +1,247 @@
+package integration
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setSeniority(t *testing.T, ts *TestServer, userId string, seniority string) {
+	w := postJSON(ts, "/users/setSeniority", map[string]interface{}{"user_id": userId, "seniority": seniority})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+}
+
+func TestReviewPolicy_SetAndGet(t *testing.T) {
+	ts, err := SetupTestServer(t)
+	require.NoError(t, err)
+	defer ts.Close()
+
+	setupAvailabilityTeam(t, ts)
+
+	w := postJSON(ts, "/team/setReviewPolicy", map[string]interface{}{
+		"team_name": "backend",
+		"composition": []map[string]interface{}{
+			{"min_seniority": "middle", "min_count": 2},
+			{"min_seniority": "senior", "min_count": 1},
+		},
+	})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	w = httptest.NewRecorder()
+	ts.Server.Handler.ServeHTTP(w, httptest.NewRequest("GET", "/team/getReviewPolicy?team_name=backend", nil))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var resp struct {
+		TeamName    string `json:"team_name"`
+		Composition []struct {
+			MinSeniority string `json:"min_seniority"`
+			MinCount     int    `json:"min_count"`
+		} `json:"composition"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Len(t, resp.Composition, 2)
+	assert.Equal(t, "senior", resp.Composition[0].MinSeniority)
+	assert.Equal(t, "middle", resp.Composition[1].MinSeniority)
+
+	// Один уровень дважды и неизвестный уровень
+	w = postJSON(ts, "/team/setReviewPolicy", map[string]interface{}{
+		"team_name": "backend",
+		"composition": []map[string]interface{}{
+			{"min_seniority": "senior", "min_count": 1},
+			{"min_seniority": "senior", "min_count": 2},
+		},
+	})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = postJSON(ts, "/users/setSeniority", map[string]interface{}{"user_id": "u2", "seniority": "principal"})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestReviewPolicy_CompositionOnCreateAndReassign(t *testing.T) {
+	ts, err := SetupTestServer(t)
+	require.NoError(t, err)
+	defer ts.Close()
+
+	setupAvailabilityTeam(t, ts)
+	setSeniority(t, ts, "u4", "senior")
+
+	w := postJSON(ts, "/team/setReviewPolicy", map[string]interface{}{
+		"team_name":   "backend",
+		"composition": []map[string]interface{}{{"min_seniority": "senior", "min_count": 1}},
+	})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	w = postJSON(ts, "/pullRequest/create", map[string]interface{}{
+		"pull_request_id":   "pr-1",
+		"pull_request_name": "PR",
+		"author_id":         "u1",
+	})
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	var created repositoryPRResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	require.Len(t, created.PR.AssignedReviewers, 2)
+	assert.Contains(t, created.PR.AssignedReviewers, "u4")
+	assert.False(t, created.UnderStaffed)
+
+	free := "u2"
+	if created.PR.AssignedReviewers[0] == "u2" {
+		free = "u3"
+	}
+
+	// Свободный участник ниже уровня senior не может заменить единственного senior
+	w = postJSON(ts, "/pullRequest/reassign", map[string]interface{}{"pull_request_id": "pr-1", "old_reviewer_id": "u4"})
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Contains(t, w.Body.String(), "NO_CANDIDATE")
+
+	setSeniority(t, ts, free, "lead")
+
+	w = postJSON(ts, "/pullRequest/reassign", map[string]interface{}{"pull_request_id": "pr-1", "old_reviewer_id": "u4"})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var reassigned struct {
+		ReplacedBy string `json:"replaced_by"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &reassigned))
+	assert.Equal(t, free, reassigned.ReplacedBy)
+
+	// Без подходящих участников PR создается с признаком нехватки
+	setSeniority(t, ts, free, "")
+	w = postJSON(ts, "/pullRequest/create", map[string]interface{}{
+		"pull_request_id":   "pr-2",
+		"pull_request_name": "PR",
+		"author_id":         "u4",
+	})
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	created = repositoryPRResponse{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	assert.Len(t, created.PR.AssignedReviewers, 2)
+	assert.True(t, created.UnderStaffed)
+}
+
+func createPRReviewers(t *testing.T, ts *TestServer, id string, authorId string) []string {
+	w := postJSON(ts, "/pullRequest/create", map[string]interface{}{
+		"pull_request_id":   id,
+		"pull_request_name": "PR",
+		"author_id":         authorId,
+	})
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	var resp repositoryPRResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	return resp.PR.AssignedReviewers
+}
+
+func TestReviewPolicy_ExcludedPairs(t *testing.T) {
+	ts, err := SetupTestServer(t)
+	require.NoError(t, err)
+	defer ts.Close()
+
+	setupAvailabilityTeam(t, ts)
+
+	w := postJSON(ts, "/team/setReviewPolicy", map[string]interface{}{
+		"team_name":      "backend",
+		"excluded_pairs": []map[string]interface{}{{"user_id": "u2", "other_user_id": "u1"}},
+	})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var saved struct {
+		ExcludedPairs []struct {
+			UserId      string `json:"user_id"`
+			OtherUserId string `json:"other_user_id"`
+		} `json:"excluded_pairs"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &saved))
+	require.Len(t, saved.ExcludedPairs, 1)
+	assert.Equal(t, "u1", saved.ExcludedPairs[0].UserId)
+	assert.Equal(t, "u2", saved.ExcludedPairs[0].OtherUserId)
+
+	// Без пары u2 был бы первым по порядку user_id
+	assert.Equal(t, []string{"u3", "u4"}, createPRReviewers(t, ts, "pr-1", "u1"))
+	assert.NotContains(t, createPRReviewers(t, ts, "pr-2", "u2"), "u1")
+
+	// Единственный свободный кандидат исключен: ревьювер снимается без замены
+	w = postJSON(ts, "/pullRequest/reassign", map[string]interface{}{"pull_request_id": "pr-1", "old_reviewer_id": "u3"})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.NotContains(t, w.Body.String(), `"u2"`)
+
+	w = postJSON(ts, "/team/setReviewPolicy", map[string]interface{}{
+		"team_name":      "backend",
+		"excluded_pairs": []map[string]interface{}{{"user_id": "u1", "other_user_id": "ghost"}},
+	})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "UNKNOWN_MEMBER")
+
+	w = postJSON(ts, "/team/setReviewPolicy", map[string]interface{}{
+		"team_name":      "backend",
+		"excluded_pairs": []map[string]interface{}{{"user_id": "u1", "other_user_id": "u1"}},
+	})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestReviewPolicy_ExcludedPairsStayInTeam(t *testing.T) {
+	ts, err := SetupTestServer(t)
+	require.NoError(t, err)
+	defer ts.Close()
+
+	setupAvailabilityTeam(t, ts)
+
+	w := postJSON(ts, "/team/setReviewPolicy", map[string]interface{}{
+		"team_name":      "backend",
+		"excluded_pairs": []map[string]interface{}{{"user_id": "u1", "other_user_id": "u2"}},
+	})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	// Пара переходит в другую команду: исключение старой команды на нее не действует
+	w = postJSON(ts, "/team/add", map[string]interface{}{
+		"team": map[string]interface{}{
+			"team_name": "frontend",
+			"members": []map[string]interface{}{
+				{"user_id": "u1", "username": "Alice", "is_active": true},
+				{"user_id": "u2", "username": "Bob", "is_active": true},
+			},
+		},
+	})
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	assert.Equal(t, []string{"u2"}, createPRReviewers(t, ts, "pr-1", "u1"))
+}
+
+func TestReviewPolicy_RepeatLimit(t *testing.T) {
+	ts, err := SetupTestServer(t)
+	require.NoError(t, err)
+	defer ts.Close()
+
+	setupAvailabilityTeam(t, ts)
+
+	w := postJSON(ts, "/team/setReviewPolicy", map[string]interface{}{
+		"team_name":    "backend",
+		"repeat_limit": map[string]interface{}{"max_reviews": 1, "window_days": 30},
+	})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	assert.Equal(t, []string{"u2", "u3"}, createPRReviewers(t, ts, "pr-1", "u1"))
+
+	// u2 и u3 уже ревьюили u1 в окне и уступают u4
+	assert.Equal(t, []string{"u2", "u4"}, createPRReviewers(t, ts, "pr-2", "u1"))
+
+	// Лимит считается по паре автор/ревьювер
+	assert.Equal(t, []string{"u1", "u3"}, createPRReviewers(t, ts, "pr-3", "u2"))
+
+	// Лимит не запрещает назначение, когда других кандидатов нет
+	assert.Len(t, createPRReviewers(t, ts, "pr-4", "u1"), 2)
+
+	w = httptest.NewRecorder()
+	ts.Server.Handler.ServeHTTP(w, httptest.NewRequest("GET", "/team/getReviewPolicy?team_name=backend", nil))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Contains(t, w.Body.String(), `"repeat_limit":{"max_reviews":1,"window_days":30}`)
+
+	w = postJSON(ts, "/team/setReviewPolicy", map[string]interface{}{"team_name": "backend"})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.NotContains(t, w.Body.String(), "repeat_limit")
+}
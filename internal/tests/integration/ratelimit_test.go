@@ -0,0 +1,137 @@
+package integration
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	rateLimitMiddleware "reviewer-service/internal/http-server/middleware/ratelimit"
+	"reviewer-service/internal/lib/ratelimit"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimit_PerRoute(t *testing.T) {
+	ts, err := SetupTestServerWithRateLimit(t, rateLimitMiddleware.Options{
+		Routes: map[string]ratelimit.Limit{
+			"/pullRequest/create": {Rate: 0.01, Burst: 2},
+		},
+	})
+	require.NoError(t, err)
+	defer ts.Close()
+
+	createPR := func(remoteAddr string) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(map[string]interface{}{
+			"pull_request_id":   "pr-1",
+			"pull_request_name": "Test PR",
+			"author_id":         "u1",
+		})
+		req := httptest.NewRequest("POST", "/pullRequest/create", bytes.NewReader(body))
+		req.RemoteAddr = remoteAddr
+		w := httptest.NewRecorder()
+		ts.Server.Handler.ServeHTTP(w, req)
+		return w
+	}
+
+	for i := 0; i < 2; i++ {
+		w := createPR("10.0.0.1:1234")
+		assert.NotEqual(t, http.StatusTooManyRequests, w.Code)
+		assert.Equal(t, "2", w.Header().Get("RateLimit-Limit"))
+	}
+
+	w := createPR("10.0.0.1:4321")
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "0", w.Header().Get("RateLimit-Remaining"))
+	assert.NotEmpty(t, w.Header().Get("Retry-After"))
+	assert.NotEmpty(t, w.Header().Get("RateLimit-Reset"))
+
+	var response map[string]interface{}
+	err = json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+
+	errorObj, ok := response["error"].(map[string]interface{})
+	require.True(t, ok)
+	assert.Equal(t, "RATE_LIMITED", errorObj["code"])
+
+	// другой клиент и пути без лимита не затронуты
+	w = createPR("10.0.0.2:1234")
+	assert.NotEqual(t, http.StatusTooManyRequests, w.Code)
+
+	req := httptest.NewRequest("GET", "/team/get?team_name=backend", nil)
+	req.RemoteAddr = "10.0.0.1:1234"
+	w = httptest.NewRecorder()
+	ts.Server.Handler.ServeHTTP(w, req)
+	assert.NotEqual(t, http.StatusTooManyRequests, w.Code)
+	assert.Empty(t, w.Header().Get("RateLimit-Limit"))
+}
+
+func TestRateLimit_RejectedCredentials(t *testing.T) {
+	ts, err := setupTestServer(t, testServerOptions{
+		authEnabled:      true,
+		bootstrapKey:     testBootstrapKey,
+		rateLimit:        &rateLimitMiddleware.Options{Default: ratelimit.Limit{Rate: 0.01, Burst: 10}},
+		addressRateLimit: &rateLimitMiddleware.Options{Default: ratelimit.Limit{Rate: 0.01, Burst: 2}},
+	})
+	require.NoError(t, err)
+	defer ts.Close()
+
+	guessKey := func() int {
+		req := httptest.NewRequest("GET", "/team/get?team_name=backend", nil)
+		req.RemoteAddr = "10.0.0.1:1234"
+		req.Header.Set("X-API-Key", "guessed-key")
+		w := httptest.NewRecorder()
+		ts.Server.Handler.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	// Подбор ключей ограничивается по адресу до аутентификации
+	assert.Equal(t, http.StatusUnauthorized, guessKey())
+	assert.Equal(t, http.StatusUnauthorized, guessKey())
+	assert.Equal(t, http.StatusTooManyRequests, guessKey())
+}
+
+func TestRateLimit_AuthenticatedClientsBehindOneAddress(t *testing.T) {
+	ts, err := setupTestServer(t, testServerOptions{
+		authEnabled:      true,
+		bootstrapKey:     testBootstrapKey,
+		rateLimit:        &rateLimitMiddleware.Options{Default: ratelimit.Limit{Rate: 0.01, Burst: 2}},
+		addressRateLimit: &rateLimitMiddleware.Options{Default: ratelimit.Limit{Rate: 0.01, Burst: 100}},
+	})
+	require.NoError(t, err)
+	defer ts.Close()
+
+	createKey := func(name string) string {
+		body, _ := json.Marshal(map[string]interface{}{"name": name, "scopes": []string{"read"}})
+		req := httptest.NewRequest("POST", "/admin/apiKeys/create", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-API-Key", testBootstrapKey)
+		w := httptest.NewRecorder()
+		ts.Server.Handler.ServeHTTP(w, req)
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+		var created struct {
+			Key string `json:"key"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+		return created.Key
+	}
+	keys := []string{createKey("ci-1"), createKey("ci-2")}
+
+	getTeam := func(apiKey string) int {
+		req := httptest.NewRequest("GET", "/team/get?team_name=backend", nil)
+		req.RemoteAddr = "10.0.0.1:1234"
+		req.Header.Set("X-API-Key", apiKey)
+		w := httptest.NewRecorder()
+		ts.Server.Handler.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	// Клиенты за одним адресом не делят лимит: у каждого ключа свой bucket
+	for _, apiKey := range keys {
+		assert.NotEqual(t, http.StatusTooManyRequests, getTeam(apiKey))
+		assert.NotEqual(t, http.StatusTooManyRequests, getTeam(apiKey))
+		assert.Equal(t, http.StatusTooManyRequests, getTeam(apiKey))
+	}
+}
@@ -0,0 +1,236 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type repositoryResponse struct {
+	Repository struct {
+		ID             int64  `json:"id"`
+		Host           string `json:"host"`
+		Name           string `json:"name"`
+		TeamName       string `json:"team_name"`
+		ReviewersCount int    `json:"reviewers_count"`
+	} `json:"repository"`
+}
+
+type repositoryPRResponse struct {
+	PR struct {
+		PullRequestId     string   `json:"pull_request_id"`
+		AssignedReviewers []string `json:"assigned_reviewers"`
+		RepositoryId      int64    `json:"repository_id"`
+		Number            int64    `json:"number"`
+	} `json:"pr"`
+	UnderStaffed bool `json:"under_staffed"`
+	DryRun       bool `json:"dry_run"`
+}
+
+func createRepository(t *testing.T, ts *TestServer, name string, reviewersCount int) int64 {
+	w := postJSON(ts, "/repositories/create", map[string]interface{}{
+		"host":            "GitHub.com",
+		"name":            name,
+		"team_name":       "backend",
+		"reviewers_count": reviewersCount,
+	})
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	var resp repositoryResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	return resp.Repository.ID
+}
+
+func createRepositoryPR(t *testing.T, ts *TestServer, repositoryId int64, number int64) repositoryPRResponse {
+	w := postJSON(ts, "/pullRequest/create", map[string]interface{}{
+		"repository_id":     repositoryId,
+		"number":            number,
+		"pull_request_name": "PR",
+		"author_id":         "u1",
+	})
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	var resp repositoryPRResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	return resp
+}
+
+func TestRepositories_CreateAndList(t *testing.T) {
+	ts, err := SetupTestServer(t)
+	require.NoError(t, err)
+	defer ts.Close()
+
+	setupAvailabilityTeam(t, ts)
+
+	w := postJSON(ts, "/repositories/create", map[string]interface{}{
+		"host":      "GitHub.com",
+		"name":      "/acme/backend/",
+		"team_name": "backend",
+	})
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	var resp repositoryResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "github.com", resp.Repository.Host)
+	assert.Equal(t, "acme/backend", resp.Repository.Name)
+	assert.Zero(t, resp.Repository.ReviewersCount)
+
+	w = postJSON(ts, "/repositories/create", map[string]interface{}{
+		"host":      "github.com",
+		"name":      "acme/backend",
+		"team_name": "backend",
+	})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "REPOSITORY_EXISTS")
+
+	w = postJSON(ts, "/repositories/create", map[string]interface{}{
+		"host":      "github.com",
+		"name":      "acme/frontend",
+		"team_name": "missing",
+	})
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	w = httptest.NewRecorder()
+	ts.Server.Handler.ServeHTTP(w, httptest.NewRequest("GET", "/repositories/list?team_name=backend", nil))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var list struct {
+		Repositories []struct {
+			Name string `json:"name"`
+		} `json:"repositories"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+	require.Len(t, list.Repositories, 1)
+	assert.Equal(t, "acme/backend", list.Repositories[0].Name)
+}
+
+func TestRepositories_PullRequestNumbers(t *testing.T) {
+	ts, err := SetupTestServer(t)
+	require.NoError(t, err)
+	defer ts.Close()
+
+	setupAvailabilityTeam(t, ts)
+	backendId := createRepository(t, ts, "acme/backend", 3)
+	frontendId := createRepository(t, ts, "acme/frontend", 0)
+
+	// Идентификатор выводится из репозитория, число ревьюверов - из его настройки
+	resp := createRepositoryPR(t, ts, backendId, 7)
+	assert.Equal(t, "acme/backend#7", resp.PR.PullRequestId)
+	assert.Equal(t, backendId, resp.PR.RepositoryId)
+	assert.Equal(t, int64(7), resp.PR.Number)
+	assert.ElementsMatch(t, []string{"u2", "u3", "u4"}, resp.PR.AssignedReviewers)
+	assert.False(t, resp.UnderStaffed)
+
+	// Номер уникален внутри репозитория, но не глобально
+	w := postJSON(ts, "/pullRequest/create", map[string]interface{}{
+		"pull_request_id":   "other-7",
+		"repository_id":     backendId,
+		"number":            7,
+		"pull_request_name": "PR",
+		"author_id":         "u1",
+	})
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Contains(t, w.Body.String(), "PR_EXISTS")
+
+	resp = createRepositoryPR(t, ts, frontendId, 7)
+	assert.Equal(t, "acme/frontend#7", resp.PR.PullRequestId)
+	assert.Len(t, resp.PR.AssignedReviewers, 2)
+
+	w = postJSON(ts, "/pullRequest/create", map[string]interface{}{
+		"pull_request_name": "PR",
+		"author_id":         "u1",
+		"number":            8,
+	})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// Ревьюверов больше, чем свободных участников
+	w = postJSON(ts, "/repositories/setReviewersCount", map[string]interface{}{"id": frontendId, "reviewers_count": 4})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	resp = createRepositoryPR(t, ts, frontendId, 8)
+	assert.Len(t, resp.PR.AssignedReviewers, 3)
+	assert.True(t, resp.UnderStaffed)
+
+	createPR(t, ts, "pr-plain", "u1")
+
+	w = httptest.NewRecorder()
+	ts.Server.Handler.ServeHTTP(w, httptest.NewRequest("GET", "/users/getReview?user_id=u2", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var review struct {
+		PullRequests []struct {
+			PullRequestId string `json:"pull_request_id"`
+			Number        int64  `json:"number"`
+		} `json:"pull_requests"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &review))
+	assert.Len(t, review.PullRequests, 4)
+
+	w = httptest.NewRecorder()
+	ts.Server.Handler.ServeHTTP(w, httptest.NewRequest("GET", "/users/getReview?user_id=u2&repository_id="+strconv.FormatInt(frontendId, 10), nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	review.PullRequests = nil
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &review))
+	require.Len(t, review.PullRequests, 2)
+	for _, pr := range review.PullRequests {
+		assert.Contains(t, []string{"acme/frontend#7", "acme/frontend#8"}, pr.PullRequestId)
+		assert.NotZero(t, pr.Number)
+	}
+}
+
+func TestRepositories_WebhookResolvesByNumber(t *testing.T) {
+	ts, err := SetupTestServerWithWebhooks(t, testWebhookSecret, testWebhookSecret)
+	require.NoError(t, err)
+	defer ts.Close()
+
+	setupWebhookData(t, ts)
+	repositoryId := createRepository(t, ts, "acme/backend", 0)
+
+	// PR создан через API со своим идентификатором, вебхук находит его по номеру
+	w := postJSON(ts, "/pullRequest/create", map[string]interface{}{
+		"pull_request_id":   "backend-42",
+		"repository_id":     repositoryId,
+		"number":            42,
+		"pull_request_name": "PR",
+		"author_id":         "u1",
+	})
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	w = postGitHubFixture(t, ts, "pull_request_closed_merged.json", "delivery-1", testWebhookSecret)
+	require.Equal(t, http.StatusOK, w.Code)
+	response := decodeWebhookResponse(t, w)
+	assert.Equal(t, "processed", response["status"])
+	assert.Equal(t, "backend-42", response["pull_request_id"])
+
+	pr, err := ts.Storage.GetPullRequestById(context.Background(), "backend-42")
+	require.NoError(t, err)
+	assert.Equal(t, "MERGED", pr.Status)
+
+	_, err = ts.Storage.GetPullRequestById(context.Background(), "acme/backend#42")
+	assert.Error(t, err)
+}
+
+func TestRepositories_WebhookLinksCreatedPR(t *testing.T) {
+	ts, err := SetupTestServerWithWebhooks(t, testWebhookSecret, testWebhookSecret)
+	require.NoError(t, err)
+	defer ts.Close()
+
+	setupWebhookData(t, ts)
+	repositoryId := createRepository(t, ts, "acme/backend", 0)
+
+	w := postGitHubFixture(t, ts, "pull_request_opened.json", "delivery-1", testWebhookSecret)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "processed", decodeWebhookResponse(t, w)["status"])
+
+	pr, err := ts.Storage.GetPullRequestById(context.Background(), "acme/backend#42")
+	require.NoError(t, err)
+	assert.Equal(t, repositoryId, pr.RepositoryId)
+	assert.Equal(t, int64(42), pr.Number)
+}
@@ -0,0 +1,212 @@
+package integration
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func postWithToken(ts *TestServer, path string, token string, reqBody map[string]interface{}) *httptest.ResponseRecorder {
+	body, _ := json.Marshal(reqBody)
+	req := httptest.NewRequest("POST", path, bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+
+	ts.Server.Handler.ServeHTTP(w, req)
+	return w
+}
+
+func TestRoles_PullRequestRules(t *testing.T) {
+	key, verifier := newTestIssuer(t)
+	ts, err := SetupTestServerWithJWT(t, testBootstrapKey, verifier)
+	require.NoError(t, err)
+	defer ts.Close()
+
+	ctx := context.Background()
+	_, err = ts.Storage.Db.Exec(ctx, `
+		INSERT INTO team (name) VALUES ('backend');
+		INSERT INTO users (user_id, username, team_name, is_active) VALUES 
+			('u1', 'Alice', 'backend', true),
+			('u2', 'Bob', 'backend', true),
+			('u3', 'Charlie', 'backend', true),
+			('u4', 'Dave', 'backend', true),
+			('u5', 'Eve', 'backend', true);
+		INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status) VALUES 
+			('pr-1', 'PR 1', 'u1', 'OPEN');
+		INSERT INTO pr_reviewers (pull_request_id, user_id) VALUES ('pr-1', 'u2');
+		INSERT INTO user_roles (user_id, role, team_name) VALUES ('u4', 'team_lead', 'backend');
+	`)
+	require.NoError(t, err)
+
+	outsider := signTestToken(t, key, testClaims("u3"))
+	reviewer := signTestToken(t, key, testClaims("u2"))
+	lead := signTestToken(t, key, testClaims("u4"))
+	author := signTestToken(t, key, testClaims("u1"))
+
+	reassignBody := map[string]interface{}{"pull_request_id": "pr-1", "old_reviewer_id": "u2"}
+	w := postWithToken(ts, "/pullRequest/reassign", outsider, reassignBody)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	// Постороннему с устаревшей версией отвечают 403, а не 412
+	for _, path := range []string{"/pullRequest/reassign", "/pullRequest/merge"} {
+		req := httptest.NewRequest("POST", path, bytes.NewReader([]byte(`{"pull_request_id": "pr-1", "old_reviewer_id": "u2"}`)))
+		req.Header.Set("Authorization", "Bearer "+outsider)
+		req.Header.Set("If-Match", `"99"`)
+		w = httptest.NewRecorder()
+		ts.Server.Handler.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusForbidden, w.Code, path)
+	}
+
+	w = postWithToken(ts, "/pullRequest/reassign", lead, reassignBody)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	mergeBody := map[string]interface{}{"pull_request_id": "pr-1"}
+	w = postWithToken(ts, "/pullRequest/merge", reviewer, mergeBody)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	w = postWithToken(ts, "/pullRequest/merge", lead, mergeBody)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	w = postWithToken(ts, "/pullRequest/merge", author, mergeBody)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+// API ключ - сервисный аккаунт: роли к нему не применяются, достаточно scope
+func TestRoles_APIKeyIsServiceAccount(t *testing.T) {
+	key, verifier := newTestIssuer(t)
+	ts, err := SetupTestServerWithJWT(t, testBootstrapKey, verifier)
+	require.NoError(t, err)
+	defer ts.Close()
+
+	_, err = ts.Storage.Db.Exec(context.Background(), `
+		INSERT INTO team (name) VALUES ('backend');
+		INSERT INTO users (user_id, username, team_name, is_active) VALUES 
+			('u1', 'Alice', 'backend', true),
+			('u2', 'Bob', 'backend', true),
+			('u3', 'Charlie', 'backend', true);
+		INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status) VALUES 
+			('pr-1', 'PR 1', 'u1', 'OPEN');
+		INSERT INTO pr_reviewers (pull_request_id, user_id) VALUES ('pr-1', 'u2');
+	`)
+	require.NoError(t, err)
+
+	postWithKey := func(path string, apiKey string, reqBody map[string]interface{}) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(reqBody)
+		req := httptest.NewRequest("POST", path, bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-API-Key", apiKey)
+		w := httptest.NewRecorder()
+		ts.Server.Handler.ServeHTTP(w, req)
+		return w
+	}
+
+	w := postWithKey("/admin/apiKeys/create", testBootstrapKey, map[string]interface{}{"name": "ci", "scopes": []string{"prs:write"}})
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var created struct {
+		Key string `json:"key"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+
+	// Пользователю-постороннему мерж запрещен, ключу с prs:write - нет
+	mergeBody := map[string]interface{}{"pull_request_id": "pr-1"}
+	w = postWithToken(ts, "/pullRequest/merge", signTestToken(t, key, testClaims("u3")), mergeBody)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	w = postWithKey("/pullRequest/merge", created.Key, mergeBody)
+	assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+}
+
+func TestRoles_SetIsActiveRequiresLead(t *testing.T) {
+	key, verifier := newTestIssuer(t)
+	ts, err := SetupTestServerWithJWT(t, testBootstrapKey, verifier)
+	require.NoError(t, err)
+	defer ts.Close()
+
+	ctx := context.Background()
+	_, err = ts.Storage.Db.Exec(ctx, `
+		INSERT INTO team (name) VALUES ('backend'), ('frontend');
+		INSERT INTO users (user_id, username, team_name, is_active) VALUES 
+			('u1', 'Alice', 'backend', true),
+			('u2', 'Bob', 'backend', true),
+			('f1', 'Frank', 'frontend', true);
+	`)
+	require.NoError(t, err)
+
+	body, _ := json.Marshal(map[string]interface{}{"user_id": "f1", "role": "team_lead", "team_name": "backend"})
+	req := httptest.NewRequest("POST", "/admin/roles/grant", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-API-Key", testBootstrapKey)
+	w := httptest.NewRecorder()
+	ts.Server.Handler.ServeHTTP(w, req)
+	require.Equal(t, http.StatusCreated, w.Code)
+
+	deactivate := map[string]interface{}{"user_id": "u2", "is_active": false}
+
+	w = postWithToken(ts, "/users/setIsActive", signTestToken(t, key, testClaims("u1")), deactivate)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	w = postWithToken(ts, "/users/setIsActive", signTestToken(t, key, testClaims("f1")), deactivate)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	moveToMobile := map[string]interface{}{
+		"team": map[string]interface{}{
+			"team_name": "mobile",
+			"members": []map[string]interface{}{
+				{"user_id": "u1", "username": "Alice", "is_active": true},
+			},
+		},
+	}
+	w = postWithToken(ts, "/team/add", signTestToken(t, key, testClaims("u2")), moveToMobile)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	var teamName string
+	err = ts.Storage.Db.QueryRow(ctx, `SELECT team_name FROM users WHERE user_id = 'u1'`).Scan(&teamName)
+	require.NoError(t, err)
+	assert.Equal(t, "backend", teamName)
+}
+
+func TestRoles_GrantValidation(t *testing.T) {
+	ts, err := SetupTestServerWithAuth(t, testBootstrapKey)
+	require.NoError(t, err)
+	defer ts.Close()
+
+	ctx := context.Background()
+	_, err = ts.Storage.Db.Exec(ctx, `
+		INSERT INTO team (name) VALUES ('backend');
+		INSERT INTO users (user_id, username, team_name, is_active) VALUES ('u1', 'Alice', 'backend', true);
+	`)
+	require.NoError(t, err)
+
+	cases := map[string]struct {
+		body map[string]interface{}
+		code int
+	}{
+		"lead without team": {map[string]interface{}{"user_id": "u1", "role": "team_lead"}, http.StatusBadRequest},
+		"lead unknown team": {map[string]interface{}{"user_id": "u1", "role": "team_lead", "team_name": "nope"}, http.StatusNotFound},
+		"admin with team":   {map[string]interface{}{"user_id": "u1", "role": "admin", "team_name": "backend"}, http.StatusBadRequest},
+		"unknown user":      {map[string]interface{}{"user_id": "ghost", "role": "admin"}, http.StatusNotFound},
+		"admin ok":          {map[string]interface{}{"user_id": "u1", "role": "admin"}, http.StatusCreated},
+		"unsupported role":  {map[string]interface{}{"user_id": "u1", "role": "member"}, http.StatusBadRequest},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			body, _ := json.Marshal(tc.body)
+			req := httptest.NewRequest("POST", "/admin/roles/grant", bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-API-Key", testBootstrapKey)
+			w := httptest.NewRecorder()
+
+			ts.Server.Handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.code, w.Code)
+		})
+	}
+}
@@ -0,0 +1,299 @@
+package integration
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reviewer-service/internal/domain/event"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type reassignResponse struct {
+	PR struct {
+		AssignedReviewers []string `json:"assigned_reviewers"`
+		Version           int64    `json:"version"`
+	} `json:"pr"`
+	ReplacedBy string `json:"replaced_by"`
+	DryRun     bool   `json:"dry_run"`
+}
+
+func setupSelectionTeam(t *testing.T, ts *TestServer) {
+	members := make([]map[string]interface{}, 0, 6)
+	for _, userId := range []string{"u1", "u2", "u3", "u4", "u5", "u6"} {
+		members = append(members, map[string]interface{}{"user_id": userId, "username": userId, "is_active": true})
+	}
+
+	w := postJSON(ts, "/team/add", map[string]interface{}{
+		"team": map[string]interface{}{"team_name": "backend", "members": members},
+	})
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+}
+
+// postCreate создает PR автора u1 с зерном выбора seed
+func postCreate(t *testing.T, ts *TestServer, id string, seed string, dryRun bool) repositoryPRResponse {
+	body, _ := json.Marshal(map[string]interface{}{
+		"pull_request_id":   id,
+		"pull_request_name": "PR",
+		"author_id":         "u1",
+		"dry_run":           dryRun,
+	})
+	req := httptest.NewRequest("POST", "/pullRequest/create", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Selection-Seed", seed)
+	w := httptest.NewRecorder()
+
+	ts.Server.Handler.ServeHTTP(w, req)
+	if dryRun {
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	} else {
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	}
+
+	var resp repositoryPRResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	return resp
+}
+
+func postReassign(t *testing.T, ts *TestServer, oldReviewerId string, seed string, dryRun bool) reassignResponse {
+	body, _ := json.Marshal(map[string]interface{}{
+		"pull_request_id": "pr-1",
+		"old_reviewer_id": oldReviewerId,
+		"dry_run":         dryRun,
+	})
+	req := httptest.NewRequest("POST", "/pullRequest/reassign", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Selection-Seed", seed)
+	w := httptest.NewRecorder()
+
+	ts.Server.Handler.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var resp reassignResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	return resp
+}
+
+func TestSelection_CreateDryRun(t *testing.T) {
+	ts, err := SetupTestServer(t)
+	require.NoError(t, err)
+	defer ts.Close()
+
+	setupSelectionTeam(t, ts)
+
+	w := postJSON(ts, "/pullRequest/create", map[string]interface{}{
+		"pull_request_id":   "pr-1",
+		"pull_request_name": "PR",
+		"author_id":         "u1",
+		"dry_run":           true,
+	})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Empty(t, w.Header().Get("ETag"))
+
+	preview := postCreate(t, ts, "pr-1", "7", true)
+	assert.True(t, preview.DryRun)
+	assert.Len(t, preview.PR.AssignedReviewers, 2)
+	assert.Subset(t, []string{"u2", "u3", "u4", "u5", "u6"}, preview.PR.AssignedReviewers)
+
+	_, err = ts.Storage.GetPullRequestById(context.Background(), "pr-1")
+	assert.Error(t, err)
+	assert.Empty(t, outboxEvents(t, ts, event.TypePullRequestCreated))
+
+	// С тем же зерном создание назначает тех же ревьюверов
+	assert.Equal(t, preview.PR.AssignedReviewers, postCreate(t, ts, "pr-1", "7", false).PR.AssignedReviewers)
+
+	// Пробный запуск проходит те же проверки
+	w = postJSON(ts, "/pullRequest/create", map[string]interface{}{
+		"pull_request_id":   "pr-1",
+		"pull_request_name": "PR",
+		"author_id":         "u1",
+		"dry_run":           true,
+	})
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Contains(t, w.Body.String(), "PR_EXISTS")
+}
+
+func TestSelection_SeededCreate(t *testing.T) {
+	ts, err := SetupTestServer(t)
+	require.NoError(t, err)
+	defer ts.Close()
+
+	setupSelectionTeam(t, ts)
+
+	// Одно зерно - один выбор
+	first := postCreate(t, ts, "pr-1", "1", true).PR.AssignedReviewers
+	assert.Equal(t, first, postCreate(t, ts, "pr-1", "1", true).PR.AssignedReviewers)
+
+	// Все кандидаты равны, поэтому выбор определяется только зерном
+	selections := make(map[string]bool)
+	for seed := 1; seed <= 20; seed++ {
+		reviewers := postCreate(t, ts, "pr-1", strconv.Itoa(seed), true).PR.AssignedReviewers
+		require.Len(t, reviewers, 2)
+		assert.Subset(t, []string{"u2", "u3", "u4", "u5", "u6"}, reviewers)
+		selections[strings.Join(reviewers, ",")] = true
+	}
+	assert.Greater(t, len(selections), 1)
+}
+
+func TestSelection_SeededReassign(t *testing.T) {
+	ts, err := SetupTestServer(t)
+	require.NoError(t, err)
+	defer ts.Close()
+
+	setupSelectionTeam(t, ts)
+	reviewers := postCreate(t, ts, "pr-1", "42", false).PR.AssignedReviewers
+	require.Len(t, reviewers, 2)
+
+	free := slices.DeleteFunc([]string{"u2", "u3", "u4", "u5", "u6"}, func(userId string) bool {
+		return slices.Contains(reviewers, userId)
+	})
+
+	preview := postReassign(t, ts, reviewers[0], "42", true)
+	assert.True(t, preview.DryRun)
+	assert.Contains(t, free, preview.ReplacedBy)
+	assert.NotContains(t, preview.PR.AssignedReviewers, reviewers[0])
+
+	// Одно зерно - один выбор, а пробный запуск ничего не меняет
+	assert.Equal(t, preview.ReplacedBy, postReassign(t, ts, reviewers[0], "42", true).ReplacedBy)
+
+	pr, err := ts.Storage.GetPullRequestById(context.Background(), "pr-1")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, reviewers, pr.AssignedReviewers)
+	assert.Equal(t, int64(1), pr.Version)
+	assert.Empty(t, outboxEvents(t, ts, event.TypeReviewerReassigned))
+
+	resp := postReassign(t, ts, reviewers[0], "42", false)
+	assert.False(t, resp.DryRun)
+	assert.Equal(t, preview.ReplacedBy, resp.ReplacedBy)
+	assert.ElementsMatch(t, preview.PR.AssignedReviewers, resp.PR.AssignedReviewers)
+	assert.Len(t, outboxEvents(t, ts, event.TypeReviewerReassigned), 1)
+
+	req := httptest.NewRequest("POST", "/pullRequest/reassign", bytes.NewReader([]byte(`{"pull_request_id": "pr-1", "old_reviewer_id": "u3"}`)))
+	req.Header.Set("X-Selection-Seed", "not-a-number")
+	w := httptest.NewRecorder()
+	ts.Server.Handler.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestSelection_SuggestReviewers(t *testing.T) {
+	ts, err := SetupTestServer(t)
+	require.NoError(t, err)
+	defer ts.Close()
+
+	setupSelectionTeam(t, ts)
+
+	// Открытый PR автора нагружает u2 и u3
+	_, err = ts.Storage.Db.Exec(context.Background(), `
+		INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id) VALUES ('pr-1', 'PR', 'u1');
+		INSERT INTO pr_reviewers (pull_request_id, user_id) VALUES ('pr-1', 'u2'), ('pr-1', 'u3');
+	`)
+	require.NoError(t, err)
+
+	w := postJSON(ts, "/team/setCodeOwners", map[string]interface{}{
+		"team_name": "backend",
+		"rules":     []map[string]interface{}{{"pattern": "internal/storage/", "owners": []string{"u5"}}},
+	})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	w = postJSON(ts, "/users/setIsActive", map[string]interface{}{"user_id": "u6", "is_active": false})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	w = httptest.NewRecorder()
+	ts.Server.Handler.ServeHTTP(w, httptest.NewRequest("GET", "/pullRequest/suggestReviewers?author_id=u1&changed_files=internal/storage/repo.go", nil))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var resp struct {
+		TeamName   string `json:"team_name"`
+		Candidates []struct {
+			UserId         string   `json:"user_id"`
+			Score          int      `json:"score"`
+			Selected       bool     `json:"selected"`
+			Available      bool     `json:"available"`
+			OpenReviews    int      `json:"open_reviews"`
+			LastReviewedAt *string  `json:"last_reviewed_at"`
+			OwnerPattern   string   `json:"owner_pattern"`
+			Reasons        []string `json:"reasons"`
+		} `json:"candidates"`
+		UnderStaffed bool `json:"under_staffed"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "backend", resp.TeamName)
+	assert.False(t, resp.UnderStaffed)
+
+	userIds := make([]string, 0, len(resp.Candidates))
+	for _, candidate := range resp.Candidates {
+		userIds = append(userIds, candidate.UserId)
+	}
+	// u5 владеет файлами, u4 свободен и не ревьюил автора, u2 и u3 нагружены
+	// и ревьюили автора только что
+	require.Equal(t, []string{"u5", "u4", "u2", "u3", "u6"}, userIds)
+
+	owner, free, loaded, inactive := resp.Candidates[0], resp.Candidates[1], resp.Candidates[2], resp.Candidates[4]
+	assert.Equal(t, 90, owner.Score)
+	assert.True(t, owner.Selected)
+	assert.Equal(t, "internal/storage/", owner.OwnerPattern)
+	assert.Equal(t, []string{"code_owner", "low_load", "not_recently_reviewed_author"}, owner.Reasons)
+
+	assert.Equal(t, 50, free.Score)
+	assert.True(t, free.Selected)
+	assert.Nil(t, free.LastReviewedAt)
+	assert.Equal(t, []string{"rank", "low_load", "not_recently_reviewed_author"}, free.Reasons)
+
+	assert.Equal(t, 20, loaded.Score)
+	assert.False(t, loaded.Selected)
+	assert.Equal(t, 1, loaded.OpenReviews)
+	assert.NotNil(t, loaded.LastReviewedAt)
+	assert.Empty(t, loaded.Reasons)
+
+	assert.False(t, inactive.Available)
+	assert.Zero(t, inactive.Score)
+	assert.Equal(t, []string{"inactive"}, inactive.Reasons)
+
+	// С тем же зерном подсказка совпадает с тем, что назначает создание PR
+	req := httptest.NewRequest("GET", "/pullRequest/suggestReviewers?author_id=u1&changed_files=internal/storage/repo.go", nil)
+	req.Header.Set("X-Selection-Seed", "5")
+	w = httptest.NewRecorder()
+	ts.Server.Handler.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+
+	suggested := make([]string, 0, 2)
+	for _, candidate := range resp.Candidates {
+		if candidate.Selected {
+			suggested = append(suggested, candidate.UserId)
+		}
+	}
+
+	body, _ := json.Marshal(map[string]interface{}{
+		"pull_request_id":   "pr-2",
+		"pull_request_name": "PR",
+		"author_id":         "u1",
+		"changed_files":     []string{"internal/storage/repo.go"},
+	})
+	req = httptest.NewRequest("POST", "/pullRequest/create", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Selection-Seed", "5")
+	w = httptest.NewRecorder()
+	ts.Server.Handler.ServeHTTP(w, req)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	var created repositoryPRResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	assert.ElementsMatch(t, suggested, created.PR.AssignedReviewers)
+	assert.Contains(t, created.PR.AssignedReviewers, "u5")
+
+	w = httptest.NewRecorder()
+	ts.Server.Handler.ServeHTTP(w, httptest.NewRequest("GET", "/pullRequest/suggestReviewers?author_id=u1&count=11", nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = httptest.NewRecorder()
+	ts.Server.Handler.ServeHTTP(w, httptest.NewRequest("GET", "/pullRequest/suggestReviewers?author_id=ghost", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
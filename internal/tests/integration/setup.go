@@ -7,16 +7,21 @@ import (
 	"net/http"
 	"os"
 	"reviewer-service/internal/config"
-	"reviewer-service/internal/http-server/handlers/pullrequest"
-	"reviewer-service/internal/http-server/handlers/team"
-	"reviewer-service/internal/http-server/handlers/user"
-	"reviewer-service/internal/http-server/middleware/logger"
+	"reviewer-service/internal/domain/auth"
+	domainPR "reviewer-service/internal/domain/pullrequest"
+	"reviewer-service/internal/githost"
+	authMiddleware "reviewer-service/internal/http-server/middleware/auth"
+	rateLimitMiddleware "reviewer-service/internal/http-server/middleware/ratelimit"
+	"reviewer-service/internal/http-server/router"
+	"reviewer-service/internal/lib/jwt"
+	"reviewer-service/internal/lib/netguard"
+	"reviewer-service/internal/notifier"
+	"reviewer-service/internal/outbox"
 	"reviewer-service/internal/storage/postgresql"
+	"reviewer-service/internal/stream"
 	"testing"
 	"time"
 
-	"github.com/go-chi/chi/v5"
-	"github.com/go-chi/chi/v5/middleware"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/testcontainers/testcontainers-go"
 	"github.com/testcontainers/testcontainers-go/modules/postgres"
@@ -24,11 +29,11 @@ import (
 )
 
 type TestServer struct {
-	Server      *http.Server
-	Storage     *postgresql.Storage
-	URL         string
-	postgresC   testcontainers.Container
-	postgresCtx context.Context
+	Server         *http.Server
+	Storage        *postgresql.Storage
+	URL            string
+	postgresC      testcontainers.Container
+	postgresCtx    context.Context
 	postgresCancel context.CancelFunc
 }
 
@@ -36,7 +41,88 @@ func init() {
 	os.Setenv("TESTCONTAINERS_RYUK_DISABLED", "true")
 }
 
+type testServerOptions struct {
+	authEnabled         bool
+	bootstrapKey        string
+	tokens              *jwt.Verifier
+	rateLimit           *rateLimitMiddleware.Options
+	addressRateLimit    *rateLimitMiddleware.Options
+	githubSecret        string
+	gitlabToken         string
+	gitHost             githost.Client
+	outboxSink          outbox.Sink
+	deliveries          *outbox.DeliveryOptions
+	notifier            *notifier.Options
+	streamBuffer        int
+	validateRequests    bool
+	withoutLegacyRoutes bool
+}
+
 func SetupTestServer(t *testing.T) (*TestServer, error) {
+	return setupTestServer(t, testServerOptions{})
+}
+
+// SetupTestServerWithAuth поднимает сервер с включенной аутентификацией по API ключам
+func SetupTestServerWithAuth(t *testing.T, bootstrapKey string) (*TestServer, error) {
+	return setupTestServer(t, testServerOptions{authEnabled: true, bootstrapKey: bootstrapKey})
+}
+
+// SetupTestServerWithJWT поднимает сервер, принимающий Bearer токены, проверяемые tokens
+func SetupTestServerWithJWT(t *testing.T, bootstrapKey string, tokens *jwt.Verifier) (*TestServer, error) {
+	return setupTestServer(t, testServerOptions{authEnabled: true, bootstrapKey: bootstrapKey, tokens: tokens})
+}
+
+// SetupTestServerWithRateLimit поднимает сервер с ограничением частоты запросов
+func SetupTestServerWithRateLimit(t *testing.T, opts rateLimitMiddleware.Options) (*TestServer, error) {
+	return setupTestServer(t, testServerOptions{rateLimit: &opts})
+}
+
+// SetupTestServerWithWebhooks поднимает сервер с вебхуками GitHub (подпись githubSecret)
+// и GitLab (токен gitlabToken)
+func SetupTestServerWithWebhooks(t *testing.T, githubSecret string, gitlabToken string) (*TestServer, error) {
+	return setupTestServer(t, testServerOptions{githubSecret: githubSecret, gitlabToken: gitlabToken})
+}
+
+// SetupTestServerWithGitHost поднимает сервер с вебхуками и синхронизацией
+// ревьюверов через client
+func SetupTestServerWithGitHost(t *testing.T, githubSecret string, client githost.Client) (*TestServer, error) {
+	return setupTestServer(t, testServerOptions{githubSecret: githubSecret, gitHost: client})
+}
+
+// SetupTestServerWithOutbox поднимает сервер, рассылающий события outbox в sink
+func SetupTestServerWithOutbox(t *testing.T, sink outbox.Sink) (*TestServer, error) {
+	return setupTestServer(t, testServerOptions{outboxSink: sink})
+}
+
+// SetupTestServerWithSubscriptions поднимает сервер, рассылающий события outbox
+// подписчикам с параметрами доставки opts
+func SetupTestServerWithSubscriptions(t *testing.T, opts outbox.DeliveryOptions) (*TestServer, error) {
+	return setupTestServer(t, testServerOptions{deliveries: &opts})
+}
+
+// SetupTestServerWithNotifications поднимает сервер, отправляющий уведомления
+// о назначениях в чаты команд
+func SetupTestServerWithNotifications(t *testing.T, opts notifier.Options) (*TestServer, error) {
+	return setupTestServer(t, testServerOptions{notifier: &opts})
+}
+
+// SetupTestServerWithStream поднимает сервер с потоком событий /events/stream;
+// bufferSize - буфер клиента в хабе
+func SetupTestServerWithStream(t *testing.T, bufferSize int) (*TestServer, error) {
+	return setupTestServer(t, testServerOptions{streamBuffer: bufferSize})
+}
+
+// SetupTestServerWithValidation поднимает сервер, отклоняющий запросы не по спецификации OpenAPI
+func SetupTestServerWithValidation(t *testing.T) (*TestServer, error) {
+	return setupTestServer(t, testServerOptions{validateRequests: true})
+}
+
+// SetupTestServerWithoutLegacyRoutes поднимает сервер, отвечающий только по путям /api/v1
+func SetupTestServerWithoutLegacyRoutes(t *testing.T) (*TestServer, error) {
+	return setupTestServer(t, testServerOptions{withoutLegacyRoutes: true})
+}
+
+func setupTestServer(t *testing.T, opts testServerOptions) (*TestServer, error) {
 	ctx := context.Background()
 
 	postgresContainer, err := postgres.RunContainer(ctx,
@@ -77,30 +163,115 @@ func SetupTestServer(t *testing.T) (*TestServer, error) {
 
 	log := config.MustConfigureLogger("test")
 
-	router := chi.NewRouter()
-	router.Use(middleware.RequestID)
-	router.Use(logger.New(log))
-	router.Use(middleware.Recoverer)
-	router.Use(middleware.URLFormat)
+	// Получатели подписок в тестах слушают на 127.0.0.1
+	subscriptionTargets, err := netguard.New([]string{"127.0.0.0/8"})
+	if err != nil {
+		postgresCancel()
+		pool.Close()
+		postgresContainer.Terminate(ctx)
+		return nil, err
+	}
+
+	outboxSink := opts.outboxSink
+	if opts.deliveries != nil {
+		deliveryOptions := *opts.deliveries
+		deliveryOptions.Targets = subscriptionTargets
+
+		outboxSink = outbox.NewSubscriptionSink(storage)
+		outbox.NewDeliverer(log, storage, storage, deliveryOptions).Start(postgresCtx)
+	}
+
+	if opts.notifier != nil {
+		chatNotifier, err := notifier.New(log, storage, *opts.notifier)
+		if err != nil {
+			postgresCancel()
+			pool.Close()
+			postgresContainer.Terminate(ctx)
+			return nil, fmt.Errorf("failed to create notifier: %w", err)
+		}
+		outboxSink = chatNotifier
+	}
+
+	var eventHub *stream.Hub
+	if opts.streamBuffer > 0 {
+		eventHub = stream.NewHub(opts.streamBuffer)
+		outboxSink = eventHub
+	}
+
+	if outboxSink != nil {
+		outbox.NewDispatcher(log, storage, storage, outboxSink, outbox.Options{
+			PollInterval:   20 * time.Millisecond,
+			MaxAttempts:    3,
+			InitialBackoff: 20 * time.Millisecond,
+			MaxBackoff:     100 * time.Millisecond,
+		}).Start(postgresCtx)
+	}
+
+	var reviewerSyncer domainPR.ReviewerSyncer
+	if opts.gitHost != nil {
+		syncer := githost.NewSyncer(log, opts.gitHost, storage, githost.Options{
+			InitialBackoff: 10 * time.Millisecond,
+			MaxBackoff:     50 * time.Millisecond,
+		})
+		syncer.Start(postgresCtx)
+		reviewerSyncer = syncer
+	}
+
+	// Random не задан: без X-Selection-Seed равные кандидаты идут в порядке
+	// user_id, поэтому тесты политики назначения детерминированы
+	routerOptions := router.Options{
+		SeedHeader:       true,
+		ValidateRequests: opts.validateRequests,
+		LegacyRoutes:     !opts.withoutLegacyRoutes,
+		GitHubSecret:     opts.githubSecret,
+		GitLabToken:      opts.gitlabToken,
+		EventHub:         eventHub,
+		StreamHeartbeat:  time.Second,
+		Syncer:           reviewerSyncer,
+		RateLimit:        opts.rateLimit,
+		AddressRateLimit: opts.addressRateLimit,
+
+		SubscriptionTargets: subscriptionTargets,
+	}
+	if opts.authEnabled {
+		routerOptions.Auth = &authMiddleware.Options{
+			APIKeys:      storage,
+			BootstrapKey: opts.bootstrapKey,
+			Tokens:       opts.tokens,
+			Users:        storage,
+			Roles:        storage,
+			UserClaim:    "sub",
+			UserScopes:   []string{auth.ScopeRead, auth.ScopePRsWrite, auth.ScopeUsersWrite, auth.ScopeTeamsWrite},
+		}
+	}
+
+	apiHandler, err := router.New(log, storage, routerOptions)
+	if err != nil {
+		postgresCancel()
+		pool.Close()
+		postgresContainer.Terminate(ctx)
+		return nil, err
+	}
 
-	router.Post("/team/add", team.Save(log, storage, storage))
-	router.Get("/team/get", team.Get(log, storage))
-	router.Post("/users/setIsActive", user.SetIsActive(log, storage, storage))
-	router.Get("/users/getReview", user.GetReview(log, storage))
-	router.Post("/pullRequest/create", pullrequest.Create(log, storage, storage))
-	router.Post("/pullRequest/merge", pullrequest.Merge(log, storage, storage))
-	router.Post("/pullRequest/reassign", pullrequest.Reassign(log, storage, storage))
+	// Весь трафик тестов сверяется со спецификацией
+	handler, err := newContractChecker(t, apiHandler)
+	if err != nil {
+		postgresCancel()
+		pool.Close()
+		postgresContainer.Terminate(ctx)
+		return nil, fmt.Errorf("failed to create contract checker: %w", err)
+	}
 
 	server := &http.Server{
 		Addr:    ":0",
-		Handler: router,
+		Handler: handler,
 	}
 
 	return &TestServer{
-		Server:        server,
-		Storage:      storage,
-		postgresC:    postgresContainer,
-		postgresCtx:  postgresCtx,
+		Server:         server,
+		Storage:        storage,
+		postgresC:      postgresContainer,
+		postgresCtx:    postgresCtx,
 		postgresCancel: postgresCancel,
 	}, nil
 }
@@ -156,14 +327,30 @@ func (ts *TestServer) Close() error {
 
 func setupTestDatabase(ctx context.Context, pool *pgxpool.Pool) error {
 	schema := `
+		DROP TABLE IF EXISTS subscription_deliveries CASCADE;
+		DROP TABLE IF EXISTS subscriptions CASCADE;
+		DROP TABLE IF EXISTS outbox CASCADE;
+		DROP TABLE IF EXISTS webhook_deliveries CASCADE;
+		DROP TABLE IF EXISTS git_identities CASCADE;
+		DROP TABLE IF EXISTS user_roles CASCADE;
+		DROP TABLE IF EXISTS api_keys CASCADE;
+		DROP TABLE IF EXISTS team_sla CASCADE;
+		DROP TABLE IF EXISTS user_unavailability CASCADE;
+		DROP TABLE IF EXISTS code_owner_rules CASCADE;
+		DROP TABLE IF EXISTS team_composition_rules CASCADE;
+		DROP TABLE IF EXISTS reviewer_exclusions CASCADE;
+		DROP TABLE IF EXISTS team_repeat_limits CASCADE;
 		DROP TABLE IF EXISTS pr_reviewers CASCADE;
 		DROP TABLE IF EXISTS pull_requests CASCADE;
+		DROP TABLE IF EXISTS repositories CASCADE;
 		DROP TABLE IF EXISTS users CASCADE;
 		DROP TABLE IF EXISTS team CASCADE;
 
 		CREATE TABLE team (
 			id BIGSERIAL PRIMARY KEY,
-			name VARCHAR(255) UNIQUE NOT NULL
+			name VARCHAR(255) UNIQUE NOT NULL,
+			chat_webhook_url TEXT NOT NULL DEFAULT '',
+			default_max_open_reviews INT CHECK (default_max_open_reviews > 0)
 		);
 
 		CREATE TABLE users (
@@ -171,7 +358,20 @@ func setupTestDatabase(ctx context.Context, pool *pgxpool.Pool) error {
 			user_id VARCHAR(255) UNIQUE NOT NULL,
 			username VARCHAR(255) NOT NULL,
 			team_name VARCHAR(255) NOT NULL,
-			is_active BOOLEAN NOT NULL DEFAULT true
+			is_active BOOLEAN NOT NULL DEFAULT true,
+			chat_handle VARCHAR(255) NOT NULL DEFAULT '',
+			max_open_reviews INT CHECK (max_open_reviews > 0),
+			seniority VARCHAR(16) CHECK (seniority IN ('junior', 'middle', 'senior', 'lead'))
+		);
+
+		CREATE TABLE repositories (
+			id BIGSERIAL PRIMARY KEY,
+			host VARCHAR(255) NOT NULL,
+			name VARCHAR(255) NOT NULL,
+			team_name VARCHAR(255) NOT NULL REFERENCES team(name) ON DELETE CASCADE,
+			reviewers_count INT CHECK (reviewers_count > 0),
+			created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+			UNIQUE (host, name)
 		);
 
 		CREATE TABLE pull_requests (
@@ -181,12 +381,19 @@ func setupTestDatabase(ctx context.Context, pool *pgxpool.Pool) error {
 			author_id VARCHAR(255) NOT NULL,
 			status VARCHAR(50) NOT NULL DEFAULT 'OPEN',
 			created_at TIMESTAMP NOT NULL DEFAULT NOW(),
-			merged_at TIMESTAMP
+			merged_at TIMESTAMP,
+			version BIGINT NOT NULL DEFAULT 1,
+			repository_id BIGINT REFERENCES repositories(id),
+			number BIGINT
 		);
 
+		CREATE UNIQUE INDEX IF NOT EXISTS idx_pull_requests_repository_number ON pull_requests(repository_id, number) WHERE repository_id IS NOT NULL;
+
 		CREATE TABLE pr_reviewers (
 			pull_request_id VARCHAR(255) NOT NULL,
 			user_id VARCHAR(255) NOT NULL,
+			assigned_at TIMESTAMP NOT NULL DEFAULT NOW(),
+			sla_breached_at TIMESTAMP,
 			PRIMARY KEY (pull_request_id, user_id),
 			FOREIGN KEY (pull_request_id) REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE
 		);
@@ -194,6 +401,140 @@ func setupTestDatabase(ctx context.Context, pool *pgxpool.Pool) error {
 		CREATE INDEX IF NOT EXISTS idx_pull_requests_author_id ON pull_requests(author_id);
 		CREATE INDEX IF NOT EXISTS idx_pull_requests_status ON pull_requests(status);
 		CREATE INDEX IF NOT EXISTS idx_pr_reviewers_user_id ON pr_reviewers(user_id);
+
+		CREATE TABLE team_sla (
+			team_name VARCHAR(255) PRIMARY KEY REFERENCES team(name) ON DELETE CASCADE,
+			review_hours INT NOT NULL CHECK (review_hours > 0),
+			working_days_only BOOLEAN NOT NULL DEFAULT true,
+			auto_reassign BOOLEAN NOT NULL DEFAULT false,
+			updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+		);
+
+		CREATE TABLE user_unavailability (
+			id BIGSERIAL PRIMARY KEY,
+			user_id VARCHAR(255) NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+			starts_at TIMESTAMP NOT NULL,
+			ends_at TIMESTAMP NOT NULL,
+			reason TEXT NOT NULL DEFAULT '',
+			created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+			CHECK (ends_at > starts_at)
+		);
+
+		CREATE INDEX IF NOT EXISTS idx_user_unavailability_user_id ON user_unavailability(user_id, ends_at);
+
+		CREATE TABLE code_owner_rules (
+			id BIGSERIAL PRIMARY KEY,
+			team_name VARCHAR(255) NOT NULL REFERENCES team(name) ON DELETE CASCADE,
+			position INT NOT NULL,
+			pattern TEXT NOT NULL,
+			owners TEXT[] NOT NULL,
+			UNIQUE (team_name, position)
+		);
+
+		CREATE TABLE team_composition_rules (
+			team_name VARCHAR(255) NOT NULL REFERENCES team(name) ON DELETE CASCADE,
+			min_seniority VARCHAR(16) NOT NULL CHECK (min_seniority IN ('junior', 'middle', 'senior', 'lead')),
+			min_count INT NOT NULL CHECK (min_count > 0),
+			PRIMARY KEY (team_name, min_seniority)
+		);
+
+		CREATE TABLE reviewer_exclusions (
+			team_name VARCHAR(255) NOT NULL REFERENCES team(name) ON DELETE CASCADE,
+			user_id VARCHAR(255) NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+			other_user_id VARCHAR(255) NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+			PRIMARY KEY (team_name, user_id, other_user_id),
+			CHECK (user_id < other_user_id)
+		);
+
+		CREATE TABLE team_repeat_limits (
+			team_name VARCHAR(255) PRIMARY KEY REFERENCES team(name) ON DELETE CASCADE,
+			max_reviews INT NOT NULL CHECK (max_reviews > 0),
+			window_days INT NOT NULL CHECK (window_days > 0)
+		);
+
+		CREATE TABLE api_keys (
+			id BIGSERIAL PRIMARY KEY,
+			name VARCHAR(255) NOT NULL,
+			prefix VARCHAR(16) NOT NULL,
+			key_hash VARCHAR(64) UNIQUE NOT NULL,
+			scopes TEXT[] NOT NULL DEFAULT '{}',
+			created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+			revoked_at TIMESTAMP
+		);
+
+		CREATE TABLE user_roles (
+			id BIGSERIAL PRIMARY KEY,
+			user_id VARCHAR(255) NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+			role VARCHAR(50) NOT NULL,
+			team_name VARCHAR(255) NOT NULL DEFAULT '',
+			UNIQUE (user_id, role, team_name)
+		);
+
+		CREATE TABLE git_identities (
+			id BIGSERIAL PRIMARY KEY,
+			provider VARCHAR(32) NOT NULL,
+			login VARCHAR(255) NOT NULL,
+			user_id VARCHAR(255) NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+			external_id VARCHAR(64),
+			UNIQUE (provider, login)
+		);
+
+		CREATE UNIQUE INDEX idx_git_identities_external_id ON git_identities(provider, external_id) WHERE external_id IS NOT NULL;
+
+		CREATE TABLE webhook_deliveries (
+			provider VARCHAR(32) NOT NULL,
+			delivery_id VARCHAR(255) NOT NULL,
+			event VARCHAR(64) NOT NULL,
+			received_at TIMESTAMP NOT NULL DEFAULT NOW(),
+			PRIMARY KEY (provider, delivery_id)
+		);
+
+		CREATE TABLE outbox (
+			id BIGSERIAL PRIMARY KEY,
+			aggregate_type VARCHAR(64) NOT NULL,
+			aggregate_id VARCHAR(255) NOT NULL,
+			event_type VARCHAR(64) NOT NULL,
+			payload JSONB NOT NULL,
+			created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+			sent_at TIMESTAMP,
+			attempts INT NOT NULL DEFAULT 0,
+			last_error TEXT,
+			team_name VARCHAR(255) NOT NULL DEFAULT '',
+			next_attempt_at TIMESTAMP NOT NULL DEFAULT NOW(),
+			claimed_until TIMESTAMP,
+			failed_at TIMESTAMP
+		);
+
+		CREATE INDEX IF NOT EXISTS idx_outbox_unsent ON outbox(id) WHERE sent_at IS NULL;
+		CREATE INDEX IF NOT EXISTS idx_outbox_pending ON outbox(aggregate_type, aggregate_id, id) WHERE sent_at IS NULL AND failed_at IS NULL;
+
+		CREATE TABLE subscriptions (
+			id BIGSERIAL PRIMARY KEY,
+			url TEXT NOT NULL,
+			secret VARCHAR(255) NOT NULL,
+			event_types TEXT[] NOT NULL,
+			team_name VARCHAR(255) NOT NULL DEFAULT '',
+			active BOOLEAN NOT NULL DEFAULT true,
+			created_at TIMESTAMP NOT NULL DEFAULT NOW()
+		);
+
+		CREATE TABLE subscription_deliveries (
+			id BIGSERIAL PRIMARY KEY,
+			subscription_id BIGINT NOT NULL REFERENCES subscriptions(id) ON DELETE CASCADE,
+			event_id BIGINT NOT NULL,
+			event_type VARCHAR(64) NOT NULL,
+			payload JSONB NOT NULL,
+			status VARCHAR(16) NOT NULL DEFAULT 'pending',
+			attempts INT NOT NULL DEFAULT 0,
+			next_attempt_at TIMESTAMP NOT NULL DEFAULT NOW(),
+			last_status_code INT NOT NULL DEFAULT 0,
+			last_error TEXT NOT NULL DEFAULT '',
+			created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+			delivered_at TIMESTAMP,
+			UNIQUE (subscription_id, event_id)
+		);
+
+		CREATE INDEX IF NOT EXISTS idx_subscription_deliveries_due ON subscription_deliveries(next_attempt_at) WHERE status = 'pending';
 	`
 
 	_, err := pool.Exec(ctx, schema)
@@ -0,0 +1,242 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reviewer-service/internal/config"
+	"reviewer-service/internal/domain/event"
+	"reviewer-service/internal/lib/clock"
+	"reviewer-service/internal/sla"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newSLAScheduler(ts *TestServer, now time.Time) (*sla.Scheduler, *clock.Fake) {
+	fakeClock := clock.NewFake(now)
+	scheduler := sla.NewScheduler(config.MustConfigureLogger("test"), ts.Storage, ts.Storage, ts.Storage, nil, sla.Options{
+		Clock: fakeClock,
+	})
+	return scheduler, fakeClock
+}
+
+func setReviewSLA(t *testing.T, ts *TestServer, reqBody map[string]interface{}) {
+	w := postJSON(ts, "/team/setReviewSla", reqBody)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+}
+
+// setAssignedAt переносит назначения PR в прошлое
+func setAssignedAt(t *testing.T, ts *TestServer, pullRequestId string, reviewerId string, at time.Time) {
+	_, err := ts.Storage.Db.Exec(context.Background(),
+		"UPDATE pr_reviewers SET assigned_at = $3 WHERE pull_request_id = $1 AND ($2 = '' OR user_id = $2)",
+		pullRequestId, reviewerId, at)
+	require.NoError(t, err)
+}
+
+func outboxEvents(t *testing.T, ts *TestServer, eventType string) []*event.Event {
+	events, err := ts.Storage.GetUnsentEvents(context.Background(), 1000)
+	require.NoError(t, err)
+
+	filtered := make([]*event.Event, 0)
+	for _, e := range events {
+		if e.Type == eventType {
+			filtered = append(filtered, e)
+		}
+	}
+	return filtered
+}
+
+func TestSLA_SetAndGet(t *testing.T) {
+	ts, err := SetupTestServer(t)
+	require.NoError(t, err)
+	defer ts.Close()
+
+	setupStreamData(t, ts)
+
+	setReviewSLA(t, ts, map[string]interface{}{"team_name": "backend", "review_hours": 24})
+
+	req := httptest.NewRequest("GET", "/team/getReviewSla?team_name=backend", nil)
+	w := httptest.NewRecorder()
+	ts.Server.Handler.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var policy map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &policy))
+	assert.Equal(t, true, policy["enabled"])
+	assert.Equal(t, float64(24), policy["review_hours"])
+	assert.Equal(t, true, policy["working_days_only"])
+	assert.Equal(t, false, policy["auto_reassign"])
+
+	// Нулевой срок отключает SLA
+	setReviewSLA(t, ts, map[string]interface{}{"team_name": "backend", "review_hours": 0})
+
+	req = httptest.NewRequest("GET", "/team/getReviewSla?team_name=backend", nil)
+	w = httptest.NewRecorder()
+	ts.Server.Handler.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	w = postJSON(ts, "/team/setReviewSla", map[string]interface{}{"team_name": "backend", "review_hours": -1})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = postJSON(ts, "/team/setReviewSla", map[string]interface{}{"team_name": "missing", "review_hours": 4})
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestSLA_BreachRecordedOnce(t *testing.T) {
+	ts, err := SetupTestServer(t)
+	require.NoError(t, err)
+	defer ts.Close()
+
+	setupStreamData(t, ts)
+	setReviewSLA(t, ts, map[string]interface{}{
+		"team_name":         "backend",
+		"review_hours":      4,
+		"working_days_only": false,
+	})
+
+	createPR(t, ts, "pr-1", "u1")
+	createPR(t, ts, "pr-2", "u2")
+	createPR(t, ts, "pr-3", "u3")
+
+	assignedAt := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	for _, prId := range []string{"pr-1", "pr-2", "pr-3"} {
+		setAssignedAt(t, ts, prId, "", assignedAt)
+	}
+
+	// Закрытые PR и PR команд без SLA не проверяются
+	w := postJSON(ts, "/pullRequest/merge", map[string]interface{}{"pull_request_id": "pr-2"})
+	require.Equal(t, http.StatusOK, w.Code)
+
+	scheduler, fakeClock := newSLAScheduler(ts, assignedAt.Add(3*time.Hour))
+
+	breached, err := scheduler.CheckOnce(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 0, breached)
+
+	fakeClock.Advance(time.Hour)
+	breached, err = scheduler.CheckOnce(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, breached)
+
+	fakeClock.Advance(time.Hour)
+	breached, err = scheduler.CheckOnce(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 0, breached)
+
+	events := outboxEvents(t, ts, event.TypeSLABreached)
+	require.Len(t, events, 1)
+	assert.Equal(t, "pr-1", events[0].AggregateId)
+	assert.Equal(t, "backend", events[0].TeamName)
+
+	var payload event.SLABreachedPayload
+	require.NoError(t, json.Unmarshal(events[0].Payload, &payload))
+	assert.Equal(t, "u2", payload.ReviewerId)
+	assert.Equal(t, 4, payload.ReviewHours)
+	assert.True(t, payload.AssignedAt.Equal(assignedAt))
+
+	// Ревьювер остается, если замена не включена
+	pr, err := ts.Storage.GetPullRequestById(context.Background(), "pr-1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u2"}, pr.AssignedReviewers)
+}
+
+func TestSLA_WorkingDaysOnly(t *testing.T) {
+	ts, err := SetupTestServer(t)
+	require.NoError(t, err)
+	defer ts.Close()
+
+	setupStreamData(t, ts)
+	setReviewSLA(t, ts, map[string]interface{}{"team_name": "backend", "review_hours": 8})
+
+	createPR(t, ts, "pr-1", "u1")
+
+	// Пятница 20:00: до конца дня 4 часа, выходные не считаются
+	setAssignedAt(t, ts, "pr-1", "", time.Date(2026, 3, 6, 20, 0, 0, 0, time.UTC))
+
+	scheduler, fakeClock := newSLAScheduler(ts, time.Date(2026, 3, 8, 23, 0, 0, 0, time.UTC))
+
+	breached, err := scheduler.CheckOnce(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 0, breached)
+
+	fakeClock.Set(time.Date(2026, 3, 9, 3, 59, 0, 0, time.UTC))
+	breached, err = scheduler.CheckOnce(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 0, breached)
+
+	fakeClock.Set(time.Date(2026, 3, 9, 4, 0, 0, 0, time.UTC))
+	breached, err = scheduler.CheckOnce(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, breached)
+}
+
+func TestSLA_AutoReassign(t *testing.T) {
+	ts, err := SetupTestServer(t)
+	require.NoError(t, err)
+	defer ts.Close()
+
+	w := postJSON(ts, "/team/add", map[string]interface{}{
+		"team": map[string]interface{}{
+			"team_name": "backend",
+			"members": []map[string]interface{}{
+				{"user_id": "u1", "username": "Alice", "is_active": true},
+				{"user_id": "u2", "username": "Bob", "is_active": true},
+				{"user_id": "u3", "username": "Charlie", "is_active": true},
+				{"user_id": "u4", "username": "Dave", "is_active": true},
+			},
+		},
+	})
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	setReviewSLA(t, ts, map[string]interface{}{
+		"team_name":         "backend",
+		"review_hours":      4,
+		"working_days_only": false,
+		"auto_reassign":     true,
+	})
+
+	createPR(t, ts, "pr-1", "u1")
+
+	pr, err := ts.Storage.GetPullRequestById(context.Background(), "pr-1")
+	require.NoError(t, err)
+	require.Len(t, pr.AssignedReviewers, 2)
+	first, second := pr.AssignedReviewers[0], pr.AssignedReviewers[1]
+
+	assignedAt := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	setAssignedAt(t, ts, "pr-1", first, assignedAt)
+	setAssignedAt(t, ts, "pr-1", second, assignedAt.Add(time.Minute))
+
+	scheduler, _ := newSLAScheduler(ts, assignedAt.Add(5*time.Hour))
+
+	breached, err := scheduler.CheckOnce(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, breached)
+
+	// Первого заменяет единственный свободный участник, для второго кандидатов
+	// не осталось, и он остается на PR
+	pr, err = ts.Storage.GetPullRequestById(context.Background(), "pr-1")
+	require.NoError(t, err)
+	assert.Len(t, pr.AssignedReviewers, 2)
+	assert.NotContains(t, pr.AssignedReviewers, first)
+	assert.Contains(t, pr.AssignedReviewers, second)
+
+	reassigned := outboxEvents(t, ts, event.TypeReviewerReassigned)
+	require.Len(t, reassigned, 1)
+
+	var payload event.ReviewerReassignedPayload
+	require.NoError(t, json.Unmarshal(reassigned[0].Payload, &payload))
+	assert.Equal(t, first, payload.OldReviewerId)
+	assert.Equal(t, event.AssignReasonSLABreached, payload.Reason)
+
+	assigned := outboxEvents(t, ts, event.TypeReviewerAssigned)
+	var assignedPayload event.ReviewerAssignedPayload
+	require.NoError(t, json.Unmarshal(assigned[len(assigned)-1].Payload, &assignedPayload))
+	assert.Equal(t, event.AssignReasonSLABreached, assignedPayload.Reason)
+	assert.Equal(t, first, assignedPayload.ReplacedReviewerId)
+
+	assert.Len(t, outboxEvents(t, ts, event.TypeSLABreached), 2)
+}
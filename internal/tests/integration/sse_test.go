@@ -0,0 +1,240 @@
+package integration
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reviewer-service/internal/domain/event"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sseClient читает поток /events/stream и отдает события в канал
+type sseClient struct {
+	events chan *event.Event
+	cancel context.CancelFunc
+}
+
+func openStream(t *testing.T, ts *TestServer, query string, lastEventId int64) *sseClient {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	req, err := http.NewRequestWithContext(ctx, "GET", ts.URL+"/events/stream"+query, nil)
+	require.NoError(t, err)
+	if lastEventId > 0 {
+		req.Header.Set("Last-Event-ID", strconv.FormatInt(lastEventId, 10))
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	client := &sseClient{
+		events: make(chan *event.Event, 100),
+		cancel: cancel,
+	}
+
+	go func() {
+		defer resp.Body.Close()
+		defer close(client.events)
+
+		var id, data string
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			line := scanner.Text()
+			switch {
+			case strings.HasPrefix(line, "id: "):
+				id = strings.TrimPrefix(line, "id: ")
+			case strings.HasPrefix(line, "data: "):
+				data = strings.TrimPrefix(line, "data: ")
+			case line == "" && data != "":
+				var e event.Event
+				if err := json.Unmarshal([]byte(data), &e); err == nil && strconv.FormatInt(e.ID, 10) == id {
+					client.events <- &e
+				}
+				id, data = "", ""
+			}
+		}
+	}()
+
+	t.Cleanup(cancel)
+	return client
+}
+
+// next ждет следующее событие потока
+func (c *sseClient) next(t *testing.T) *event.Event {
+	select {
+	case e, ok := <-c.events:
+		require.True(t, ok, "stream closed")
+		return e
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "no event received")
+		return nil
+	}
+}
+
+// expectNone проверяет, что за короткое время событий не пришло
+func (c *sseClient) expectNone(t *testing.T) {
+	select {
+	case e := <-c.events:
+		assert.Failf(t, "unexpected event", "%s %s", e.Type, e.AggregateId)
+	case <-time.After(300 * time.Millisecond):
+	}
+}
+
+func setupStreamData(t *testing.T, ts *TestServer) {
+	for _, team := range []struct {
+		name    string
+		members []map[string]interface{}
+	}{
+		{"backend", []map[string]interface{}{
+			{"user_id": "u1", "username": "Alice", "is_active": true},
+			{"user_id": "u2", "username": "Bob", "is_active": true},
+		}},
+		{"frontend", []map[string]interface{}{
+			{"user_id": "u3", "username": "Charlie", "is_active": true},
+			{"user_id": "u4", "username": "Dave", "is_active": true},
+		}},
+	} {
+		w := postJSON(ts, "/team/add", map[string]interface{}{
+			"team": map[string]interface{}{"team_name": team.name, "members": team.members},
+		})
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	}
+}
+
+func createPR(t *testing.T, ts *TestServer, id string, authorId string) {
+	w := postJSON(ts, "/pullRequest/create", map[string]interface{}{
+		"pull_request_id":   id,
+		"pull_request_name": "PR " + id,
+		"author_id":         authorId,
+	})
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+}
+
+func TestStream_LiveEventsFilteredByTeam(t *testing.T) {
+	ts, err := SetupTestServerWithStream(t, 16)
+	require.NoError(t, err)
+	defer ts.Close()
+	require.NoError(t, ts.Start())
+
+	setupStreamData(t, ts)
+
+	backend := openStream(t, ts, "?team_name=backend", 0)
+	merges := openStream(t, ts, "?types=pr.merged", 0)
+
+	createPR(t, ts, "pr-1", "u1")
+	createPR(t, ts, "pr-2", "u3")
+
+	e := backend.next(t)
+	assert.Equal(t, event.TypePullRequestCreated, e.Type)
+	assert.Equal(t, "pr-1", e.AggregateId)
+	assert.Equal(t, "backend", e.TeamName)
+
+	e = backend.next(t)
+	assert.Equal(t, event.TypeReviewerAssigned, e.Type)
+	assert.Contains(t, string(e.Payload), `"reviewer_id":"u2"`)
+	backend.expectNone(t)
+
+	w := postJSON(ts, "/pullRequest/merge", map[string]interface{}{"pull_request_id": "pr-2"})
+	require.Equal(t, http.StatusOK, w.Code)
+
+	e = merges.next(t)
+	assert.Equal(t, event.TypePullRequestMerged, e.Type)
+	assert.Equal(t, "pr-2", e.AggregateId)
+	merges.expectNone(t)
+}
+
+func TestStream_FilterByUser(t *testing.T) {
+	ts, err := SetupTestServerWithStream(t, 16)
+	require.NoError(t, err)
+	defer ts.Close()
+	require.NoError(t, ts.Start())
+
+	setupStreamData(t, ts)
+
+	stream := openStream(t, ts, "?user_id=u4", 0)
+
+	createPR(t, ts, "pr-1", "u1")
+	createPR(t, ts, "pr-2", "u3")
+
+	// События PR, где u4 назначен ревьювером
+	e := stream.next(t)
+	assert.Equal(t, event.TypePullRequestCreated, e.Type)
+	assert.Equal(t, "pr-2", e.AggregateId)
+
+	e = stream.next(t)
+	assert.Equal(t, event.TypeReviewerAssigned, e.Type)
+	assert.Equal(t, "pr-2", e.AggregateId)
+	stream.expectNone(t)
+}
+
+func TestStream_ResumeWithLastEventId(t *testing.T) {
+	ts, err := SetupTestServerWithStream(t, 16)
+	require.NoError(t, err)
+	defer ts.Close()
+	require.NoError(t, ts.Start())
+
+	setupStreamData(t, ts)
+
+	createPR(t, ts, "pr-1", "u1")
+	createPR(t, ts, "pr-2", "u3")
+
+	var firstId int64
+	err = ts.Storage.Db.QueryRow(context.Background(),
+		"SELECT MIN(id) FROM outbox WHERE aggregate_id = 'pr-1'").Scan(&firstId)
+	require.NoError(t, err)
+
+	// Клиент видел первое событие и переподключается
+	stream := openStream(t, ts, "", firstId)
+
+	expected := []struct{ eventType, prId string }{
+		{event.TypeReviewerAssigned, "pr-1"},
+		{event.TypePullRequestCreated, "pr-2"},
+		{event.TypeReviewerAssigned, "pr-2"},
+	}
+	lastId := firstId
+	for _, exp := range expected {
+		e := stream.next(t)
+		assert.Equal(t, exp.eventType, e.Type)
+		assert.Equal(t, exp.prId, e.AggregateId)
+		assert.Greater(t, e.ID, lastId)
+		lastId = e.ID
+	}
+
+	// После журнала идут новые события без повторов
+	w := postJSON(ts, "/pullRequest/merge", map[string]interface{}{"pull_request_id": "pr-1"})
+	require.Equal(t, http.StatusOK, w.Code)
+
+	e := stream.next(t)
+	assert.Equal(t, event.TypePullRequestMerged, e.Type)
+	assert.Equal(t, "pr-1", e.AggregateId)
+	assert.Greater(t, e.ID, lastId)
+	stream.expectNone(t)
+}
+
+func TestStream_Validation(t *testing.T) {
+	ts, err := SetupTestServerWithStream(t, 16)
+	require.NoError(t, err)
+	defer ts.Close()
+
+	for _, path := range []string{
+		"/events/stream?types=pr.created,pr.deleted",
+		"/events/stream?last_event_id=abc",
+		"/events/stream?last_event_id=-1",
+	} {
+		req := httptest.NewRequest("GET", path, nil)
+		w := httptest.NewRecorder()
+		ts.Server.Handler.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, path)
+		assert.Contains(t, w.Body.String(), "VALIDATION_ERROR")
+	}
+}
@@ -0,0 +1,282 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reviewer-service/internal/domain/event"
+	"reviewer-service/internal/outbox"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSubscriptionSecret = "subscription-test-secret"
+
+type receivedRequest struct {
+	Header http.Header
+	Body   []byte
+}
+
+// subscriptionReceiver - httptest получатель, запоминающий запросы. Пока fail
+// установлен, отвечает 500
+type subscriptionReceiver struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	requests []receivedRequest
+	fail     atomic.Bool
+}
+
+func newSubscriptionReceiver(t *testing.T) *subscriptionReceiver {
+	receiver := &subscriptionReceiver{}
+	receiver.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		receiver.mu.Lock()
+		receiver.requests = append(receiver.requests, receivedRequest{Header: r.Header.Clone(), Body: body})
+		receiver.mu.Unlock()
+
+		if receiver.fail.Load() {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(receiver.Close)
+	return receiver
+}
+
+func (r *subscriptionReceiver) Requests() []receivedRequest {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]receivedRequest(nil), r.requests...)
+}
+
+func setupSubscriptionData(t *testing.T, ts *TestServer) {
+	_, err := ts.Storage.Db.Exec(context.Background(), `
+		INSERT INTO team (name) VALUES ('backend'), ('frontend');
+		INSERT INTO users (user_id, username, team_name, is_active) VALUES 
+			('u1', 'Alice', 'backend', true),
+			('u2', 'Bob', 'backend', true),
+			('u3', 'Charlie', 'backend', true),
+			('f1', 'Frank', 'frontend', true),
+			('f2', 'Grace', 'frontend', true);
+	`)
+	require.NoError(t, err)
+}
+
+func createSubscription(t *testing.T, ts *TestServer, reqBody map[string]interface{}) int64 {
+	w := postJSON(ts, "/subscriptions/create", reqBody)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	var response map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	subscription := response["subscription"].(map[string]interface{})
+	return int64(subscription["id"].(float64))
+}
+
+func getDeliveries(t *testing.T, ts *TestServer, subscriptionId int64, status string) []map[string]interface{} {
+	path := "/subscriptions/deliveries?subscription_id=" + strconv.FormatInt(subscriptionId, 10)
+	if status != "" {
+		path += "&status=" + status
+	}
+	req := httptest.NewRequest("GET", path, nil)
+	w := httptest.NewRecorder()
+	ts.Server.Handler.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var response struct {
+		Deliveries []map[string]interface{} `json:"deliveries"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	return response.Deliveries
+}
+
+func TestSubscriptions_DeliversSignedEvents(t *testing.T) {
+	ts, err := SetupTestServerWithSubscriptions(t, outbox.DeliveryOptions{PollInterval: 20 * time.Millisecond})
+	require.NoError(t, err)
+	defer ts.Close()
+	setupSubscriptionData(t, ts)
+
+	backend := newSubscriptionReceiver(t)
+	frontend := newSubscriptionReceiver(t)
+
+	backendId := createSubscription(t, ts, map[string]interface{}{
+		"url":         backend.URL,
+		"event_types": []string{event.TypePullRequestCreated, event.TypeReviewerAssigned},
+		"team_name":   "backend",
+		"secret":      testSubscriptionSecret,
+	})
+	createSubscription(t, ts, map[string]interface{}{
+		"url":         frontend.URL,
+		"event_types": []string{event.TypePullRequestCreated},
+		"team_name":   "frontend",
+	})
+
+	w := postJSON(ts, "/pullRequest/create", map[string]interface{}{
+		"pull_request_id":   "pr-1",
+		"pull_request_name": "Add feature",
+		"author_id":         "u1",
+	})
+	require.Equal(t, http.StatusCreated, w.Code)
+
+	require.Eventually(t, func() bool {
+		return len(backend.Requests()) == 3
+	}, 5*time.Second, 20*time.Millisecond)
+
+	reviewers := make([]string, 0)
+	for _, req := range backend.Requests() {
+		assert.Equal(t, outbox.Sign(testSubscriptionSecret, req.Body), req.Header.Get(outbox.HeaderSignature))
+		assert.NotEmpty(t, req.Header.Get(outbox.HeaderDelivery))
+
+		var e event.Event
+		require.NoError(t, json.Unmarshal(req.Body, &e))
+		assert.Equal(t, e.Type, req.Header.Get(outbox.HeaderEvent))
+		assert.Equal(t, "pr-1", e.AggregateId)
+		assert.Equal(t, "backend", e.TeamName)
+
+		if e.Type == event.TypeReviewerAssigned {
+			var payload event.ReviewerAssignedPayload
+			require.NoError(t, json.Unmarshal(e.Payload, &payload))
+			reviewers = append(reviewers, payload.ReviewerId)
+		}
+	}
+	assert.ElementsMatch(t, []string{"u2", "u3"}, reviewers)
+	assert.Empty(t, frontend.Requests())
+
+	deliveries := getDeliveries(t, ts, backendId, "delivered")
+	assert.Len(t, deliveries, 3)
+	assert.Equal(t, float64(http.StatusNoContent), deliveries[0]["last_status_code"])
+}
+
+func TestSubscriptions_DeadLetterAndRetry(t *testing.T) {
+	ts, err := SetupTestServerWithSubscriptions(t, outbox.DeliveryOptions{
+		PollInterval:   20 * time.Millisecond,
+		MaxAttempts:    3,
+		InitialBackoff: 10 * time.Millisecond,
+		MaxBackoff:     50 * time.Millisecond,
+	})
+	require.NoError(t, err)
+	defer ts.Close()
+	setupSubscriptionData(t, ts)
+
+	receiver := newSubscriptionReceiver(t)
+	receiver.fail.Store(true)
+
+	subscriptionId := createSubscription(t, ts, map[string]interface{}{
+		"url":         receiver.URL,
+		"event_types": []string{event.TypePullRequestMerged},
+	})
+
+	w := postJSON(ts, "/pullRequest/create", map[string]interface{}{
+		"pull_request_id":   "pr-1",
+		"pull_request_name": "Add feature",
+		"author_id":         "f1",
+	})
+	require.Equal(t, http.StatusCreated, w.Code)
+
+	w = postJSON(ts, "/pullRequest/merge", map[string]interface{}{"pull_request_id": "pr-1"})
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var dead []map[string]interface{}
+	require.Eventually(t, func() bool {
+		dead = getDeliveries(t, ts, subscriptionId, "dead")
+		return len(dead) == 1
+	}, 5*time.Second, 20*time.Millisecond)
+
+	assert.Equal(t, float64(3), dead[0]["attempts"])
+	assert.Equal(t, float64(http.StatusInternalServerError), dead[0]["last_status_code"])
+	assert.Equal(t, event.TypePullRequestMerged, dead[0]["event_type"])
+	assert.Len(t, receiver.Requests(), 3)
+
+	receiver.fail.Store(false)
+
+	w = postJSON(ts, "/subscriptions/deliveries/retry", map[string]interface{}{"id": dead[0]["id"]})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	require.Eventually(t, func() bool {
+		return len(getDeliveries(t, ts, subscriptionId, "delivered")) == 1
+	}, 5*time.Second, 20*time.Millisecond)
+	assert.Len(t, receiver.Requests(), 4)
+}
+
+func TestSubscriptions_Validation(t *testing.T) {
+	ts, err := SetupTestServer(t)
+	require.NoError(t, err)
+	defer ts.Close()
+	setupSubscriptionData(t, ts)
+
+	w := postJSON(ts, "/subscriptions/create", map[string]interface{}{
+		"url":         "https://example.com/hook",
+		"event_types": []string{"pr.exploded"},
+	})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "INVALID_EVENT_TYPE")
+
+	w = postJSON(ts, "/subscriptions/create", map[string]interface{}{
+		"url":         "https://example.com/hook",
+		"event_types": []string{event.TypePullRequestCreated},
+		"team_name":   "missing",
+	})
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	w = postJSON(ts, "/subscriptions/create", map[string]interface{}{
+		"url":         "not a url",
+		"event_types": []string{event.TypePullRequestCreated},
+	})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = postJSON(ts, "/subscriptions/create", map[string]interface{}{
+		"url":         "https://example.com/hook",
+		"event_types": []string{event.TypePullRequestCreated},
+	})
+	require.Equal(t, http.StatusCreated, w.Code)
+	assert.Contains(t, w.Body.String(), `"secret":"whsec_`)
+
+	w = postJSON(ts, "/subscriptions/delete", map[string]interface{}{"id": 999})
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestSubscriptions_RejectsInternalTargets(t *testing.T) {
+	ts, err := SetupTestServer(t)
+	require.NoError(t, err)
+	defer ts.Close()
+	setupSubscriptionData(t, ts)
+
+	tests := []struct {
+		name string
+		url  string
+	}{
+		{name: "scheme", url: "ftp://example.com/hook"},
+		{name: "loopback ipv6", url: "http://[::1]:8080/hook"},
+		{name: "metadata", url: "http://169.254.169.254/latest/meta-data"},
+		{name: "private", url: "https://10.1.2.3/hook"},
+		{name: "unspecified", url: "http://0.0.0.0:8080/hook"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := postJSON(ts, "/subscriptions/create", map[string]interface{}{
+				"url":         tt.url,
+				"event_types": []string{event.TypePullRequestCreated},
+			})
+			assert.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+			assert.Contains(t, w.Body.String(), "INVALID_URL")
+		})
+	}
+
+	// 127.0.0.0/8 разрешена в subscriptions.allowed_targets тестового сервера
+	w := postJSON(ts, "/subscriptions/create", map[string]interface{}{
+		"url":         "http://127.0.0.1:8080/hook",
+		"event_types": []string{event.TypePullRequestCreated},
+	})
+	assert.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+}
@@ -0,0 +1,182 @@
+package integration
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type errorBody struct {
+	Error struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+		Details []struct {
+			Field   string `json:"field"`
+			Message string `json:"message"`
+		} `json:"details"`
+	} `json:"error"`
+}
+
+func decodeError(t *testing.T, w *httptest.ResponseRecorder) errorBody {
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+
+	var body errorBody
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body), w.Body.String())
+	return body
+}
+
+func TestAPIv1_VersionedAndLegacyRoutes(t *testing.T) {
+	ts, err := SetupTestServer(t)
+	require.NoError(t, err)
+	defer ts.Close()
+
+	w := postJSON(ts, "/api/v1/team/add", map[string]interface{}{
+		"team": map[string]interface{}{
+			"team_name": "backend",
+			"members": []map[string]interface{}{
+				{"user_id": "u1", "username": "Alice", "is_active": true},
+				{"user_id": "u2", "username": "Bob", "is_active": true},
+			},
+		},
+	})
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	assert.Empty(t, w.Header().Get("Deprecation"))
+
+	req := httptest.NewRequest("GET", "/team/get?team_name=backend", nil)
+	w = httptest.NewRecorder()
+	ts.Server.Handler.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "true", w.Header().Get("Deprecation"))
+	assert.Equal(t, `</api/v1/team/get>; rel="successor-version"`, w.Header().Get("Link"))
+
+	// Ошибки по старым путям тоже помечены устаревшими
+	w = postJSON(ts, "/pullRequest/merge", map[string]interface{}{"pull_request_id": "missing"})
+	require.Equal(t, http.StatusNotFound, w.Code)
+	assert.Equal(t, "true", w.Header().Get("Deprecation"))
+	assert.Equal(t, "NOT_FOUND", decodeError(t, w).Error.Code)
+}
+
+func TestAPIv1_LegacyRoutesDisabled(t *testing.T) {
+	ts, err := SetupTestServerWithoutLegacyRoutes(t)
+	require.NoError(t, err)
+	defer ts.Close()
+
+	req := httptest.NewRequest("GET", "/team/get?team_name=backend", nil)
+	w := httptest.NewRecorder()
+	ts.Server.Handler.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusNotFound, w.Code)
+	assert.Equal(t, "NOT_FOUND", decodeError(t, w).Error.Code)
+
+	req = httptest.NewRequest("GET", "/api/v1/team/get?team_name=backend", nil)
+	w = httptest.NewRecorder()
+	ts.Server.Handler.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusNotFound, w.Code)
+	body := decodeError(t, w)
+	assert.Equal(t, "NOT_FOUND", body.Error.Code)
+	assert.Equal(t, "team not found", body.Error.Message)
+}
+
+func TestAPIv1_ValidationErrorDetails(t *testing.T) {
+	ts, err := SetupTestServer(t)
+	require.NoError(t, err)
+	defer ts.Close()
+
+	w := postJSON(ts, "/api/v1/pullRequest/create", map[string]interface{}{
+		"pull_request_id": "pr-1",
+	})
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	body := decodeError(t, w)
+	assert.Equal(t, "VALIDATION_ERROR", body.Error.Code)
+
+	fields := make(map[string]string)
+	for _, detail := range body.Error.Details {
+		fields[detail.Field] = detail.Message
+	}
+	assert.Equal(t, map[string]string{
+		"pull_request_name": "is required",
+		"author_id":         "is required",
+	}, fields)
+
+	// Ошибки разбора тела не относятся к полям и details не содержат
+	req := httptest.NewRequest("POST", "/api/v1/pullRequest/create", nil)
+	rec := httptest.NewRecorder()
+	ts.Server.Handler.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	body = decodeError(t, rec)
+	assert.Equal(t, "INVALID_REQUEST", body.Error.Code)
+	assert.Empty(t, body.Error.Details)
+}
+
+func TestAPIv1_ErrorStatusesAreConsistent(t *testing.T) {
+	ts, err := SetupTestServer(t)
+	require.NoError(t, err)
+	defer ts.Close()
+
+	setupStreamData(t, ts)
+	createPR(t, ts, "pr-1", "u1")
+
+	w := postJSON(ts, "/api/v1/pullRequest/merge", map[string]interface{}{"pull_request_id": "pr-1"})
+	require.Equal(t, http.StatusOK, w.Code)
+
+	// PR_MERGED и PR_EXISTS - конфликт состояния, одинаково для всех маршрутов
+	w = postJSON(ts, "/api/v1/pullRequest/reassign", map[string]interface{}{
+		"pull_request_id": "pr-1",
+		"old_reviewer_id": "u2",
+	})
+	require.Equal(t, http.StatusConflict, w.Code)
+	assert.Equal(t, "PR_MERGED", decodeError(t, w).Error.Code)
+
+	w = postJSON(ts, "/api/v1/pullRequest/create", map[string]interface{}{
+		"pull_request_id":   "pr-1",
+		"pull_request_name": "again",
+		"author_id":         "u1",
+	})
+	require.Equal(t, http.StatusConflict, w.Code)
+	assert.Equal(t, "PR_EXISTS", decodeError(t, w).Error.Code)
+
+	w = postJSON(ts, "/api/v1/users/setIsActive", map[string]interface{}{
+		"user_id":   "missing",
+		"is_active": false,
+	})
+	require.Equal(t, http.StatusNotFound, w.Code)
+	assert.Equal(t, "NOT_FOUND", decodeError(t, w).Error.Code)
+}
+
+func TestAPIv1_MergedAtFieldName(t *testing.T) {
+	ts, err := SetupTestServer(t)
+	require.NoError(t, err)
+	defer ts.Close()
+
+	setupStreamData(t, ts)
+	createPR(t, ts, "pr-1", "u1")
+
+	mergedPR := func(path string) map[string]interface{} {
+		w := postJSON(ts, path, map[string]interface{}{"pull_request_id": "pr-1"})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var resp struct {
+			PR map[string]interface{} `json:"pr"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		return resp.PR
+	}
+
+	pr := mergedPR("/api/v1/pullRequest/merge")
+	assert.NotNil(t, pr["merged_at"])
+	assert.NotContains(t, pr, "mergedAt")
+
+	// Устаревший путь сохраняет прежнее имя поля
+	pr = mergedPR("/pullRequest/merge")
+	assert.NotNil(t, pr["mergedAt"])
+	assert.NotContains(t, pr, "merged_at")
+}
@@ -0,0 +1,177 @@
+package integration
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testWebhookSecret = "test-webhook-secret"
+
+func postGitHubFixture(t *testing.T, ts *TestServer, fixture string, deliveryId string, secret string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	body, err := os.ReadFile(filepath.Join("testdata", "github", fixture))
+	require.NoError(t, err)
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+
+	req := httptest.NewRequest("POST", "/webhooks/github", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-GitHub-Event", "pull_request")
+	req.Header.Set("X-GitHub-Delivery", deliveryId)
+	req.Header.Set("X-Hub-Signature-256", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	w := httptest.NewRecorder()
+
+	ts.Server.Handler.ServeHTTP(w, req)
+	return w
+}
+
+func setupWebhookData(t *testing.T, ts *TestServer) {
+	t.Helper()
+
+	ctx := context.Background()
+	_, err := ts.Storage.Db.Exec(ctx, `
+		INSERT INTO team (name) VALUES ('backend');
+		INSERT INTO users (user_id, username, team_name, is_active) VALUES 
+			('u1', 'Alice', 'backend', true),
+			('u2', 'Bob', 'backend', true),
+			('u3', 'Charlie', 'backend', true);
+	`)
+	require.NoError(t, err)
+
+	body, _ := json.Marshal(map[string]interface{}{
+		"provider": "github",
+		"login":    "alice-gh",
+		"user_id":  "u1",
+	})
+	req := httptest.NewRequest("POST", "/admin/identities/set", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	ts.Server.Handler.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+}
+
+func decodeWebhookResponse(t *testing.T, w *httptest.ResponseRecorder) map[string]interface{} {
+	t.Helper()
+
+	var response map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	return response
+}
+
+func TestGitHubWebhook_OpenedAndMerged(t *testing.T) {
+	ts, err := SetupTestServerWithWebhooks(t, testWebhookSecret, testWebhookSecret)
+	require.NoError(t, err)
+	defer ts.Close()
+
+	setupWebhookData(t, ts)
+
+	w := postGitHubFixture(t, ts, "pull_request_opened.json", "delivery-1", testWebhookSecret)
+	require.Equal(t, http.StatusOK, w.Code)
+	response := decodeWebhookResponse(t, w)
+	assert.Equal(t, "processed", response["status"])
+	assert.Equal(t, "acme/backend#42", response["pull_request_id"])
+
+	pr, err := ts.Storage.GetPullRequestById(context.Background(), "acme/backend#42")
+	require.NoError(t, err)
+	assert.Equal(t, "u1", pr.AuthorId)
+	assert.Equal(t, "Add reviewer statistics endpoint", pr.PullRequestName)
+	assert.Len(t, pr.AssignedReviewers, 2)
+
+	// Повторная доставка не меняет PR
+	w = postGitHubFixture(t, ts, "pull_request_opened.json", "delivery-1", testWebhookSecret)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "duplicate", decodeWebhookResponse(t, w)["status"])
+
+	w = postGitHubFixture(t, ts, "pull_request_closed_merged.json", "delivery-2", testWebhookSecret)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "processed", decodeWebhookResponse(t, w)["status"])
+
+	pr, err = ts.Storage.GetPullRequestById(context.Background(), "acme/backend#42")
+	require.NoError(t, err)
+	assert.Equal(t, "MERGED", pr.Status)
+	assert.NotNil(t, pr.MergedAt)
+}
+
+func TestGitHubWebhook_DraftThenReadyThenClosed(t *testing.T) {
+	ts, err := SetupTestServerWithWebhooks(t, testWebhookSecret, testWebhookSecret)
+	require.NoError(t, err)
+	defer ts.Close()
+
+	setupWebhookData(t, ts)
+
+	w := postGitHubFixture(t, ts, "pull_request_opened_draft.json", "delivery-1", testWebhookSecret)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "ignored", decodeWebhookResponse(t, w)["status"])
+
+	_, err = ts.Storage.GetPullRequestById(context.Background(), "acme/backend#42")
+	require.Error(t, err)
+
+	w = postGitHubFixture(t, ts, "pull_request_ready_for_review.json", "delivery-2", testWebhookSecret)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "processed", decodeWebhookResponse(t, w)["status"])
+
+	w = postGitHubFixture(t, ts, "pull_request_closed.json", "delivery-3", testWebhookSecret)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	pr, err := ts.Storage.GetPullRequestById(context.Background(), "acme/backend#42")
+	require.NoError(t, err)
+	assert.Equal(t, "CLOSED", pr.Status)
+	assert.Nil(t, pr.MergedAt)
+
+	// Переназначение на закрытом PR запрещено
+	body, _ := json.Marshal(map[string]interface{}{
+		"pull_request_id": "acme/backend#42",
+		"old_reviewer_id": pr.AssignedReviewers[0],
+	})
+	req := httptest.NewRequest("POST", "/pullRequest/reassign", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rw := httptest.NewRecorder()
+	ts.Server.Handler.ServeHTTP(rw, req)
+	assert.Equal(t, http.StatusConflict, rw.Code)
+}
+
+func TestGitHubWebhook_UnknownLoginIsIgnored(t *testing.T) {
+	ts, err := SetupTestServerWithWebhooks(t, testWebhookSecret, testWebhookSecret)
+	require.NoError(t, err)
+	defer ts.Close()
+
+	w := postGitHubFixture(t, ts, "pull_request_opened.json", "delivery-1", testWebhookSecret)
+	require.Equal(t, http.StatusOK, w.Code)
+	response := decodeWebhookResponse(t, w)
+	assert.Equal(t, "ignored", response["status"])
+	assert.Contains(t, response["reason"], "alice-gh")
+
+	// Пропущенная доставка не записывается и обрабатывается после настройки логина
+	setupWebhookData(t, ts)
+
+	w = postGitHubFixture(t, ts, "pull_request_opened.json", "delivery-1", testWebhookSecret)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "processed", decodeWebhookResponse(t, w)["status"])
+}
+
+func TestGitHubWebhook_InvalidSignature(t *testing.T) {
+	ts, err := SetupTestServerWithWebhooks(t, testWebhookSecret, testWebhookSecret)
+	require.NoError(t, err)
+	defer ts.Close()
+
+	w := postGitHubFixture(t, ts, "pull_request_opened.json", "delivery-1", "wrong-secret")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	errorObj, ok := decodeWebhookResponse(t, w)["error"].(map[string]interface{})
+	require.True(t, ok)
+	assert.Equal(t, "INVALID_SIGNATURE", errorObj["code"])
+}
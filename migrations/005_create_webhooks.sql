@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS git_identities (
+    id BIGSERIAL PRIMARY KEY,
+    provider VARCHAR(32) NOT NULL,
+    login VARCHAR(255) NOT NULL,
+    user_id VARCHAR(255) NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    UNIQUE (provider, login)
+);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    provider VARCHAR(32) NOT NULL,
+    delivery_id VARCHAR(255) NOT NULL,
+    event VARCHAR(64) NOT NULL,
+    received_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (provider, delivery_id)
+);
@@ -0,0 +1,3 @@
+ALTER TABLE users ADD COLUMN IF NOT EXISTS chat_handle VARCHAR(255) NOT NULL DEFAULT '';
+
+ALTER TABLE team ADD COLUMN IF NOT EXISTS chat_webhook_url TEXT NOT NULL DEFAULT '';
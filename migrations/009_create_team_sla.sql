@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS team_sla (
+    team_name VARCHAR(255) PRIMARY KEY REFERENCES team(name) ON DELETE CASCADE,
+    review_hours INT NOT NULL CHECK (review_hours > 0),
+    working_days_only BOOLEAN NOT NULL DEFAULT true,
+    auto_reassign BOOLEAN NOT NULL DEFAULT false,
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE pr_reviewers ADD COLUMN IF NOT EXISTS assigned_at TIMESTAMP NOT NULL DEFAULT NOW();
+ALTER TABLE pr_reviewers ADD COLUMN IF NOT EXISTS sla_breached_at TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS idx_pr_reviewers_sla_pending ON pr_reviewers(assigned_at) WHERE sla_breached_at IS NULL;
@@ -0,0 +1,2 @@
+ALTER TABLE team ADD COLUMN IF NOT EXISTS default_max_open_reviews INT CHECK (default_max_open_reviews > 0);
+ALTER TABLE users ADD COLUMN IF NOT EXISTS max_open_reviews INT CHECK (max_open_reviews > 0);
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS code_owner_rules (
+    id BIGSERIAL PRIMARY KEY,
+    team_name VARCHAR(255) NOT NULL REFERENCES team(name) ON DELETE CASCADE,
+    position INT NOT NULL,
+    pattern TEXT NOT NULL,
+    owners TEXT[] NOT NULL,
+    UNIQUE (team_name, position)
+);
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS repositories (
+    id BIGSERIAL PRIMARY KEY,
+    host VARCHAR(255) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    team_name VARCHAR(255) NOT NULL REFERENCES team(name) ON DELETE CASCADE,
+    reviewers_count INT CHECK (reviewers_count > 0),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE (host, name)
+);
+
+CREATE INDEX IF NOT EXISTS idx_repositories_team_name ON repositories(team_name);
+
+ALTER TABLE pull_requests ADD COLUMN IF NOT EXISTS repository_id BIGINT REFERENCES repositories(id);
+ALTER TABLE pull_requests ADD COLUMN IF NOT EXISTS number BIGINT;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_pull_requests_repository_number ON pull_requests(repository_id, number) WHERE repository_id IS NOT NULL;
@@ -0,0 +1,8 @@
+ALTER TABLE users ADD COLUMN IF NOT EXISTS seniority VARCHAR(16) CHECK (seniority IN ('junior', 'middle', 'senior', 'lead'));
+
+CREATE TABLE IF NOT EXISTS team_composition_rules (
+    team_name VARCHAR(255) NOT NULL REFERENCES team(name) ON DELETE CASCADE,
+    min_seniority VARCHAR(16) NOT NULL CHECK (min_seniority IN ('junior', 'middle', 'senior', 'lead')),
+    min_count INT NOT NULL CHECK (min_count > 0),
+    PRIMARY KEY (team_name, min_seniority)
+);
@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS reviewer_exclusions (
+    team_name VARCHAR(255) NOT NULL REFERENCES team(name) ON DELETE CASCADE,
+    user_id VARCHAR(255) NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    other_user_id VARCHAR(255) NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    PRIMARY KEY (team_name, user_id, other_user_id),
+    CHECK (user_id < other_user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_reviewer_exclusions_other ON reviewer_exclusions(other_user_id);
+
+CREATE TABLE IF NOT EXISTS team_repeat_limits (
+    team_name VARCHAR(255) PRIMARY KEY REFERENCES team(name) ON DELETE CASCADE,
+    max_reviews INT NOT NULL CHECK (max_reviews > 0),
+    window_days INT NOT NULL CHECK (window_days > 0)
+);
+
+CREATE INDEX IF NOT EXISTS idx_pr_reviewers_user_assigned ON pr_reviewers(user_id, assigned_at);
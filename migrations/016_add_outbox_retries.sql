@@ -0,0 +1,5 @@
+ALTER TABLE outbox ADD COLUMN IF NOT EXISTS next_attempt_at TIMESTAMP NOT NULL DEFAULT NOW();
+ALTER TABLE outbox ADD COLUMN IF NOT EXISTS claimed_until TIMESTAMP;
+ALTER TABLE outbox ADD COLUMN IF NOT EXISTS failed_at TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS idx_outbox_pending ON outbox(aggregate_type, aggregate_id, id) WHERE sent_at IS NULL AND failed_at IS NULL;
@@ -0,0 +1,3 @@
+ALTER TABLE git_identities ADD COLUMN IF NOT EXISTS external_id VARCHAR(64);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_git_identities_external_id ON git_identities(provider, external_id) WHERE external_id IS NOT NULL;